@@ -14,6 +14,7 @@ import (
 	"go_gin/internal/routes"
 	"go_gin/internal/service"
 	"go_gin/pkg/shutdown"
+	"go_gin/pkg/storage"
 	"log"
 	"net/http"
 	"time"
@@ -41,14 +42,89 @@ import (
 func main() {
 	pgstore := db.NewPGStore(config.Database)
 	dbs, _ := pgstore.Connect()
+	blobs, err := storage.New(config.Storage)
+	if err != nil {
+		log.Fatalf("storage: %s\n", err)
+	}
 	validation := validator.New()
 	repositoryTodolist := repository.NewTodolistRepository()
 	repositoryUser := repository.NewUsersRepository()
+	repositoryComment := repository.NewCommentRepository()
+	repositoryActivity := repository.NewActivityRepository()
+	repositoryNotification := repository.NewNotificationRepository()
+	repositoryAttachment := repository.NewAttachmentRepository()
+	repositoryProject := repository.NewProjectRepository()
+	repositoryShare := repository.NewShareRepository()
+	repositoryAssignment := repository.NewAssignmentRepository()
+	repositoryDependency := repository.NewDependencyRepository()
+	repositoryTag := repository.NewTagRepository()
+	repositorySavedSearch := repository.NewSavedSearchRepository()
+	repositoryView := repository.NewViewRepository()
+	repositoryBoard := repository.NewBoardRepository()
+	repositoryTemplate := repository.NewTemplateRepository()
+	repositoryTimeEntry := repository.NewTimeEntryRepository()
+	repositoryRevision := repository.NewRevisionRepository()
+	repositoryCalendar := repository.NewCalendarRepository()
+	repositoryCalDAV := repository.NewCalDAVRepository()
+	repositoryStatistics := repository.NewStatisticsRepository()
+	todolistAccess := service.NewTodoListAccess(repositoryTodolist, repositoryShare, repositoryProject)
 	serviceUser := service.NewUsersService(dbs, repositoryUser, validation)
-	serviceTodolist := service.NewTodoListService(dbs, validation, repositoryTodolist)
+	serviceTodolist := service.NewTodoListService(dbs, validation, repositoryTodolist, repositoryActivity, repositoryAttachment, blobs, todolistAccess, repositoryProject, repositoryAssignment, repositoryUser, repositoryNotification, repositoryDependency, repositorySavedSearch, repositoryRevision)
+	serviceComment := service.NewCommentService(dbs, validation, repositoryComment, todolistAccess, repositoryActivity, repositoryNotification, repositoryUser)
+	serviceNotification := service.NewNotificationService(dbs, validation, repositoryNotification)
+	serviceAttachment := service.NewAttachmentService(dbs, validation, repositoryAttachment, todolistAccess, blobs)
+	serviceDependency := service.NewDependencyService(dbs, validation, repositoryDependency, repositoryTodolist, todolistAccess)
+	serviceProject := service.NewProjectService(dbs, validation, repositoryProject)
+	serviceShare := service.NewShareService(dbs, validation, repositoryShare, repositoryTodolist, repositoryProject, repositoryNotification, repositoryUser)
+	serviceTag := service.NewTagService(dbs, validation, repositoryTag, todolistAccess)
+	serviceSavedSearch := service.NewSavedSearchService(dbs, validation, repositorySavedSearch)
+	serviceView := service.NewViewService(dbs, validation, repositoryView, repositoryTodolist, repositoryUser)
+	serviceBoard := service.NewBoardService(dbs, validation, repositoryBoard, serviceTodolist)
+	serviceTemplate := service.NewTemplateService(dbs, validation, repositoryTemplate, serviceTodolist)
+	serviceTime := service.NewTimeService(dbs, validation, repositoryTimeEntry, repositoryTodolist, todolistAccess)
+	serviceCalendar := service.NewCalendarService(dbs, validation, repositoryCalendar, repositoryTodolist)
+	serviceQuickAdd := service.NewQuickAddService(dbs, validation, serviceTodolist, repositoryTag)
+	serviceCalDAV := service.NewCalDAVService(dbs, validation, repositoryCalDAV, repositoryTodolist, serviceTodolist)
+	serviceStatistics := service.NewStatisticsService(dbs, validation, repositoryStatistics)
 	controllerUser := controller.NewUsersController(serviceUser)
 	controllerTodolist := controller.NewTodoListController(serviceTodolist)
-	router := routes.Routes{Controller: controllerUser, Middleware: &middleware.Middleware{Repository: repositoryUser, DB: dbs}, TodoList: controllerTodolist}
+	controllerComment := controller.NewCommentController(serviceComment)
+	controllerNotification := controller.NewNotificationController(serviceNotification)
+	controllerAttachment := controller.NewAttachmentController(serviceAttachment)
+	controllerProject := controller.NewProjectController(serviceProject)
+	controllerShare := controller.NewShareController(serviceShare)
+	controllerDependency := controller.NewDependencyController(serviceDependency)
+	controllerTag := controller.NewTagController(serviceTag)
+	controllerSavedSearch := controller.NewSavedSearchController(serviceSavedSearch)
+	controllerView := controller.NewViewController(serviceView)
+	controllerBoard := controller.NewBoardController(serviceBoard)
+	controllerTemplate := controller.NewTemplateController(serviceTemplate)
+	controllerTime := controller.NewTimeController(serviceTime)
+	controllerCalendar := controller.NewCalendarController(serviceCalendar)
+	controllerQuickAdd := controller.NewQuickAddController(serviceQuickAdd)
+	controllerCalDAV := controller.NewCalDAVController(serviceCalDAV)
+	controllerStatistics := controller.NewStatisticsController(serviceStatistics)
+	router := routes.Routes{
+		Controller:   controllerUser,
+		Middleware:   &middleware.Middleware{Repository: repositoryUser, DB: dbs},
+		TodoList:     controllerTodolist,
+		Comment:      controllerComment,
+		Notification: controllerNotification,
+		Attachment:   controllerAttachment,
+		Project:      controllerProject,
+		Share:        controllerShare,
+		Dependency:   controllerDependency,
+		Tag:          controllerTag,
+		SavedSearch:  controllerSavedSearch,
+		View:         controllerView,
+		Board:        controllerBoard,
+		Template:     controllerTemplate,
+		Time:         controllerTime,
+		Calendar:     controllerCalendar,
+		CalDAV:       controllerCalDAV,
+		QuickAdd:     controllerQuickAdd,
+		Statistics:   controllerStatistics,
+	}
 	srv := &http.Server{
 		Addr:    fmt.Sprintf(":%s", config.Server.Port),
 		Handler: router.Run(), //type gin.RouterGroup
@@ -61,6 +137,7 @@ func main() {
 	}()
 
 	wait := shutdown.ShutDown(context.Background(), 2*time.Second, map[string]model.Operation{
+		"trash-purge": purgeTrash(serviceTodolist),
 		"http-server": func(ctx context.Context) error {
 			return srv.Shutdown(context.Background())
 		},
@@ -71,3 +148,35 @@ func main() {
 	})
 	<-wait
 }
+
+// purgeTrash deletes for good the todolists trashed more than config.Trash.RetentionDays ago, every
+// config.Trash.PurgeInterval minutes. The returned operation stops it.
+func purgeTrash(todolists *service.TodoListService) model.Operation {
+	if config.Trash.RetentionDays <= 0 || config.Trash.PurgeInterval <= 0 {
+		return func(ctx context.Context) error {
+			return nil
+		}
+	}
+	ticker := time.NewTicker(time.Duration(config.Trash.PurgeInterval) * time.Minute)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case now := <-ticker.C:
+				purged, err := todolists.PurgeTrash(context.Background(), now.AddDate(0, 0, -config.Trash.RetentionDays))
+				if err != nil {
+					log.Printf("trash purge: %s\n", err)
+				} else if purged > 0 {
+					log.Printf("trash purge: %d todolists purged\n", purged)
+				}
+			}
+		}
+	}()
+	return func(ctx context.Context) error {
+		ticker.Stop()
+		close(done)
+		return nil
+	}
+}
@@ -28,13 +28,21 @@ type OTH struct {
 	BatchSize   int    `mapstructure:"batch_size"`
 	LimitInsert int    `mapstructure:"limit_insert"`
 	Limit       int    `mapstructure:"limit"`
+	MaxLimit    int    `mapstructure:"max_limit"`
+	// RequireIfMatch rejects writes to versioned resources that do not send an If-Match header
+	RequireIfMatch bool `mapstructure:"require_if_match"`
+	// MaxImportSize caps the body of a todolist import in bytes, 0 leaves it unbounded
+	MaxImportSize int64 `mapstructure:"max_import_size"`
 }
 
 type CFG struct {
-	Server   *SRV   `mapstructure:"server"`
-	Database *DB    `mapstructure:"database"`
-	Other    *OTH   `mapstructure:"other"`
-	JWT      *TOKEN `mapstructure:"jwt"`
+	Server   *SRV      `mapstructure:"server"`
+	Database *DB       `mapstructure:"database"`
+	Other    *OTH      `mapstructure:"other"`
+	JWT      *TOKEN    `mapstructure:"jwt"`
+	Workflow *WORKFLOW `mapstructure:"workflow"`
+	Storage  *STORAGE  `mapstructure:"storage"`
+	Trash    *TRASH    `mapstructure:"trash"`
 }
 
 type TOKEN struct {
@@ -43,11 +51,43 @@ type TOKEN struct {
 	SecretKey string `mapstructure:"secret_key"`
 }
 
+type WORKFLOW struct {
+	Initial     string              `mapstructure:"initial"`
+	Start       string              `mapstructure:"start"`
+	Done        string              `mapstructure:"done"`
+	Statuses    []string            `mapstructure:"statuses"`
+	Transitions map[string][]string `mapstructure:"transitions"`
+}
+
+type STORAGE struct {
+	Driver       string   `mapstructure:"driver"`
+	Path         string   `mapstructure:"path"`
+	MaxSize      int64    `mapstructure:"max_size"`
+	AllowedTypes []string `mapstructure:"allowed_types"`
+	Endpoint     string   `mapstructure:"endpoint"`
+	Region       string   `mapstructure:"region"`
+	Bucket       string   `mapstructure:"bucket"`
+	AccessKey    string   `mapstructure:"access_key"`
+	SecretKey    string   `mapstructure:"secret_key"`
+	PathStyle    bool     `mapstructure:"path_style"`
+}
+
+// TRASH keeps deleted todolists for RetentionDays before purging them, checked every PurgeInterval minutes, and
+// lets a delete be undone for UndoMinutes. A RetentionDays of 0 keeps the trash until it is emptied by hand.
+type TRASH struct {
+	RetentionDays int `mapstructure:"retention_days"`
+	PurgeInterval int `mapstructure:"purge_interval"`
+	UndoMinutes   int `mapstructure:"undo_minutes"`
+}
+
 var (
 	Database *DB
 	Server   *SRV
 	Other    *OTH
 	JWT      *TOKEN
+	Workflow *WORKFLOW
+	Storage  *STORAGE
+	Trash    *TRASH
 )
 
 func init() {
@@ -66,4 +106,16 @@ func init() {
 	Server = config.Server
 	Other = config.Other
 	JWT = config.JWT
+	Workflow = config.Workflow
+	if Workflow == nil {
+		Workflow = &WORKFLOW{}
+	}
+	Storage = config.Storage
+	if Storage == nil {
+		Storage = &STORAGE{}
+	}
+	Trash = config.Trash
+	if Trash == nil {
+		Trash = &TRASH{}
+	}
 }
@@ -0,0 +1,180 @@
+package controller
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"go_gin/internal/config"
+	"go_gin/internal/domain/model"
+	"go_gin/internal/domain/model/web"
+	"go_gin/internal/handler"
+	"mime"
+	"net/http"
+)
+
+type AttachmentController struct {
+	Service model.AttachmentService
+}
+
+func NewAttachmentController(service model.AttachmentService) *AttachmentController {
+	return &AttachmentController{Service: service}
+}
+
+// GetAttachments godoc
+// @Summary Get Attachments of a Todolist
+// @Description Retrieve the attachments metadata of a Todolist as JSON
+// @Tags Attachment
+// @Param id	path	string	true "Must Be UUID Format"
+// @Param id	query	int		true "ID todolist"
+// @Produce json
+// @Success	200	{object}	web.StandartResponse
+// @Failure 400 {object} 	handler.ResponseErrors "Bad request"
+// @Failure 401 {object} 	handler.ResponseErrors "Unauthorized"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Router  /user/{id}/todolist/attachments [get]
+func (a *AttachmentController) GetAttachments(c *gin.Context) {
+	var query web.TodoListByIDQuery
+	ctx := context.Background()
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	responses, errService := a.Service.FindAttachments(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly get attachments", map[string]interface{}{
+		"attachments": responses,
+	}))
+}
+
+// CreateAttachment godoc
+// @Summary Upload Attachment to a Todolist
+// @Description Upload a file as multipart form, size and type are limited by configuration
+// @Tags Attachment
+// @Accept	multipart/form-data
+// @Param id	path	string	true "Must Be UUID Format"
+// @Param id	query	int		true "ID todolist"
+// @Param file	formData	file	true "File to attach"
+// @Produce json
+// @Success	200	{object}	web.StandartResponse
+// @Failure 400 {object} 	handler.ResponseErrors "Bad request"
+// @Failure 401 {object} 	handler.ResponseErrors "Unauthorized"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Router  /user/{id}/todolist/attachment [post]
+func (a *AttachmentController) CreateAttachment(c *gin.Context) {
+	var query web.TodoListByIDQuery
+	ctx := context.Background()
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	if config.Storage.MaxSize > 0 {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, config.Storage.MaxSize+1<<20)
+	}
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": err.Error(),
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	response, errService := a.Service.CreateAttachment(ctx, file, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly upload attachment", map[string]interface{}{
+		"attachment": response,
+	}))
+}
+
+// DownloadAttachment godoc
+// @Summary Download Attachment
+// @Description Download the file of an attachment, supports Range requests
+// @Tags Attachment
+// @Param id	path	string	true "Must Be UUID Format"
+// @Param id	query	int		true "ID todolist"
+// @Param attachment_id	query	int		true "ID attachment"
+// @Param Range	header	string	false "Byte range"
+// @Produce octet-stream
+// @Success	200	{file}	file
+// @Success	206	{file}	file
+// @Failure 400 {object} 	handler.ResponseErrors "Bad request"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Router  /user/{id}/todolist/attachment [get]
+func (a *AttachmentController) DownloadAttachment(c *gin.Context) {
+	var query web.AttachmentByIDQuery
+	ctx := context.Background()
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	attachment, reader, errService := a.Service.OpenAttachment(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	defer reader.Close()
+	c.Header("Content-Type", attachment.ContentType)
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}))
+	c.Header("ETag", `"`+attachment.Checksum+`"`)
+	c.Header("X-Checksum-Sha256", attachment.Checksum)
+	http.ServeContent(c.Writer, c.Request, attachment.FileName, attachment.CreatedAt, reader)
+}
+
+// DeleteAttachment godoc
+// @Summary Delete Attachment
+// @Description Delete an attachment and its stored file
+// @Tags Attachment
+// @Param id	path	string	true "Must Be UUID Format"
+// @Param id	query	int		true "ID todolist"
+// @Param attachment_id	query	int		true "ID attachment"
+// @Produce json
+// @Success	200	{object}	web.StandartResponse
+// @Failure 400 {object} 	handler.ResponseErrors "Bad request"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Router  /user/{id}/todolist/attachment [delete]
+func (a *AttachmentController) DeleteAttachment(c *gin.Context) {
+	var query web.AttachmentByIDQuery
+	ctx := context.Background()
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	errService := a.Service.DeleteAttachment(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly delete attachment", nil))
+}
@@ -0,0 +1,209 @@
+package controller
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"go_gin/internal/domain/model"
+	"go_gin/internal/domain/model/web"
+	"go_gin/internal/handler"
+	"net/http"
+)
+
+type BoardController struct {
+	Service model.BoardService
+}
+
+func NewBoardController(service model.BoardService) *BoardController {
+	return &BoardController{Service: service}
+}
+
+// GetBoard godoc
+// @Summary Get Board
+// @Description Retrieve the kanban board of a user with every column, its cards in order and its count as JSON
+// @Tags Board
+// @Param id	path	string	true	"Must Be UUID Format"
+// @Produce json
+// @Success	200 {object}	web.StandartResponse
+// @Failure 400 {object} handler.ResponseErrors "Bad request"
+// @Failure 401 {object} handler.ResponseErrors "Unauthorized"
+// @Router /user/{id}/board	[get]
+func (b *BoardController) GetBoard(c *gin.Context) {
+	ctx := context.Background()
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID)}
+	board, errService := b.Service.FindBoard(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly get board", map[string]interface{}{
+		"board": board,
+	}))
+}
+
+// CreateColumn godoc
+// @Summary Create Board Column
+// @Description Add a column at the end of the board, backed by a status or a custom lane without status
+// @Tags Board
+// @Param id	path	string	true	"Must Be UUID Format"
+// @Param request	body	model.BoardColumnRequest	true	"Board Column"
+// @Produce json
+// @Success	200 {object}	web.StandartResponse
+// @Failure 400 {object} handler.ResponseErrors "Bad request"
+// @Failure 401 {object} handler.ResponseErrors "Unauthorized"
+// @Failure 409 {object} handler.ResponseErrors "Conflict"
+// @Router /user/{id}/board/column	[post]
+func (b *BoardController) CreateColumn(c *gin.Context) {
+	var request model.BoardColumnRequest
+	ctx := context.Background()
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": err.Error(),
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID)}
+	column, errService := b.Service.CreateColumn(ctx, request, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly create board column", map[string]interface{}{
+		"column": column,
+	}))
+}
+
+// UpdateColumn godoc
+// @Summary Update Board Column
+// @Description Rename a column, change its status or its WIP limit
+// @Tags Board
+// @Param id	path	string	true	"Must Be UUID Format"
+// @Param column_id	query	int	true	"ID board column"
+// @Param request	body	model.BoardColumnRequest	true	"Board Column"
+// @Produce json
+// @Success	200 {object}	web.StandartResponse
+// @Failure 400 {object} handler.ResponseErrors "Bad request"
+// @Failure 401 {object} handler.ResponseErrors "Unauthorized"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Failure 409 {object} handler.ResponseErrors "Conflict"
+// @Router /user/{id}/board/column	[put]
+func (b *BoardController) UpdateColumn(c *gin.Context) {
+	var request model.BoardColumnRequest
+	var query web.BoardColumnByIDQuery
+	errQuery := c.ShouldBindQuery(&query)
+	ctx := context.Background()
+	if errQuery != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": err.Error(),
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	errService := b.Service.UpdateColumn(ctx, request, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly update board column", nil))
+}
+
+// DeleteColumn godoc
+// @Summary Delete Board Column
+// @Description Delete a column, its cards go back to the column of their status
+// @Tags Board
+// @Param id	path	string	true	"Must Be UUID Format"
+// @Param column_id	query	int	true	"ID board column"
+// @Produce json
+// @Success	200 {object}	web.StandartResponse
+// @Failure 400 {object} handler.ResponseErrors "Bad request"
+// @Failure 401 {object} handler.ResponseErrors "Unauthorized"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Router /user/{id}/board/column	[delete]
+func (b *BoardController) DeleteColumn(c *gin.Context) {
+	var query web.BoardColumnByIDQuery
+	ctx := context.Background()
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	errService := b.Service.DeleteColumn(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly delete board column", nil))
+}
+
+// MoveCard godoc
+// @Summary Move Board Card
+// @Description Move a todolist into a column at an index of its cards, updating its status and position at once
+// @Tags Board
+// @Param id	path	string	true	"Must Be UUID Format"
+// @Param id	query	int	true	"ID Todolist"
+// @Param request	body	model.BoardCardMoveRequest	true	"Column and index"
+// @Param If-Match	header	string	false "ETag the Todolist must still have"
+// @Produce json
+// @Success	200 {object}	web.StandartResponse
+// @Failure 400 {object} handler.ResponseErrors "Bad request"
+// @Failure 401 {object} handler.ResponseErrors "Unauthorized"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Failure 409 {object} handler.ResponseErrors "WIP limit reached or blocked"
+// @Failure 412 {object} handler.ResponseErrors "Precondition Failed"
+// @Failure 428 {object} handler.ResponseErrors "Precondition Required"
+// @Router /user/{id}/board/card	[patch]
+func (b *BoardController) MoveCard(c *gin.Context) {
+	var request model.BoardCardMoveRequest
+	var query web.TodoListByIDQuery
+	errQuery := c.ShouldBindQuery(&query)
+	ctx := context.Background()
+	if errQuery != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": err.Error(),
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query, IfMatch: c.GetHeader("If-Match")}
+	todolist, errService := b.Service.MoveCard(ctx, request, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.Header("ETag", web.ETag(todolist.Version))
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly move card", map[string]interface{}{
+		"todolist": todolist,
+	}))
+}
@@ -0,0 +1,358 @@
+package controller
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go_gin/internal/config"
+	"go_gin/internal/domain/model"
+	"go_gin/internal/domain/model/web"
+	"go_gin/internal/exception"
+	"go_gin/internal/handler"
+	"go_gin/pkg/dav"
+	"net/http"
+	"net/url"
+	"path"
+)
+
+// CalDAVController speaks CalDAV on /dav: /dav/{id}/ is the principal and calendar home of a user and
+// /dav/{id}/todolist/ the collection holding a VTODO per todolist.
+type CalDAVController struct {
+	Service model.CalDAVService
+}
+
+func NewCalDAVController(service model.CalDAVService) *CalDAVController {
+	return &CalDAVController{Service: service}
+}
+
+const calDAVContentType = "text/calendar; charset=utf-8; component=vtodo"
+
+func calDAVHome(userID string) string {
+	return "/dav/" + userID + "/"
+}
+
+func calDAVCollection(userID string) string {
+	return calDAVHome(userID) + "todolist/"
+}
+
+// calDAVParams returns the params of the logged in user, refusing paths of another user.
+func calDAVParams(c *gin.Context) (web.Params, bool) {
+	userID, _ := c.Get("user_id")
+	id, _ := userID.(uuid.UUID)
+	if c.Param("id") != "" && c.Param("id") != id.String() {
+		responseErrors := handler.NewResponseErrors(exception.NewError(errors.New("FORBIDDEN"), exception.ErrorForbidden))
+		c.JSON(responseErrors.Status, responseErrors)
+		return web.Params{}, false
+	}
+	return web.Params{UserID: web.UserID(id.String())}, true
+}
+
+// calDAVBody decodes the XML body of a PROPFIND or REPORT.
+func calDAVBody(c *gin.Context) (dav.Element, bool) {
+	body, err := dav.Decode(c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "xml body invalid",
+		})
+		return dav.Element{}, false
+	}
+	return body, true
+}
+
+func writeMultistatus(c *gin.Context, multistatus dav.Multistatus) {
+	c.Header("Content-Type", "application/xml; charset=utf-8")
+	c.Status(http.StatusMultiStatus)
+	multistatus.Encode(c.Writer)
+}
+
+func calDAVCollectionProperties(userID string, collection model.CalDAVCollection) []dav.Element {
+	return []dav.Element{
+		dav.New(dav.NamespaceDAV, "resourcetype", dav.New(dav.NamespaceDAV, "collection"), dav.New(dav.NamespaceCalDAV, "calendar")),
+		dav.NewText(dav.NamespaceDAV, "displayname", "Todolist"),
+		dav.New(dav.NamespaceDAV, "owner", dav.Href(calDAVHome(userID))),
+		dav.New(dav.NamespaceCalDAV, "supported-calendar-component-set", dav.Element{
+			Name:  xml.Name{Space: dav.NamespaceCalDAV, Local: "comp"},
+			Attrs: []xml.Attr{{Name: xml.Name{Local: "name"}, Value: "VTODO"}},
+		}),
+		dav.New(dav.NamespaceDAV, "supported-report-set",
+			dav.New(dav.NamespaceDAV, "supported-report", dav.New(dav.NamespaceDAV, "report", dav.New(dav.NamespaceCalDAV, "calendar-query"))),
+			dav.New(dav.NamespaceDAV, "supported-report", dav.New(dav.NamespaceDAV, "report", dav.New(dav.NamespaceCalDAV, "calendar-multiget"))),
+			dav.New(dav.NamespaceDAV, "supported-report", dav.New(dav.NamespaceDAV, "report", dav.New(dav.NamespaceDAV, "sync-collection")))),
+		dav.New(dav.NamespaceDAV, "current-user-privilege-set",
+			dav.New(dav.NamespaceDAV, "privilege", dav.New(dav.NamespaceDAV, "read")),
+			dav.New(dav.NamespaceDAV, "privilege", dav.New(dav.NamespaceDAV, "write"))),
+		dav.NewText(dav.NamespaceCalendarServer, "getctag", collection.SyncToken),
+		dav.NewText(dav.NamespaceDAV, "sync-token", collection.SyncToken),
+	}
+}
+
+// calDAVObjectProperties are the properties of an object, its calendar-data only comes with a REPORT.
+func calDAVObjectProperties(object model.CalDAVObject, withData bool) []dav.Element {
+	properties := []dav.Element{
+		dav.New(dav.NamespaceDAV, "resourcetype"),
+		dav.NewText(dav.NamespaceDAV, "getetag", object.ETag),
+		dav.NewText(dav.NamespaceDAV, "getcontenttype", calDAVContentType),
+	}
+	if withData {
+		properties = append(properties, dav.NewText(dav.NamespaceCalDAV, "calendar-data", object.Data))
+	}
+	return properties
+}
+
+func calDAVObjectHref(userID string, name string) string {
+	return calDAVCollection(userID) + url.PathEscape(name)
+}
+
+// WellKnown sends CalDAV clients looking up /.well-known/caldav to /dav/.
+func (cd *CalDAVController) WellKnown(c *gin.Context) {
+	c.Redirect(http.StatusMovedPermanently, "/dav/")
+}
+
+// Options tells CalDAV clients what the server supports, it needs no login.
+func (cd *CalDAVController) Options(c *gin.Context) {
+	c.Header("DAV", "1, 3, calendar-access")
+	c.Header("Allow", "OPTIONS, GET, HEAD, PUT, DELETE, PROPFIND, REPORT")
+	c.Status(http.StatusOK)
+}
+
+// PropfindRoot points clients to the principal of the logged in user.
+func (cd *CalDAVController) PropfindRoot(c *gin.Context) {
+	params, ok := calDAVParams(c)
+	if !ok {
+		return
+	}
+	body, ok := calDAVBody(c)
+	if !ok {
+		return
+	}
+	home := dav.Href(calDAVHome(string(params.UserID)))
+	writeMultistatus(c, dav.Multistatus{Responses: []dav.Response{
+		dav.NewResponse("/dav/", dav.Requested(body), []dav.Element{
+			dav.New(dav.NamespaceDAV, "resourcetype", dav.New(dav.NamespaceDAV, "collection")),
+			dav.New(dav.NamespaceDAV, "current-user-principal", home),
+		}),
+	}})
+}
+
+// PropfindHome describes the principal of the user, which is also its calendar home, and with Depth 1 its collection.
+func (cd *CalDAVController) PropfindHome(c *gin.Context) {
+	ctx := context.Background()
+	params, ok := calDAVParams(c)
+	if !ok {
+		return
+	}
+	body, ok := calDAVBody(c)
+	if !ok {
+		return
+	}
+	userID := string(params.UserID)
+	requested := dav.Requested(body)
+	properties := []dav.Element{
+		dav.New(dav.NamespaceDAV, "resourcetype", dav.New(dav.NamespaceDAV, "collection"), dav.New(dav.NamespaceDAV, "principal")),
+		dav.NewText(dav.NamespaceDAV, "displayname", c.GetString("username")),
+		dav.New(dav.NamespaceDAV, "current-user-principal", dav.Href(calDAVHome(userID))),
+		dav.New(dav.NamespaceDAV, "principal-URL", dav.Href(calDAVHome(userID))),
+		dav.New(dav.NamespaceCalDAV, "calendar-home-set", dav.Href(calDAVHome(userID))),
+		dav.New(dav.NamespaceCalDAV, "calendar-user-address-set", dav.Href("mailto:"+c.GetString("email"))),
+	}
+	multistatus := dav.Multistatus{Responses: []dav.Response{dav.NewResponse(calDAVHome(userID), requested, properties)}}
+	if c.GetHeader("Depth") != "0" {
+		collection, errService := cd.Service.FindCalDAVCollection(ctx, params, "")
+		if errService != nil {
+			responseErrors := handler.NewResponseErrors(errService)
+			c.JSON(responseErrors.Status, responseErrors)
+			return
+		}
+		multistatus.Responses = append(multistatus.Responses, dav.NewResponse(calDAVCollection(userID), requested, calDAVCollectionProperties(userID, collection)))
+	}
+	writeMultistatus(c, multistatus)
+}
+
+// PropfindCollection describes the VTODO collection and with Depth 1 every object in it.
+func (cd *CalDAVController) PropfindCollection(c *gin.Context) {
+	ctx := context.Background()
+	params, ok := calDAVParams(c)
+	if !ok {
+		return
+	}
+	body, ok := calDAVBody(c)
+	if !ok {
+		return
+	}
+	collection, errService := cd.Service.FindCalDAVCollection(ctx, params, "")
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	userID := string(params.UserID)
+	requested := dav.Requested(body)
+	multistatus := dav.Multistatus{Responses: []dav.Response{dav.NewResponse(calDAVCollection(userID), requested, calDAVCollectionProperties(userID, collection))}}
+	if c.GetHeader("Depth") != "0" {
+		for _, object := range collection.Objects {
+			multistatus.Responses = append(multistatus.Responses, dav.NewResponse(calDAVObjectHref(userID, object.Name), requested, calDAVObjectProperties(object, false)))
+		}
+	}
+	writeMultistatus(c, multistatus)
+}
+
+func (cd *CalDAVController) PropfindObject(c *gin.Context) {
+	ctx := context.Background()
+	params, ok := calDAVParams(c)
+	if !ok {
+		return
+	}
+	body, ok := calDAVBody(c)
+	if !ok {
+		return
+	}
+	object, errService := cd.Service.FindCalDAVObject(ctx, params, c.Param("name"))
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	userID := string(params.UserID)
+	writeMultistatus(c, dav.Multistatus{Responses: []dav.Response{
+		dav.NewResponse(calDAVObjectHref(userID, object.Name), dav.Requested(body), calDAVObjectProperties(object, false)),
+	}})
+}
+
+// Report answers the calendar-query, calendar-multiget and sync-collection reports of the collection. A
+// calendar-query only filters on the component, time ranges are left to the client.
+func (cd *CalDAVController) Report(c *gin.Context) {
+	ctx := context.Background()
+	params, ok := calDAVParams(c)
+	if !ok {
+		return
+	}
+	body, ok := calDAVBody(c)
+	if !ok {
+		return
+	}
+	userID := string(params.UserID)
+	requested := dav.Requested(body)
+	var multistatus dav.Multistatus
+	switch {
+	case body.Is(dav.NamespaceCalDAV, "calendar-query"):
+		collection, errService := cd.Service.FindCalDAVCollection(ctx, params, "")
+		if errService != nil {
+			responseErrors := handler.NewResponseErrors(errService)
+			c.JSON(responseErrors.Status, responseErrors)
+			return
+		}
+		filter, _ := body.Child(dav.NamespaceCalDAV, "filter")
+		calendar, _ := filter.Child(dav.NamespaceCalDAV, "comp-filter")
+		component, _ := calendar.Child(dav.NamespaceCalDAV, "comp-filter")
+		if name := component.Attr("name"); name != "" && name != "VTODO" {
+			break
+		}
+		for _, object := range collection.Objects {
+			multistatus.Responses = append(multistatus.Responses, dav.NewResponse(calDAVObjectHref(userID, object.Name), requested, calDAVObjectProperties(object, true)))
+		}
+	case body.Is(dav.NamespaceCalDAV, "calendar-multiget"):
+		for _, href := range body.Children {
+			if !href.Is(dav.NamespaceDAV, "href") {
+				continue
+			}
+			name, _ := url.PathUnescape(path.Base(href.Text))
+			object, errService := cd.Service.FindCalDAVObject(ctx, params, name)
+			if errService != nil {
+				multistatus.Responses = append(multistatus.Responses, dav.Response{Href: href.Text, Status: handler.NewResponseErrors(errService).Status})
+				continue
+			}
+			multistatus.Responses = append(multistatus.Responses, dav.NewResponse(href.Text, requested, calDAVObjectProperties(object, true)))
+		}
+	case body.Is(dav.NamespaceDAV, "sync-collection"):
+		token, _ := body.Child(dav.NamespaceDAV, "sync-token")
+		collection, errService := cd.Service.FindCalDAVCollection(ctx, params, token.Text)
+		if errService != nil {
+			responseErrors := handler.NewResponseErrors(errService)
+			if responseErrors.Status == http.StatusForbidden {
+				// the client starts over without a token
+				c.Header("Content-Type", "application/xml; charset=utf-8")
+				c.Status(http.StatusForbidden)
+				dav.EncodeError(c.Writer, dav.New(dav.NamespaceDAV, "valid-sync-token"))
+				return
+			}
+			c.JSON(responseErrors.Status, responseErrors)
+			return
+		}
+		for _, object := range collection.Objects {
+			multistatus.Responses = append(multistatus.Responses, dav.NewResponse(calDAVObjectHref(userID, object.Name), requested, calDAVObjectProperties(object, true)))
+		}
+		for _, name := range collection.Deleted {
+			multistatus.Responses = append(multistatus.Responses, dav.Response{Href: calDAVObjectHref(userID, name), Status: http.StatusNotFound})
+		}
+		multistatus.SyncToken = collection.SyncToken
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "report " + body.Name.Local + " is not supported",
+		})
+		return
+	}
+	writeMultistatus(c, multistatus)
+}
+
+func (cd *CalDAVController) GetObject(c *gin.Context) {
+	ctx := context.Background()
+	params, ok := calDAVParams(c)
+	if !ok {
+		return
+	}
+	object, errService := cd.Service.FindCalDAVObject(ctx, params, c.Param("name"))
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.Header("ETag", object.ETag)
+	c.Data(http.StatusOK, calDAVContentType, []byte(object.Data))
+}
+
+// PutObject creates or replaces a todolist from the VTODO in the body, If-Match and If-None-Match: * guard it.
+func (cd *CalDAVController) PutObject(c *gin.Context) {
+	ctx := context.Background()
+	params, ok := calDAVParams(c)
+	if !ok {
+		return
+	}
+	params.IfMatch = c.GetHeader("If-Match")
+	params.IfNoneMatch = c.GetHeader("If-None-Match")
+	if config.Other.MaxImportSize > 0 {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, config.Other.MaxImportSize)
+	}
+	object, created, errService := cd.Service.PutCalDAVObject(ctx, params, c.Param("name"), c.Request.Body)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.Header("ETag", object.ETag)
+	if created {
+		c.Status(http.StatusCreated)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// DeleteObject moves the todolist to the trash, If-Match guards it.
+func (cd *CalDAVController) DeleteObject(c *gin.Context) {
+	ctx := context.Background()
+	params, ok := calDAVParams(c)
+	if !ok {
+		return
+	}
+	params.IfMatch = c.GetHeader("If-Match")
+	errService := cd.Service.DeleteCalDAVObject(ctx, params, c.Param("name"))
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"github.com/gin-gonic/gin"
+	"go_gin/internal/domain/model"
+	"go_gin/internal/domain/model/web"
+	"go_gin/internal/handler"
+	"net/http"
+	"strings"
+)
+
+type CalendarController struct {
+	Service model.CalendarService
+}
+
+func NewCalendarController(service model.CalendarService) *CalendarController {
+	return &CalendarController{Service: service}
+}
+
+// CreateCalendarToken godoc
+// @Summary Create a Calendar feed
+// @Description Create the secret URL of the iCalendar feed of the user's due Todolists, replacing the previous one. The token is only shown once
+// @Tags Calendar
+// @Param id	path	string	true "Must Be UUID Format"
+// @Produce json
+// @Success	201	{object}	web.StandartResponse
+// @Failure 400 {object} 	handler.ResponseErrors "Bad request"
+// @Failure 401 {object} 	handler.ResponseErrors "Unauthorized"
+// @Router  /user/{id}/calendar/token [post]
+func (cc *CalendarController) CreateCalendarToken(c *gin.Context) {
+	ctx := context.Background()
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID)}
+	response, errService := cc.Service.CreateCalendarToken(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusCreated, web.NewStandartResponse(http.StatusCreated, "successfuly create calendar feed", response))
+}
+
+// DeleteCalendarToken godoc
+// @Summary Delete the Calendar feed
+// @Description Revoke the secret URL of the user's iCalendar feed
+// @Tags Calendar
+// @Param id	path	string	true "Must Be UUID Format"
+// @Produce json
+// @Success	200	{object}	web.StandartResponse
+// @Failure 400 {object} 	handler.ResponseErrors "Bad request"
+// @Failure 401 {object} 	handler.ResponseErrors "Unauthorized"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Router  /user/{id}/calendar/token [delete]
+func (cc *CalendarController) DeleteCalendarToken(c *gin.Context) {
+	ctx := context.Background()
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID)}
+	errService := cc.Service.DeleteCalendarToken(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly delete calendar feed", nil))
+}
+
+// GetCalendarFeed godoc
+// @Summary Get a Calendar feed
+// @Description iCalendar feed of the Todolists with a due date, as VTODO and all-day VEVENT. The token is the authentication, calendar apps subscribe to this URL
+// @Tags Calendar
+// @Param token	path	string	true "Feed token, .ics may be appended"
+// @Produce	text/calendar
+// @Success	200
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Router  /calendar/{token} [get]
+func (cc *CalendarController) GetCalendarFeed(c *gin.Context) {
+	ctx := context.Background()
+	token := strings.TrimSuffix(c.Param("token"), ".ics")
+	var feed bytes.Buffer
+	errService := cc.Service.WriteCalendarFeed(ctx, token, &feed)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.Header("Cache-Control", "private, max-age=300")
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", feed.Bytes())
+}
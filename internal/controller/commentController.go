@@ -0,0 +1,251 @@
+package controller
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"go_gin/internal/domain/model"
+	"go_gin/internal/domain/model/web"
+	"go_gin/internal/handler"
+	"net/http"
+)
+
+type CommentController struct {
+	Service model.CommentService
+}
+
+func NewCommentController(service model.CommentService) *CommentController {
+	return &CommentController{Service: service}
+}
+
+// GetComments godoc
+// @Summary Get Comments of a Todolist
+// @Description Retrieve the comments of a Todolist as JSON
+// @Tags Comment
+// @Param id	path	string	true "Must Be UUID Format"
+// @Param id	query	int		true "ID todolist"
+// @Produce json
+// @Success	200	{object}	web.StandartResponse
+// @Failure 400 {object} 	handler.ResponseErrors "Bad request"
+// @Failure 401 {object} 	handler.ResponseErrors "Unauthorized"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Router  /user/{id}/todolist/comments [get]
+func (cc *CommentController) GetComments(c *gin.Context) {
+	var query web.TodoListByIDQuery
+	ctx := context.Background()
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	responses, errService := cc.Service.FindComments(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly get comments", map[string]interface{}{
+		"comments": responses,
+	}))
+}
+
+// CreateComment godoc
+// @Summary Create Comment on a Todolist
+// @Description Create a markdown comment, @username mentions notify the mentioned users
+// @Tags Comment
+// @Param id	path	string	true "Must Be UUID Format"
+// @Param id	query	int		true "ID todolist"
+// @Param request	body	model.CommentRequest	true	"Comment Body"
+// @Produce json
+// @Success	200	{object}	web.StandartResponse
+// @Failure 400 {object} 	handler.ResponseErrors "Bad request"
+// @Failure 401 {object} 	handler.ResponseErrors "Unauthorized"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Router  /user/{id}/todolist/comment [post]
+func (cc *CommentController) CreateComment(c *gin.Context) {
+	var request model.CommentRequest
+	var query web.TodoListByIDQuery
+	ctx := context.Background()
+	errQuery := c.ShouldBindQuery(&query)
+	if errQuery != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": err.Error(),
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	response, errService := cc.Service.CreateComment(ctx, request, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly create comment", map[string]interface{}{
+		"comment": response,
+	}))
+}
+
+// UpdateComment godoc
+// @Summary Update Comment
+// @Description Edit own comment, the previous body is kept in the edit history
+// @Tags Comment
+// @Param id	path	string	true "Must Be UUID Format"
+// @Param id	query	int		true "ID todolist"
+// @Param comment_id	query	int		true "ID comment"
+// @Param request	body	model.CommentRequest	true	"Comment Body"
+// @Produce json
+// @Success	200	{object}	web.StandartResponse
+// @Failure 400 {object} 	handler.ResponseErrors "Bad request"
+// @Failure 403 {object} 	handler.ResponseErrors "Forbidden"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Router  /user/{id}/todolist/comment [put]
+func (cc *CommentController) UpdateComment(c *gin.Context) {
+	var request model.CommentRequest
+	var query web.CommentByIDQuery
+	ctx := context.Background()
+	errQuery := c.ShouldBindQuery(&query)
+	if errQuery != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": err.Error(),
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	errService := cc.Service.UpdateComment(ctx, request, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly update comment", nil))
+}
+
+// DeleteComment godoc
+// @Summary Delete Comment
+// @Description Soft delete own comment
+// @Tags Comment
+// @Param id	path	string	true "Must Be UUID Format"
+// @Param id	query	int		true "ID todolist"
+// @Param comment_id	query	int		true "ID comment"
+// @Produce json
+// @Success	200	{object}	web.StandartResponse
+// @Failure 400 {object} 	handler.ResponseErrors "Bad request"
+// @Failure 403 {object} 	handler.ResponseErrors "Forbidden"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Router  /user/{id}/todolist/comment [delete]
+func (cc *CommentController) DeleteComment(c *gin.Context) {
+	var query web.CommentByIDQuery
+	ctx := context.Background()
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	errService := cc.Service.DeleteComment(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly delete comment", nil))
+}
+
+// GetCommentHistory godoc
+// @Summary Get Comment edit history
+// @Description Retrieve the previous bodies of a comment as JSON
+// @Tags Comment
+// @Param id	path	string	true "Must Be UUID Format"
+// @Param id	query	int		true "ID todolist"
+// @Param comment_id	query	int		true "ID comment"
+// @Produce json
+// @Success	200	{object}	web.StandartResponse
+// @Failure 400 {object} 	handler.ResponseErrors "Bad request"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Router  /user/{id}/todolist/comment/history [get]
+func (cc *CommentController) GetCommentHistory(c *gin.Context) {
+	var query web.CommentByIDQuery
+	ctx := context.Background()
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	revisions, errService := cc.Service.FindCommentHistory(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly get comment history", map[string]interface{}{
+		"revisions": revisions,
+	}))
+}
+
+// GetActivities godoc
+// @Summary Get Activity feed of a Todolist
+// @Description Retrieve comments and field changes of a Todolist ordered by time
+// @Tags Comment
+// @Param id	path	string	true "Must Be UUID Format"
+// @Param id	query	int		true "ID todolist"
+// @Produce json
+// @Success	200	{object}	web.StandartResponse
+// @Failure 400 {object} 	handler.ResponseErrors "Bad request"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Router  /user/{id}/todolist/activity [get]
+func (cc *CommentController) GetActivities(c *gin.Context) {
+	var query web.TodoListByIDQuery
+	ctx := context.Background()
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	responses, errService := cc.Service.FindActivities(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly get activity", map[string]interface{}{
+		"activity": responses,
+	}))
+}
@@ -0,0 +1,170 @@
+package controller
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"go_gin/internal/domain/model"
+	"go_gin/internal/domain/model/web"
+	"go_gin/internal/handler"
+	"net/http"
+)
+
+type DependencyController struct {
+	Service model.DependencyService
+}
+
+func NewDependencyController(service model.DependencyService) *DependencyController {
+	return &DependencyController{Service: service}
+}
+
+// GetDependencies godoc
+// @Summary Get Todolist dependencies
+// @Description Retrieve the Todolist blocking a Todolist and the ones it blocks
+// @Tags Dependency
+// @Param id	path	string	true	"Must Be UUID Format"
+// @Param id	query	int	true	"ID Todolist"
+// @Produce json
+// @Success	200 {object}	web.StandartResponse
+// @Failure 400 {object} handler.ResponseErrors "Bad request"
+// @Failure 401 {object} handler.ResponseErrors "Unauthorized"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Router /user/{id}/todolist/dependencies	[get]
+func (d *DependencyController) GetDependencies(c *gin.Context) {
+	var query web.TodoListByIDQuery
+	ctx := context.Background()
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	dependencies, errService := d.Service.FindDependencies(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly get dependencies", map[string]interface{}{
+		"dependencies": dependencies,
+	}))
+}
+
+// CreateDependency godoc
+// @Summary Create Todolist dependency
+// @Description Mark a Todolist as blocked by another one, links that would create a cycle are refused
+// @Tags Dependency
+// @Param id	path	string	true	"Must Be UUID Format"
+// @Param id	query	int	true	"ID blocked Todolist"
+// @Param request	body	model.DependencyRequest	true	"Blocker"
+// @Produce json
+// @Success	200 {object}	web.StandartResponse
+// @Failure 400 {object} handler.ResponseErrors "Bad request"
+// @Failure 401 {object} handler.ResponseErrors "Unauthorized"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Failure 409 {object} handler.ResponseErrors "Cycle or duplicate"
+// @Router /user/{id}/todolist/dependency	[post]
+func (d *DependencyController) CreateDependency(c *gin.Context) {
+	var request model.DependencyRequest
+	var query web.TodoListByIDQuery
+	errQuery := c.ShouldBindQuery(&query)
+	ctx := context.Background()
+	if errQuery != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": err.Error(),
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	errService := d.Service.CreateDependency(ctx, request, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly create dependency", nil))
+}
+
+// DeleteDependency godoc
+// @Summary Delete Todolist dependency
+// @Description Remove the link between a Todolist and one of its blockers
+// @Tags Dependency
+// @Param id	path	string	true	"Must Be UUID Format"
+// @Param id	query	int	true	"ID blocked Todolist"
+// @Param blocker_id	query	int	true	"ID blocker Todolist"
+// @Produce json
+// @Success	200 {object}	web.StandartResponse
+// @Failure 400 {object} handler.ResponseErrors "Bad request"
+// @Failure 401 {object} handler.ResponseErrors "Unauthorized"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Router /user/{id}/todolist/dependency	[delete]
+func (d *DependencyController) DeleteDependency(c *gin.Context) {
+	var query web.DependencyQuery
+	ctx := context.Background()
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	errService := d.Service.DeleteDependency(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly delete dependency", nil))
+}
+
+// GetProjectPlan godoc
+// @Summary Get Project plan
+// @Description Retrieve the Todolist of a project in dependency order with the critical path given their due dates
+// @Tags Dependency
+// @Param id	path	string	true	"Must Be UUID Format"
+// @Param project_id	query	int	true	"ID project"
+// @Produce json
+// @Success	200 {object}	web.StandartResponse
+// @Failure 400 {object} handler.ResponseErrors "Bad request"
+// @Failure 401 {object} handler.ResponseErrors "Unauthorized"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Router /user/{id}/project/plan	[get]
+func (d *DependencyController) GetProjectPlan(c *gin.Context) {
+	var query web.ProjectByIDQuery
+	ctx := context.Background()
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	plan, errService := d.Service.FindProjectPlan(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly get project plan", map[string]interface{}{
+		"plan": plan,
+	}))
+}
@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"go_gin/internal/domain/model"
+	"go_gin/internal/domain/model/web"
+	"go_gin/internal/handler"
+	"net/http"
+)
+
+type NotificationController struct {
+	Service model.NotificationService
+}
+
+func NewNotificationController(service model.NotificationService) *NotificationController {
+	return &NotificationController{Service: service}
+}
+
+// GetNotifications godoc
+// @Summary Get Notifications
+// @Description Retrieve the notifications of a user as JSON, newest first
+// @Tags Notification
+// @Param id	path	string	true	"Must Be UUID Format"
+// @Param page	query	int		true	"Page Number"
+// @Produce json
+// @Success	200 {object}	web.StandartResponse
+// @Failure 400 {object} handler.ResponseErrors "Bad request"
+// @Failure 401 {object} handler.ResponseErrors "Unauthorized"
+// @Router /user/{id}/notifications	[get]
+func (n *NotificationController) GetNotifications(c *gin.Context) {
+	var query web.GetAllQuery
+	ctx := context.Background()
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	notifications, pagination, errService := n.Service.FindNotifications(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly get notifications", map[string]interface{}{
+		"notifications": notifications,
+		"pagination":    pagination,
+	}))
+}
+
+// ReadNotifications godoc
+// @Summary Mark Notifications as read
+// @Description Mark notifications of a user as read
+// @Tags Notification
+// @Param id	path	string	true	"Must Be UUID Format"
+// @Param id	query	[]int	true	"ID notification"
+// @Produce json
+// @Success	200 {object}	web.StandartResponse
+// @Failure 400 {object} handler.ResponseErrors "Bad request"
+// @Failure 401 {object} handler.ResponseErrors "Unauthorized"
+// @Router /user/{id}/notifications	[patch]
+func (n *NotificationController) ReadNotifications(c *gin.Context) {
+	ids := c.QueryArray("id")
+	if len(ids) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	ctx := context.Background()
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: web.NotificationByIDsQuery{IDs: ids}}
+	errService := n.Service.ReadNotifications(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly read notifications", nil))
+}
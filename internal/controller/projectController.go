@@ -0,0 +1,156 @@
+package controller
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"go_gin/internal/domain/model"
+	"go_gin/internal/domain/model/web"
+	"go_gin/internal/handler"
+	"net/http"
+)
+
+type ProjectController struct {
+	Service model.ProjectService
+}
+
+func NewProjectController(service model.ProjectService) *ProjectController {
+	return &ProjectController{Service: service}
+}
+
+// GetProjects godoc
+// @Summary Get Projects
+// @Description Retrieve the projects of a user as JSON
+// @Tags Project
+// @Param id	path	string	true	"Must Be UUID Format"
+// @Produce json
+// @Success	200 {object}	web.StandartResponse
+// @Failure 400 {object} handler.ResponseErrors "Bad request"
+// @Failure 401 {object} handler.ResponseErrors "Unauthorized"
+// @Router /user/{id}/projects	[get]
+func (p *ProjectController) GetProjects(c *gin.Context) {
+	ctx := context.Background()
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID)}
+	projects, errService := p.Service.FindProjects(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly get projects", map[string]interface{}{
+		"projects": projects,
+	}))
+}
+
+// CreateProject godoc
+// @Summary Create Project
+// @Description Create a project to group todolist
+// @Tags Project
+// @Param id	path	string	true	"Must Be UUID Format"
+// @Param request	body	model.ProjectRequest	true	"Project"
+// @Produce json
+// @Success	200 {object}	web.StandartResponse
+// @Failure 400 {object} handler.ResponseErrors "Bad request"
+// @Failure 401 {object} handler.ResponseErrors "Unauthorized"
+// @Router /user/{id}/project	[post]
+func (p *ProjectController) CreateProject(c *gin.Context) {
+	var request model.ProjectRequest
+	ctx := context.Background()
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": err.Error(),
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID)}
+	project, errService := p.Service.CreateProject(ctx, request, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly create project", map[string]interface{}{
+		"project": project,
+	}))
+}
+
+// UpdateProject godoc
+// @Summary Update Project
+// @Description Rename or describe a project
+// @Tags Project
+// @Param id	path	string	true	"Must Be UUID Format"
+// @Param project_id	query	int	true	"ID project"
+// @Param request	body	model.ProjectRequest	true	"Project"
+// @Produce json
+// @Success	200 {object}	web.StandartResponse
+// @Failure 400 {object} handler.ResponseErrors "Bad request"
+// @Failure 401 {object} handler.ResponseErrors "Unauthorized"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Router /user/{id}/project	[put]
+func (p *ProjectController) UpdateProject(c *gin.Context) {
+	var request model.ProjectRequest
+	var query web.ProjectByIDQuery
+	errQuery := c.ShouldBindQuery(&query)
+	ctx := context.Background()
+	if errQuery != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": err.Error(),
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	errService := p.Service.UpdateProject(ctx, request, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly update project", nil))
+}
+
+// DeleteProject godoc
+// @Summary Delete Project
+// @Description Delete a project, its todolist are kept without a project
+// @Tags Project
+// @Param id	path	string	true	"Must Be UUID Format"
+// @Param project_id	query	int	true	"ID project"
+// @Produce json
+// @Success	200 {object}	web.StandartResponse
+// @Failure 400 {object} handler.ResponseErrors "Bad request"
+// @Failure 401 {object} handler.ResponseErrors "Unauthorized"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Router /user/{id}/project	[delete]
+func (p *ProjectController) DeleteProject(c *gin.Context) {
+	var query web.ProjectByIDQuery
+	ctx := context.Background()
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	errService := p.Service.DeleteProject(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly delete project", nil))
+}
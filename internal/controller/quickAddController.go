@@ -0,0 +1,56 @@
+package controller
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"go_gin/internal/domain/model"
+	"go_gin/internal/domain/model/web"
+	"go_gin/internal/handler"
+	"net/http"
+)
+
+type QuickAddController struct {
+	Service model.QuickAddService
+}
+
+func NewQuickAddController(service model.QuickAddService) *QuickAddController {
+	return &QuickAddController{Service: service}
+}
+
+// QuickAddTodoList godoc
+// @Summary Quick add a Todolist
+// @Description Create a Todolist from free text such as "Pay rent every 1st of month !high #home": due date and time (today, tomorrow at 5pm, next friday, in 3 days, nov 5, 2026-11-05), priority (!high, !3, p1), tags (#home) and recurrence (daily, every monday, every 2 weeks, every 1st of month) are read out of it and the rest is the title. A dry run only returns the parsed Todolist
+// @Tags Todolist
+// @Param id	path	string	true "Must Be UUID Format"
+// @Param request	body	model.QuickAddRequest	true	"Text of the Todolist"
+// @Produce json
+// @Success	200	{object}	web.StandartResponse "Dry run"
+// @Success	201	{object}	web.StandartResponse
+// @Failure 400 {object} 	handler.ResponseErrors "Bad request"
+// @Failure 401 {object} 	handler.ResponseErrors "Unauthorized"
+// @Router  /user/{id}/todolist/quick [post]
+func (q *QuickAddController) QuickAddTodoList(c *gin.Context) {
+	var request model.QuickAddRequest
+	ctx := context.Background()
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": err.Error(),
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID)}
+	response, errService := q.Service.QuickAddTodoList(ctx, request, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	if response.DryRun {
+		c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "dry run, nothing was created", response))
+		return
+	}
+	c.JSON(http.StatusCreated, web.NewStandartResponse(http.StatusCreated, "successfuly create todo list", response))
+}
@@ -0,0 +1,156 @@
+package controller
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"go_gin/internal/domain/model"
+	"go_gin/internal/domain/model/web"
+	"go_gin/internal/handler"
+	"net/http"
+)
+
+type SavedSearchController struct {
+	Service model.SavedSearchService
+}
+
+func NewSavedSearchController(service model.SavedSearchService) *SavedSearchController {
+	return &SavedSearchController{Service: service}
+}
+
+// GetSavedSearches godoc
+// @Summary Get Saved Searches
+// @Description Retrieve the saved searches of a user as JSON
+// @Tags Search
+// @Param id	path	string	true	"Must Be UUID Format"
+// @Produce json
+// @Success	200 {object}	web.StandartResponse
+// @Failure 400 {object} handler.ResponseErrors "Bad request"
+// @Failure 401 {object} handler.ResponseErrors "Unauthorized"
+// @Router /user/{id}/searches	[get]
+func (s *SavedSearchController) GetSavedSearches(c *gin.Context) {
+	ctx := context.Background()
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID)}
+	searches, errService := s.Service.FindSavedSearches(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly get saved searches", map[string]interface{}{
+		"searches": searches,
+	}))
+}
+
+// CreateSavedSearch godoc
+// @Summary Create Saved Search
+// @Description Save a todolist search query under a name, run it with search_id on GET /user/{id}/todolists
+// @Tags Search
+// @Param id	path	string	true	"Must Be UUID Format"
+// @Param request	body	model.SavedSearchRequest	true	"Saved Search"
+// @Produce json
+// @Success	200 {object}	web.StandartResponse
+// @Failure 400 {object} handler.ResponseErrors "Bad request"
+// @Failure 401 {object} handler.ResponseErrors "Unauthorized"
+// @Router /user/{id}/search	[post]
+func (s *SavedSearchController) CreateSavedSearch(c *gin.Context) {
+	var request model.SavedSearchRequest
+	ctx := context.Background()
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": err.Error(),
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID)}
+	search, errService := s.Service.CreateSavedSearch(ctx, request, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly create saved search", map[string]interface{}{
+		"search": search,
+	}))
+}
+
+// UpdateSavedSearch godoc
+// @Summary Update Saved Search
+// @Description Rename a saved search or change its query
+// @Tags Search
+// @Param id	path	string	true	"Must Be UUID Format"
+// @Param search_id	query	int	true	"ID saved search"
+// @Param request	body	model.SavedSearchRequest	true	"Saved Search"
+// @Produce json
+// @Success	200 {object}	web.StandartResponse
+// @Failure 400 {object} handler.ResponseErrors "Bad request"
+// @Failure 401 {object} handler.ResponseErrors "Unauthorized"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Router /user/{id}/search	[put]
+func (s *SavedSearchController) UpdateSavedSearch(c *gin.Context) {
+	var request model.SavedSearchRequest
+	var query web.SavedSearchByIDQuery
+	errQuery := c.ShouldBindQuery(&query)
+	ctx := context.Background()
+	if errQuery != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": err.Error(),
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	errService := s.Service.UpdateSavedSearch(ctx, request, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly update saved search", nil))
+}
+
+// DeleteSavedSearch godoc
+// @Summary Delete Saved Search
+// @Description Delete a saved search
+// @Tags Search
+// @Param id	path	string	true	"Must Be UUID Format"
+// @Param search_id	query	int	true	"ID saved search"
+// @Produce json
+// @Success	200 {object}	web.StandartResponse
+// @Failure 400 {object} handler.ResponseErrors "Bad request"
+// @Failure 401 {object} handler.ResponseErrors "Unauthorized"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Router /user/{id}/search	[delete]
+func (s *SavedSearchController) DeleteSavedSearch(c *gin.Context) {
+	var query web.SavedSearchByIDQuery
+	ctx := context.Background()
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	errService := s.Service.DeleteSavedSearch(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly delete saved search", nil))
+}
@@ -0,0 +1,185 @@
+package controller
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"go_gin/internal/domain/model"
+	"go_gin/internal/domain/model/web"
+	"go_gin/internal/handler"
+	"net/http"
+)
+
+type ShareController struct {
+	Service model.ShareService
+}
+
+func NewShareController(service model.ShareService) *ShareController {
+	return &ShareController{Service: service}
+}
+
+// GetSharesReceived godoc
+// @Summary Get Shares received
+// @Description Retrieve the invitations and grants other users gave to the user
+// @Tags Share
+// @Param id	path	string	true	"Must Be UUID Format"
+// @Param status	query	string	false	"pending, accepted or declined"
+// @Produce json
+// @Success	200 {object}	web.StandartResponse
+// @Failure 400 {object} handler.ResponseErrors "Bad request"
+// @Failure 401 {object} handler.ResponseErrors "Unauthorized"
+// @Router /user/{id}/shares	[get]
+func (s *ShareController) GetSharesReceived(c *gin.Context) {
+	var query web.ShareQuery
+	ctx := context.Background()
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	shares, errService := s.Service.FindSharesReceived(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly get shares", map[string]interface{}{
+		"shares": shares,
+	}))
+}
+
+// GetSharesSent godoc
+// @Summary Get Shares sent
+// @Description Retrieve the shares the user gave on its todolist and projects
+// @Tags Share
+// @Param id	path	string	true	"Must Be UUID Format"
+// @Produce json
+// @Success	200 {object}	web.StandartResponse
+// @Failure 400 {object} handler.ResponseErrors "Bad request"
+// @Failure 401 {object} handler.ResponseErrors "Unauthorized"
+// @Router /user/{id}/shares/sent	[get]
+func (s *ShareController) GetSharesSent(c *gin.Context) {
+	ctx := context.Background()
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID)}
+	shares, errService := s.Service.FindSharesSent(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly get shares", map[string]interface{}{
+		"shares": shares,
+	}))
+}
+
+// CreateShare godoc
+// @Summary Share Todolist or Project
+// @Description Invite another user as viewer or editor of a todolist or a whole project
+// @Tags Share
+// @Param id	path	string	true	"Must Be UUID Format"
+// @Param request	body	model.ShareRequest	true	"Share"
+// @Produce json
+// @Success	200 {object}	web.StandartResponse
+// @Failure 400 {object} handler.ResponseErrors "Bad request"
+// @Failure 401 {object} handler.ResponseErrors "Unauthorized"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Failure 409 {object} handler.ResponseErrors "Already shared"
+// @Router /user/{id}/shares	[post]
+func (s *ShareController) CreateShare(c *gin.Context) {
+	var request model.ShareRequest
+	ctx := context.Background()
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": err.Error(),
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID)}
+	share, errService := s.Service.CreateShare(ctx, request, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly create share", map[string]interface{}{
+		"share": share,
+	}))
+}
+
+// RespondShare godoc
+// @Summary Accept or decline Share
+// @Description Accept or decline an invitation, only the invited user can respond
+// @Tags Share
+// @Param id	path	string	true	"Must Be UUID Format"
+// @Param share_id	query	int	true	"ID share"
+// @Param action	query	string	true	"accept or decline"
+// @Produce json
+// @Success	200 {object}	web.StandartResponse
+// @Failure 400 {object} handler.ResponseErrors "Bad request"
+// @Failure 401 {object} handler.ResponseErrors "Unauthorized"
+// @Failure 403 {object} handler.ResponseErrors "Forbidden"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Router /user/{id}/share	[patch]
+func (s *ShareController) RespondShare(c *gin.Context) {
+	var query web.ShareRespondQuery
+	ctx := context.Background()
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	errService := s.Service.RespondShare(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly respond share", nil))
+}
+
+// DeleteShare godoc
+// @Summary Delete Share
+// @Description Revoke a share as its owner or leave it as the invited user
+// @Tags Share
+// @Param id	path	string	true	"Must Be UUID Format"
+// @Param share_id	query	int	true	"ID share"
+// @Produce json
+// @Success	200 {object}	web.StandartResponse
+// @Failure 400 {object} handler.ResponseErrors "Bad request"
+// @Failure 401 {object} handler.ResponseErrors "Unauthorized"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Router /user/{id}/share	[delete]
+func (s *ShareController) DeleteShare(c *gin.Context) {
+	var query web.ShareByIDQuery
+	ctx := context.Background()
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	errService := s.Service.DeleteShare(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly delete share", nil))
+}
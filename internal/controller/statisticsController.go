@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"go_gin/internal/domain/model"
+	"go_gin/internal/domain/model/web"
+	"go_gin/internal/handler"
+	"net/http"
+)
+
+type StatisticsController struct {
+	Service model.StatisticsService
+}
+
+func NewStatisticsController(service model.StatisticsService) *StatisticsController {
+	return &StatisticsController{Service: service}
+}
+
+// GetStatistics godoc
+// @Summary Get the statistics of a user
+// @Description Todolists created and completed per day or week, completion rate, average lead time from creation to completion, overdue count, priority distribution and completion streaks, days in UTC
+// @Tags Statistics
+// @Param id	path	string	true "Must Be UUID Format"
+// @Param from	query	string	true "Start date 2006-01-02"
+// @Param to	query	string	true "End date included 2006-01-02"
+// @Param period	query	string	false "day or week"
+// @Produce json
+// @Success	200	{object}	web.StandartResponse
+// @Failure 400 {object} 	handler.ResponseErrors "Bad request"
+// @Failure 401 {object} 	handler.ResponseErrors "Unauthorized"
+// @Router  /user/{id}/stats [get]
+func (s *StatisticsController) GetStatistics(c *gin.Context) {
+	var query web.StatisticsQuery
+	ctx := context.Background()
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	statistics, errService := s.Service.FindStatistics(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly get statistics", statistics))
+}
+
+// GetAllStatistics godoc
+// @Security Bearer
+// @Summary Get the statistics of every user
+// @Description The statistics of the user statistics endpoint aggregated across all users, with the number of users who created a Todolist in the range
+// @Tags Admin
+// @Param from	query	string	true "Start date 2006-01-02"
+// @Param to	query	string	true "End date included 2006-01-02"
+// @Param period	query	string	false "day or week"
+// @Produce json
+// @Success	200	{object}	web.StandartResponse
+// @Failure 400 {object} 	handler.ResponseErrors "Bad request"
+// @Failure 401 {object} 	handler.ResponseErrors "Unauthorized"
+// @Failure 403 {object} 	handler.ResponseErrors "Forbidden"
+// @Router  /admin/stats [get]
+func (s *StatisticsController) GetAllStatistics(c *gin.Context) {
+	var query web.StatisticsQuery
+	ctx := context.Background()
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	statistics, errService := s.Service.FindAllStatistics(ctx, web.Params{Query: query})
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly get statistics", statistics))
+}
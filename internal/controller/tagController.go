@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"go_gin/internal/domain/model"
+	"go_gin/internal/domain/model/web"
+	"go_gin/internal/handler"
+	"net/http"
+)
+
+type TagController struct {
+	Service model.TagService
+}
+
+func NewTagController(service model.TagService) *TagController {
+	return &TagController{Service: service}
+}
+
+// GetTags godoc
+// @Summary Get Tags of a Todolist
+// @Description Retrieve the tags of a Todolist as JSON
+// @Tags Tag
+// @Param id	path	string	true "Must Be UUID Format"
+// @Param id	query	int		true "ID todolist"
+// @Produce json
+// @Success	200	{object}	web.StandartResponse
+// @Failure 400 {object} 	handler.ResponseErrors "Bad request"
+// @Failure 401 {object} 	handler.ResponseErrors "Unauthorized"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Router  /user/{id}/todolist/tags [get]
+func (t *TagController) GetTags(c *gin.Context) {
+	var query web.TodoListByIDQuery
+	ctx := context.Background()
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	tags, errService := t.Service.FindTags(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly get tags", map[string]interface{}{
+		"tags": tags,
+	}))
+}
+
+// UpdateTags godoc
+// @Summary Replace Tags of a Todolist
+// @Description Set the tags of a Todolist, tags are lowercased and searched with tag:name
+// @Tags Tag
+// @Param id	path	string	true "Must Be UUID Format"
+// @Param id	query	int		true "ID todolist"
+// @Param request	body	model.TagsRequest	true	"Tags"
+// @Produce json
+// @Success	200	{object}	web.StandartResponse
+// @Failure 400 {object} 	handler.ResponseErrors "Bad request"
+// @Failure 401 {object} 	handler.ResponseErrors "Unauthorized"
+// @Failure 403 {object} 	handler.ResponseErrors "Forbidden"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Router  /user/{id}/todolist/tags [put]
+func (t *TagController) UpdateTags(c *gin.Context) {
+	var request model.TagsRequest
+	var query web.TodoListByIDQuery
+	ctx := context.Background()
+	errQuery := c.ShouldBindQuery(&query)
+	if errQuery != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": err.Error(),
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	tags, errService := t.Service.UpdateTags(ctx, request, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly update tags", map[string]interface{}{
+		"tags": tags,
+	}))
+}
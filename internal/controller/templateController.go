@@ -0,0 +1,241 @@
+package controller
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"go_gin/internal/domain/model"
+	"go_gin/internal/domain/model/web"
+	"go_gin/internal/handler"
+	"net/http"
+)
+
+type TemplateController struct {
+	Service model.TemplateService
+}
+
+func NewTemplateController(service model.TemplateService) *TemplateController {
+	return &TemplateController{Service: service}
+}
+
+// GetTemplates godoc
+// @Summary Get Templates
+// @Description Retrieve the task templates of a user with their items as JSON
+// @Tags Template
+// @Param id	path	string	true	"Must Be UUID Format"
+// @Produce json
+// @Success	200 {object}	web.StandartResponse
+// @Failure 400 {object} handler.ResponseErrors "Bad request"
+// @Failure 401 {object} handler.ResponseErrors "Unauthorized"
+// @Router /user/{id}/templates	[get]
+func (t *TemplateController) GetTemplates(c *gin.Context) {
+	ctx := context.Background()
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID)}
+	templates, errService := t.Service.FindTemplates(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly get templates", map[string]interface{}{
+		"templates": templates,
+	}))
+}
+
+// GetTemplateByID godoc
+// @Summary Get Template By ID
+// @Description Retrieve a task template with its items as JSON
+// @Tags Template
+// @Param id	path	string	true	"Must Be UUID Format"
+// @Param template_id	query	int	true	"ID template"
+// @Produce json
+// @Success	200 {object}	web.StandartResponse
+// @Failure 400 {object} handler.ResponseErrors "Bad request"
+// @Failure 401 {object} handler.ResponseErrors "Unauthorized"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Router /user/{id}/template	[get]
+func (t *TemplateController) GetTemplateByID(c *gin.Context) {
+	var query web.TemplateByIDQuery
+	ctx := context.Background()
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	template, errService := t.Service.FindTemplateByID(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly get template", map[string]interface{}{
+		"template": template,
+	}))
+}
+
+// CreateTemplate godoc
+// @Summary Create Template
+// @Description Save a named set of todolists with due dates relative to the start date (+3d, +2w)
+// @Tags Template
+// @Param id	path	string	true	"Must Be UUID Format"
+// @Param request	body	model.TemplateRequest	true	"Template"
+// @Produce json
+// @Success	200 {object}	web.StandartResponse
+// @Failure 400 {object} handler.ResponseErrors "Bad request"
+// @Failure 401 {object} handler.ResponseErrors "Unauthorized"
+// @Failure 409 {object} handler.ResponseErrors "Conflict"
+// @Router /user/{id}/template	[post]
+func (t *TemplateController) CreateTemplate(c *gin.Context) {
+	var request model.TemplateRequest
+	ctx := context.Background()
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": err.Error(),
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID)}
+	template, errService := t.Service.CreateTemplate(ctx, request, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly create template", map[string]interface{}{
+		"template": template,
+	}))
+}
+
+// UpdateTemplate godoc
+// @Summary Update Template
+// @Description Rename a template and replace its items
+// @Tags Template
+// @Param id	path	string	true	"Must Be UUID Format"
+// @Param template_id	query	int	true	"ID template"
+// @Param request	body	model.TemplateRequest	true	"Template"
+// @Produce json
+// @Success	200 {object}	web.StandartResponse
+// @Failure 400 {object} handler.ResponseErrors "Bad request"
+// @Failure 401 {object} handler.ResponseErrors "Unauthorized"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Failure 409 {object} handler.ResponseErrors "Conflict"
+// @Router /user/{id}/template	[put]
+func (t *TemplateController) UpdateTemplate(c *gin.Context) {
+	var request model.TemplateRequest
+	var query web.TemplateByIDQuery
+	errQuery := c.ShouldBindQuery(&query)
+	ctx := context.Background()
+	if errQuery != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": err.Error(),
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	errService := t.Service.UpdateTemplate(ctx, request, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly update template", nil))
+}
+
+// DeleteTemplate godoc
+// @Summary Delete Template
+// @Description Delete a template, todolists already created from it are kept
+// @Tags Template
+// @Param id	path	string	true	"Must Be UUID Format"
+// @Param template_id	query	int	true	"ID template"
+// @Produce json
+// @Success	200 {object}	web.StandartResponse
+// @Failure 400 {object} handler.ResponseErrors "Bad request"
+// @Failure 401 {object} handler.ResponseErrors "Unauthorized"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Router /user/{id}/template	[delete]
+func (t *TemplateController) DeleteTemplate(c *gin.Context) {
+	var query web.TemplateByIDQuery
+	ctx := context.Background()
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	errService := t.Service.DeleteTemplate(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly delete template", nil))
+}
+
+// InstantiateTemplate godoc
+// @Summary Instantiate Template
+// @Description Create every todolist of a template for user_id in one transaction, due dates counted from start_date and all assigned to assignee_id when given. Only the user itself, an admin or an editor of the project of user_id given as project_id may create them
+// @Tags Template
+// @Param id	path	string	true	"Must Be UUID Format"
+// @Param template_id	query	int	true	"ID template"
+// @Param request	body	model.TemplateInstantiateRequest	true	"Start date and target user"
+// @Produce json
+// @Success	200 {object}	web.StandartResponse
+// @Failure 400 {object} handler.ResponseErrors "Bad request"
+// @Failure 401 {object} handler.ResponseErrors "Unauthorized"
+// @Failure 403 {object} handler.ResponseErrors "Forbidden"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Router /user/{id}/template/instantiate	[post]
+func (t *TemplateController) InstantiateTemplate(c *gin.Context) {
+	var request model.TemplateInstantiateRequest
+	var query web.TemplateByIDQuery
+	errQuery := c.ShouldBindQuery(&query)
+	ctx := context.Background()
+	if errQuery != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": err.Error(),
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	todolists, errService := t.Service.InstantiateTemplate(ctx, request, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly instantiate template", map[string]interface{}{
+		"todolist": todolists,
+	}))
+}
@@ -0,0 +1,333 @@
+package controller
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"go_gin/internal/domain/model"
+	"go_gin/internal/domain/model/web"
+	"go_gin/internal/handler"
+	"net/http"
+)
+
+type TimeController struct {
+	Service model.TimeService
+}
+
+func NewTimeController(service model.TimeService) *TimeController {
+	return &TimeController{Service: service}
+}
+
+// StartTimer godoc
+// @Summary Start a Timer on a Todolist
+// @Description Start tracking time on a Todolist, only one timer can run at a time
+// @Tags Time
+// @Param id	path	string	true "Must Be UUID Format"
+// @Param id	query	int		true "ID todolist"
+// @Param request	body	model.TimerStartRequest	false	"Timer"
+// @Produce json
+// @Success	201	{object}	web.StandartResponse
+// @Failure 400 {object} 	handler.ResponseErrors "Bad request"
+// @Failure 401 {object} 	handler.ResponseErrors "Unauthorized"
+// @Failure 403 {object} 	handler.ResponseErrors "Forbidden"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Failure 409 {object} 	handler.ResponseErrors "Conflict"
+// @Router  /user/{id}/todolist/timer/start [post]
+func (t *TimeController) StartTimer(c *gin.Context) {
+	var request model.TimerStartRequest
+	var query web.TodoListByIDQuery
+	ctx := context.Background()
+	errQuery := c.ShouldBindQuery(&query)
+	if errQuery != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	if c.Request.ContentLength > 0 {
+		err := c.ShouldBindJSON(&request)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status":  http.StatusBadRequest,
+				"message": err.Error(),
+			})
+			return
+		}
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	entry, errService := t.Service.StartTimer(ctx, request, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusCreated, web.NewStandartResponse(http.StatusCreated, "successfuly start timer", map[string]interface{}{
+		"time_entry": entry,
+	}))
+}
+
+// StopTimer godoc
+// @Summary Stop the running Timer
+// @Description Stop the timer running for the user and log its time
+// @Tags Time
+// @Param id	path	string	true "Must Be UUID Format"
+// @Produce json
+// @Success	200	{object}	web.StandartResponse
+// @Failure 400 {object} 	handler.ResponseErrors "Bad request"
+// @Failure 401 {object} 	handler.ResponseErrors "Unauthorized"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Router  /user/{id}/timer/stop [post]
+func (t *TimeController) StopTimer(c *gin.Context) {
+	ctx := context.Background()
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID)}
+	entry, errService := t.Service.StopTimer(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly stop timer", map[string]interface{}{
+		"time_entry": entry,
+	}))
+}
+
+// GetRunningTimer godoc
+// @Summary Get the running Timer
+// @Description Retrieve the timer running for the user, null when none runs
+// @Tags Time
+// @Param id	path	string	true "Must Be UUID Format"
+// @Produce json
+// @Success	200	{object}	web.StandartResponse
+// @Failure 400 {object} 	handler.ResponseErrors "Bad request"
+// @Failure 401 {object} 	handler.ResponseErrors "Unauthorized"
+// @Router  /user/{id}/timer [get]
+func (t *TimeController) GetRunningTimer(c *gin.Context) {
+	ctx := context.Background()
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID)}
+	entry, errService := t.Service.FindRunningTimer(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly get timer", map[string]interface{}{
+		"time_entry": entry,
+	}))
+}
+
+// CreateTimeEntry godoc
+// @Summary Log Time on a Todolist
+// @Description Add a finished time entry to a Todolist
+// @Tags Time
+// @Param id	path	string	true "Must Be UUID Format"
+// @Param id	query	int		true "ID todolist"
+// @Param request	body	model.TimeEntryRequest	true	"Time entry"
+// @Produce json
+// @Success	201	{object}	web.StandartResponse
+// @Failure 400 {object} 	handler.ResponseErrors "Bad request"
+// @Failure 401 {object} 	handler.ResponseErrors "Unauthorized"
+// @Failure 403 {object} 	handler.ResponseErrors "Forbidden"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Router  /user/{id}/todolist/time [post]
+func (t *TimeController) CreateTimeEntry(c *gin.Context) {
+	var request model.TimeEntryRequest
+	var query web.TodoListByIDQuery
+	ctx := context.Background()
+	errQuery := c.ShouldBindQuery(&query)
+	if errQuery != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": err.Error(),
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	entry, errService := t.Service.CreateTimeEntry(ctx, request, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusCreated, web.NewStandartResponse(http.StatusCreated, "successfuly create time entry", map[string]interface{}{
+		"time_entry": entry,
+	}))
+}
+
+// DeleteTimeEntry godoc
+// @Summary Delete a Time entry
+// @Description Delete a time entry of the user
+// @Tags Time
+// @Param id	path	string	true "Must Be UUID Format"
+// @Param entry_id	query	int		true "ID time entry"
+// @Produce json
+// @Success	200	{object}	web.StandartResponse
+// @Failure 400 {object} 	handler.ResponseErrors "Bad request"
+// @Failure 401 {object} 	handler.ResponseErrors "Unauthorized"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Router  /user/{id}/time/entry [delete]
+func (t *TimeController) DeleteTimeEntry(c *gin.Context) {
+	var query web.TimeEntryByIDQuery
+	ctx := context.Background()
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	errService := t.Service.DeleteTimeEntry(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly delete time entry", nil))
+}
+
+// GetTimeSummary godoc
+// @Summary Get the Time of a Todolist
+// @Description Retrieve the time entries of a Todolist with its estimate, actual and remaining minutes
+// @Tags Time
+// @Param id	path	string	true "Must Be UUID Format"
+// @Param id	query	int		true "ID todolist"
+// @Produce json
+// @Success	200	{object}	web.StandartResponse
+// @Failure 400 {object} 	handler.ResponseErrors "Bad request"
+// @Failure 401 {object} 	handler.ResponseErrors "Unauthorized"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Router  /user/{id}/todolist/time [get]
+func (t *TimeController) GetTimeSummary(c *gin.Context) {
+	var query web.TodoListByIDQuery
+	ctx := context.Background()
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	summary, errService := t.Service.FindTimeSummary(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly get time", summary))
+}
+
+// UpdateEstimate godoc
+// @Summary Estimate a Todolist
+// @Description Set the estimate of a Todolist in minutes, null clears it
+// @Tags Time
+// @Param id	path	string	true "Must Be UUID Format"
+// @Param id	query	int		true "ID todolist"
+// @Param request	body	model.TodoListEstimateRequest	true	"Estimate"
+// @Produce json
+// @Success	200	{object}	web.StandartResponse
+// @Failure 400 {object} 	handler.ResponseErrors "Bad request"
+// @Failure 401 {object} 	handler.ResponseErrors "Unauthorized"
+// @Failure 403 {object} 	handler.ResponseErrors "Forbidden"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Router  /user/{id}/todolist/estimate [put]
+func (t *TimeController) UpdateEstimate(c *gin.Context) {
+	var request model.TodoListEstimateRequest
+	var query web.TodoListByIDQuery
+	ctx := context.Background()
+	errQuery := c.ShouldBindQuery(&query)
+	if errQuery != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": err.Error(),
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	errService := t.Service.UpdateEstimate(ctx, request, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly update estimate", nil))
+}
+
+// GetTimeReport godoc
+// @Summary Get a Time report
+// @Description Sum the logged minutes per day or week, of the user by project or of a project by user, as json or csv
+// @Tags Time
+// @Param id	path	string	true "Must Be UUID Format"
+// @Param from	query	string	true "Start date 2006-01-02"
+// @Param to	query	string	true "End date included 2006-01-02"
+// @Param period	query	string	false "day or week"
+// @Param project_id	query	int	false "ID project"
+// @Param format	query	string	false "json or csv"
+// @Produce json
+// @Produce text/csv
+// @Success	200	{object}	web.StandartResponse
+// @Failure 400 {object} 	handler.ResponseErrors "Bad request"
+// @Failure 401 {object} 	handler.ResponseErrors "Unauthorized"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Router  /user/{id}/time/report [get]
+func (t *TimeController) GetTimeReport(c *gin.Context) {
+	var query web.TimeReportQuery
+	ctx := context.Background()
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	rows, errService := t.Service.FindTimeReport(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	if query.Format == "csv" {
+		data, errCSV := rows.CSV()
+		if errCSV != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"status":  http.StatusInternalServerError,
+				"message": errCSV.Error(),
+			})
+			return
+		}
+		c.Header("Content-Disposition", "attachment; filename=time-report.csv")
+		c.Data(http.StatusOK, "text/csv", data)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly get time report", map[string]interface{}{
+		"report": rows,
+	}))
+}
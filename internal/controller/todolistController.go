@@ -3,9 +3,12 @@ package controller
 import (
 	"context"
 	"github.com/gin-gonic/gin"
+	"go_gin/internal/config"
 	"go_gin/internal/domain/model"
 	"go_gin/internal/domain/model/web"
 	"go_gin/internal/handler"
+	"go_gin/pkg/taskio"
+	"log"
 	"net/http"
 )
 
@@ -20,11 +23,14 @@ type TodoListController struct {
 // @Param id	path	string	true "Must Be UUID Format"
 // @Param id	query	int	true "ID Todolist"
 // @Param request	body	model.TodoListRequest	true	"Object Todolist for Update Todolist"
+// @Param If-Match	header	string	false "ETag the Todolist must still have"
 // @Produce	json
 // @Success	200	{object}	web.StandartResponse
 // @Failure 400 {object} 	handler.ResponseErrors "Bad request"
 // @Failure 401 {object} 	handler.ResponseErrors "Unauthorized"
 // @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Failure 412 {object} 	handler.ResponseErrors "Precondition Failed"
+// @Failure 428 {object} 	handler.ResponseErrors "Precondition Required"
 // @Router  /user/{id}/todolist [put]
 func (t *TodoListController) UpdateTodoList(c *gin.Context) {
 	var request model.TodoListRequest
@@ -47,27 +53,240 @@ func (t *TodoListController) UpdateTodoList(c *gin.Context) {
 		return
 	}
 	userID := c.Param("id")
-	params := web.Params{UserID: web.UserID(userID), Query: query}
-	errService := t.Service.UpdateTodoList(ctx, request, params)
+	params := web.Params{UserID: web.UserID(userID), Query: query, IfMatch: c.GetHeader("If-Match")}
+	version, errService := t.Service.UpdateTodoList(ctx, request, params)
 	if errService != nil {
 		responseErrors := handler.NewResponseErrors(errService)
 		c.JSON(responseErrors.Status, responseErrors)
 		return
 	}
+	c.Header("ETag", web.ETag(version))
 	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly update todo list", nil))
 }
 
+// ImportTodolists	godoc
+// @Summary	Import Todolists
+// @Description Create Todolists from a CSV, JSON array, NDJSON, Todoist CSV, Trello JSON or iCalendar (VTODO) export sent as the body, all or nothing. Every record that cannot be imported is reported with its line
+// @Tags Todolist
+// @Param id	path	string	true "Must Be UUID Format"
+// @Param format	query	string	true "csv, json, ndjson, todoist, trello or ics"
+// @Param mapping	query	string	false "Columns of the file to fields as column=field pairs, comma separated"
+// @Param dry_run	query	bool	false "Only check the records"
+// @Produce	json
+// @Success	200	{object}	web.StandartResponse
+// @Failure 400 {object} 	handler.ResponseErrors "Bad request"
+// @Failure 401 {object} 	handler.ResponseErrors "Unauthorized"
+// @Failure 422 {object} 	web.StandartResponse "Some records cannot be imported, nothing was created"
+// @Router  /user/{id}/todolists/import [post]
+func (t *TodoListController) ImportTodoLists(c *gin.Context) {
+	var query web.TodoListImportQuery
+	ctx := context.Background()
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	if config.Other.MaxImportSize > 0 {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, config.Other.MaxImportSize)
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	response, errService := t.Service.ImportTodoLists(ctx, c.Request.Body, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	if response.Failed > 0 {
+		c.JSON(http.StatusUnprocessableEntity, web.NewStandartResponse(http.StatusUnprocessableEntity, "some records cannot be imported, nothing was created", response))
+		return
+	}
+	message := "successfuly import todo lists"
+	if response.DryRun {
+		message = "dry run, nothing was created"
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, message, response))
+}
+
+// ExportTodolists	godoc
+// @Summary	Export Todolists
+// @Description Stream the Todolists matching q as CSV, a JSON array or NDJSON
+// @Tags Todolist
+// @Param id	path	string	true "Must Be UUID Format"
+// @Param format	query	string	false "csv, json or ndjson, json by default"
+// @Param fields	query	string	false "Comma separated fields to export, all by default"
+// @Param q	query	string	false "Search query the Todolists must match"
+// @Produce	json
+// @Produce	text/csv
+// @Produce	application/x-ndjson
+// @Success	200
+// @Failure 400 {object} 	handler.ResponseErrors "Bad request"
+// @Failure 401 {object} 	handler.ResponseErrors "Unauthorized"
+// @Router  /user/{id}/todolists/export [get]
+func (t *TodoListController) ExportTodoLists(c *gin.Context) {
+	var query web.TodoListExportQuery
+	ctx := context.Background()
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	contentType, extension := "application/json", taskio.FormatJSON
+	switch query.Format {
+	case taskio.FormatCSV:
+		contentType, extension = "text/csv", taskio.FormatCSV
+	case taskio.FormatNDJSON:
+		contentType, extension = "application/x-ndjson", taskio.FormatNDJSON
+	}
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", "attachment; filename=todolists."+extension)
+	errService := t.Service.ExportTodoLists(ctx, params, c.Writer)
+	if errService != nil {
+		if c.Writer.Written() {
+			// the status is out already, the client sees a truncated file
+			log.Printf("todolist export: %s\n", errService)
+			return
+		}
+		c.Writer.Header().Del("Content-Type")
+		c.Writer.Header().Del("Content-Disposition")
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+	}
+}
+
+// UpdateTodolists	godoc
+// @Summary	Bulk Update Todolists
+// @Description Apply the same field changes to the Todolists in ids or to those matching filter, all or nothing, with a result per Todolist. A dry run reports without writing
+// @Tags Todolist
+// @Param id	path	string	true "Must Be UUID Format"
+// @Param request	body	model.TodoListBulkUpdateRequest	true	"Todolists and the Patch to apply"
+// @Produce	json
+// @Success	200	{object}	web.StandartResponse
+// @Failure 400 {object} 	handler.ResponseErrors "Bad request"
+// @Failure 401 {object} 	handler.ResponseErrors "Unauthorized"
+// @Failure 409 {object} 	web.StandartResponse "Some Todolists failed, nothing was updated"
+// @Router  /user/{id}/todolists [patch]
+func (t *TodoListController) UpdateTodoLists(c *gin.Context) {
+	var request model.TodoListBulkUpdateRequest
+	ctx := context.Background()
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": err.Error(),
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID)}
+	response, errService := t.Service.UpdatesTodoLists(ctx, request, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	if response.Failed > 0 {
+		c.JSON(http.StatusConflict, web.NewStandartResponse(http.StatusConflict, "some todo list cannot be updated, nothing was updated", response))
+		return
+	}
+	message := "successfuly update todo lists"
+	if response.DryRun {
+		message = "dry run, nothing was updated"
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, message, response))
+}
+
+// UpdateTodolistStatus	godoc
+// @Summary	Update Todolist Status
+// @Description Move Todolist to another status of the workflow
+// @Tags Todolist
+// @Param id	path	string	true "Must Be UUID Format"
+// @Param id	query	int	true "ID Todolist"
+// @Param request	body	model.TodoListStatusRequest	true	"Target Status"
+// @Produce	json
+// @Success	200	{object}	web.StandartResponse
+// @Failure 400 {object} 	handler.ResponseErrors "Bad request"
+// @Failure 401 {object} 	handler.ResponseErrors "Unauthorized"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Router  /user/{id}/todolist/status [patch]
+func (t *TodoListController) UpdateTodoListStatus(c *gin.Context) {
+	var request model.TodoListStatusRequest
+	var query web.TodoListByIDQuery
+	errQuery := c.ShouldBindQuery(&query)
+	ctx := context.Background()
+	if errQuery != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": err.Error(),
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	errService := t.Service.UpdateTodoListStatus(ctx, request, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly update todo list status", nil))
+}
+
+// GetTodolistGroupByStatus godoc
+// @Summary Get Todolist grouped by status
+// @Description Retrieve Todolist grouped by workflow status with counts as JSON
+// @Tags Todolist
+// @Param id	path	string 	true	"Must Be UUID Format"
+// @Produce json
+// @Success	200 {object}	web.StandartResponse
+// @Failure 400 {object} handler.ResponseErrors "Bad request"
+// @Failure 401 {object} handler.ResponseErrors "Unauthorized"
+// @Router /user/{id}/todolists/status	[get]
+func (t *TodoListController) GetTodoListGroupByStatus(c *gin.Context) {
+	ctx := context.Background()
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID)}
+	groups, errService := t.Service.FindTodoListsGroupByStatus(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly get group by status", map[string]interface{}{
+		"groups": groups,
+	}))
+}
+
 // DeleteTodolistByID godoc
 // @Summary	Delete Todolist By ID
-// @Description Retrieve a object Todolist as JSON
+// @Description Move a Todolist to the trash, the returned undo token restores it for a few minutes
 // @Tags Todolist
 // @Param id	path	string	true "Must Be UUID Format"
 // @Param id 		query	int		true "ID todolist"
+// @Param If-Match	header	string	false "ETag the Todolist must still have"
 // @Produce	json
 // @Success	200	{object}	web.StandartResponse
 // @Failure 400 {object} 	handler.ResponseErrors "Bad request"
 // @Failure 401 {object} 	handler.ResponseErrors "Unauthorized"
 // @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Failure 412 {object} 	handler.ResponseErrors "Precondition Failed"
+// @Failure 428 {object} 	handler.ResponseErrors "Precondition Required"
 // @Router  /user/{id}/todolist [delete]
 func (t *TodoListController) DeleteTodoList(c *gin.Context) {
 	var query web.TodoListByIDQuery
@@ -82,21 +301,22 @@ func (t *TodoListController) DeleteTodoList(c *gin.Context) {
 	}
 	userID := c.Param("id")
 	params := web.Params{
-		UserID: web.UserID(userID),
-		Query:  query,
+		UserID:  web.UserID(userID),
+		Query:   query,
+		IfMatch: c.GetHeader("If-Match"),
 	}
-	errService := t.Service.DeleteTodoList(ctx, params)
+	undo, errService := t.Service.DeleteTodoList(ctx, params)
 	if errService != nil {
 		responseErrors := handler.NewResponseErrors(errService)
 		c.JSON(responseErrors.Status, responseErrors)
 		return
 	}
-	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly delete todo list", nil))
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly delete todo list", undo))
 }
 
 // DeleteTodolistByID godoc
 // @Summary	Delete Todolist By ID
-// @Description Retrieve a object Todolist as JSON
+// @Description Move Todolists to the trash, the returned undo token restores them all for a few minutes
 // @Tags Todolist
 // @Param id	path	string	true "Must Be UUID Format"
 // @Param id 		query	[]int		true "ID todolist"
@@ -119,13 +339,13 @@ func (t *TodoListController) DeleteTodoLists(c *gin.Context) {
 	}
 	query := web.TodoListByIDsQuery{IDs: ids}
 	params := web.Params{UserID: web.UserID(userID), Query: query}
-	errService := t.Service.DeletesTodoLists(ctx, params)
+	undo, errService := t.Service.DeletesTodoLists(ctx, params)
 	if errService != nil {
 		responseErrors := handler.NewResponseErrors(errService)
 		c.JSON(responseErrors.Status, responseErrors)
 		return
 	}
-	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly delete todo list", nil))
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly delete todo list", undo))
 }
 
 func NewTodoListController(service model.TodoListService) *TodoListController {
@@ -134,11 +354,12 @@ func NewTodoListController(service model.TodoListService) *TodoListController {
 
 // Todolist godoc
 // @Summary Get Todolist array by search key
-// @Description Retrieve a list of all Todolist as JSON
+// @Description Full-text search over task name and description, best matches first with the matched words highlighted in <mark>
 // @Tags Todolist
 // @Param id	path	string 	true "Must be UUID Format"
-// @Param search query string true "search keywords for task name, description"
+// @Param search query string true "search keywords for task name, description, every word matches as a prefix"
 // @Param page query int true "Page number"
+// @Param limit query int false "Page size, capped by the server"
 // @Produce json
 // @Success 200 {object} web.StandartResponse
 // @Failure 400 {object} handler.ResponseErrors "Bad request"
@@ -178,15 +399,34 @@ func (t *TodoListController) GetTodoListSearch(c *gin.Context) {
 // @Description Retrieve a list all Todolist as JSON
 // @Tags Todolist
 // @Param id	path	string 	true	"Must Be UUID Format"
-// @Param page	query	int		true	"Page Number"
+// @Param page	query	int		false	"Page Number"
+// @Param limit	query	int	false	"Page size, capped by the server"
+// @Param cursor	query	string	false	"Cursor of the page to fetch, from pagination.NextCursor or pagination.PrevCursor"
+// @Param paginate	query	string	false	"Set to cursor to start keyset pagination"	Enums(offset, cursor)
+// @Param status	query	[]string	false	"Filter by status"
+// @Param completed	query	bool	false	"Filter by completed"
+// @Param priority_min	query	int	false	"Lowest priority"
+// @Param priority_max	query	int	false	"Highest priority"
+// @Param due_from	query	string	false	"Due on or after, YYYY-MM-DD"
+// @Param due_to	query	string	false	"Due on or before, YYYY-MM-DD"
+// @Param overdue	query	bool	false	"Past due and not completed"
+// @Param created_from	query	string	false	"Created on or after, YYYY-MM-DD"
+// @Param created_to	query	string	false	"Created on or before, YYYY-MM-DD"
+// @Param updated_from	query	string	false	"Updated on or after, YYYY-MM-DD"
+// @Param updated_to	query	string	false	"Updated on or before, YYYY-MM-DD"
+// @Param sort	query	string	false	"Comma separated keys, - for descending, e.g. -priority,due_date"
+// @Param fields	query	string	false	"Comma separated fields to return, e.g. task_name,due_date"
+// @Param q	query	string	false	"Search query, e.g. priority>=3 due<2026-11-01 tag:work -completed \"quarterly report\""
+// @Param search_id	query	int	false	"Run a saved search, combined with q"
 // @Produce json
 // @Success	200 {object}	web.StandartResponse
+// @Header	200	{string}	Link	"Links to the next and previous pages"
 // @Failure 400 {object} handler.ResponseErrors "Bad request"
 // @Failure 401 {object} handler.ResponseErrors "Unauthorized"
 // @Failed	404	{object}	handler.ResponseErrors "Not Found"
 // @Router /user/{id}/todolists	[get]
 func (t *TodoListController) GetTodoListAll(c *gin.Context) {
-	var query web.GetAllQuery
+	var query web.TodoListsQuery
 	ctx := context.Background()
 	err := c.ShouldBindQuery(&query)
 	if err != nil {
@@ -207,8 +447,23 @@ func (t *TodoListController) GetTodoListAll(c *gin.Context) {
 		c.JSON(responseErrors.Status, responseErrors)
 		return
 	}
+	var todolists interface{} = responses
+	if fields, _ := web.ParseFields(query.Fields, web.TodoListFields); len(fields) > 0 {
+		todolists, err = responses.SelectFields(fields)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"status":  http.StatusInternalServerError,
+				"message": err.Error(),
+			})
+			return
+		}
+	}
+	pagination.SetLinks(c.Request.URL)
+	if link := pagination.LinkHeader(); link != "" {
+		c.Header("Link", link)
+	}
 	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly get all", map[string]interface{}{
-		"todolist":   responses,
+		"todolist":   todolists,
 		"pagination": pagination,
 	}))
 }
@@ -219,8 +474,10 @@ func (t *TodoListController) GetTodoListAll(c *gin.Context) {
 // @Tags Todolist
 // @Param id	path	string	true "Must Be UUID Format"
 // @Param id 		query	int		true "ID todolist"
+// @Param If-None-Match	header	string	false "ETag of the cached Todolist"
 // @Produce	json
 // @Success	200	{object}	web.StandartResponse
+// @Success	304	"Not Modified"
 // @Failure 400 {object} 	handler.ResponseErrors "Bad request"
 // @Failure 401 {object} 	handler.ResponseErrors "Unauthorized"
 // @Failed	404	{object} 	handler.ResponseErrors "Not Found"
@@ -247,6 +504,11 @@ func (t *TodoListController) GetTodoListByID(c *gin.Context) {
 		c.JSON(responseErrors.Status, responseErrors)
 		return
 	}
+	c.Header("ETag", web.ETag(response.Version))
+	if ifNoneMatch := c.GetHeader("If-None-Match"); ifNoneMatch != "" && web.MatchWeakETag(ifNoneMatch, response.Version) {
+		c.Status(http.StatusNotModified)
+		return
+	}
 	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly get all", map[string]interface{}{
 		"todolist": response,
 	}))
@@ -271,7 +533,7 @@ func (t *TodoListController) CreateTodoList(c *gin.Context) {
 
 	userID := c.Param("id")
 	params := web.Params{UserID: web.UserID(userID)}
-	errService := t.Service.CreateTodoList(ctx, request, params)
+	_, errService := t.Service.CreateTodoList(ctx, request, params)
 	if errService != nil {
 		responseErrors := handler.NewResponseErrors(errService)
 		c.JSON(responseErrors.Status, responseErrors)
@@ -313,3 +575,445 @@ func (t *TodoListController) CreatesTodoLists(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly creates todo list", nil))
 }
+
+// GetSharedTodoLists godoc
+// @Summary Get Todolist shared with me
+// @Description Retrieve Todolist other users shared with the user, directly or through a project
+// @Tags Todolist
+// @Param id	path	string	true	"Must Be UUID Format"
+// @Param page	query	int		true	"Page Number"
+// @Produce json
+// @Success	200 {object}	web.StandartResponse
+// @Failure 400 {object} handler.ResponseErrors "Bad request"
+// @Failure 401 {object} handler.ResponseErrors "Unauthorized"
+// @Router /user/{id}/todolists/shared	[get]
+func (t *TodoListController) GetSharedTodoLists(c *gin.Context) {
+	var query web.GetAllQuery
+	ctx := context.Background()
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	todolists, pagination, errService := t.Service.FindSharedTodoLists(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly get shared todo list", map[string]interface{}{
+		"todolist":   todolists,
+		"pagination": pagination,
+	}))
+}
+
+// GetAssignedTodoLists godoc
+// @Summary Get Todolist assigned to me
+// @Description Retrieve Todolist assigned to the user, whoever owns them
+// @Tags Todolist
+// @Param id	path	string	true	"Must Be UUID Format"
+// @Param page	query	int		true	"Page Number"
+// @Produce json
+// @Success	200 {object}	web.StandartResponse
+// @Failure 400 {object} handler.ResponseErrors "Bad request"
+// @Failure 401 {object} handler.ResponseErrors "Unauthorized"
+// @Router /user/{id}/todolists/assigned	[get]
+func (t *TodoListController) GetAssignedTodoLists(c *gin.Context) {
+	var query web.GetAllQuery
+	ctx := context.Background()
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	todolists, pagination, errService := t.Service.FindAssignedTodoLists(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly get assigned todo list", map[string]interface{}{
+		"todolist":   todolists,
+		"pagination": pagination,
+	}))
+}
+
+// AssignTodoList	godoc
+// @Summary	Assign Todolist
+// @Description Assign Todolist to another user, a null assignee_id unassigns it
+// @Tags Todolist
+// @Param id	path	string	true "Must Be UUID Format"
+// @Param id	query	int	true "ID Todolist"
+// @Param request	body	model.TodoListAssignRequest	true	"Assignee"
+// @Produce	json
+// @Success	200	{object}	web.StandartResponse
+// @Failure 400 {object} 	handler.ResponseErrors "Bad request"
+// @Failure 401 {object} 	handler.ResponseErrors "Unauthorized"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Router  /user/{id}/todolist/assignee [patch]
+func (t *TodoListController) AssignTodoList(c *gin.Context) {
+	var request model.TodoListAssignRequest
+	var query web.TodoListByIDQuery
+	errQuery := c.ShouldBindQuery(&query)
+	ctx := context.Background()
+	if errQuery != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": err.Error(),
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	errService := t.Service.AssignTodoList(ctx, request, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly assign todo list", nil))
+}
+
+// MoveTodoList	godoc
+// @Summary	Move Todolist
+// @Description Move a Todolist right after after_id or right before before_id in the manual order of its list
+// @Tags Todolist
+// @Param id	path	string	true "Must Be UUID Format"
+// @Param id	query	int	true "ID Todolist"
+// @Param request	body	model.TodoListMoveRequest	true	"Neighbour"
+// @Produce	json
+// @Success	200	{object}	web.StandartResponse
+// @Failure 400 {object} 	handler.ResponseErrors "Bad request"
+// @Failure 401 {object} 	handler.ResponseErrors "Unauthorized"
+// @Failure 403 {object} 	handler.ResponseErrors "Forbidden"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Router  /user/{id}/todolist/position [patch]
+func (t *TodoListController) MoveTodoList(c *gin.Context) {
+	var request model.TodoListMoveRequest
+	var query web.TodoListByIDQuery
+	errQuery := c.ShouldBindQuery(&query)
+	ctx := context.Background()
+	if errQuery != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": err.Error(),
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	todolist, errService := t.Service.MoveTodoList(ctx, request, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly move todo list", map[string]interface{}{
+		"todolist": todolist,
+	}))
+}
+
+// GetAssignments godoc
+// @Summary Get Todolist assignment history
+// @Description Retrieve every reassignment of a Todolist, oldest first
+// @Tags Todolist
+// @Param id	path	string	true "Must Be UUID Format"
+// @Param id	query	int	true "ID Todolist"
+// @Produce json
+// @Success	200 {object}	web.StandartResponse
+// @Failure 400 {object} handler.ResponseErrors "Bad request"
+// @Failure 401 {object} handler.ResponseErrors "Unauthorized"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Router /user/{id}/todolist/assignments	[get]
+func (t *TodoListController) GetAssignments(c *gin.Context) {
+	var query web.TodoListByIDQuery
+	ctx := context.Background()
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	assignments, errService := t.Service.FindAssignments(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly get assignments", map[string]interface{}{
+		"assignments": assignments,
+	}))
+}
+
+// GetTrashedTodoLists godoc
+// @Summary Get Todolist in the trash
+// @Description Retrieve the deleted Todolist of the user, latest deleted first, until they are purged
+// @Tags Trash
+// @Param id	path	string	true	"Must Be UUID Format"
+// @Param page	query	int		true	"Page Number"
+// @Produce json
+// @Success	200 {object}	web.StandartResponse
+// @Failure 400 {object} handler.ResponseErrors "Bad request"
+// @Failure 401 {object} handler.ResponseErrors "Unauthorized"
+// @Router /user/{id}/trash	[get]
+func (t *TodoListController) GetTrashedTodoLists(c *gin.Context) {
+	var query web.GetAllQuery
+	ctx := context.Background()
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	todolists, pagination, errService := t.Service.FindTrashedTodoLists(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly get trash", map[string]interface{}{
+		"todolist":   todolists,
+		"pagination": pagination,
+	}))
+}
+
+// RestoreTodoList godoc
+// @Summary Restore Todolist By ID
+// @Description Restore a Todolist from the trash
+// @Tags Trash
+// @Param id	path	string	true "Must Be UUID Format"
+// @Param id	query	int		true "ID todolist"
+// @Produce json
+// @Success	200	{object}	web.StandartResponse
+// @Failure 400 {object} 	handler.ResponseErrors "Bad request"
+// @Failure 401 {object} 	handler.ResponseErrors "Unauthorized"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Router  /user/{id}/todolist/restore [patch]
+func (t *TodoListController) RestoreTodoList(c *gin.Context) {
+	var query web.TodoListByIDQuery
+	ctx := context.Background()
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	errService := t.Service.RestoreTodoList(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly restore todo list", nil))
+}
+
+// RestoreTodoLists godoc
+// @Summary Restore Todolists By IDs
+// @Description Restore Todolists from the trash
+// @Tags Trash
+// @Param id	path	string	true "Must Be UUID Format"
+// @Param id	query	[]int	true "ID todolist"
+// @Produce json
+// @Success	200	{object}	web.StandartResponse
+// @Failure 400 {object} 	handler.ResponseErrors "Bad request"
+// @Failure 401 {object} 	handler.ResponseErrors "Unauthorized"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Router  /user/{id}/todolists/restore [patch]
+func (t *TodoListController) RestoreTodoLists(c *gin.Context) {
+	ids := c.QueryArray("id")
+	userID := c.Param("id")
+	ctx := context.Background()
+	if len(ids) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	query := web.TodoListByIDsQuery{IDs: ids}
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	errService := t.Service.RestoresTodoLists(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly restore todo list", nil))
+}
+
+// UndoDeleteTodoLists godoc
+// @Summary Undo a Todolist delete
+// @Description Restore every Todolist removed by the delete call that returned the undo token
+// @Tags Trash
+// @Param id	path	string	true "Must Be UUID Format"
+// @Param request	body	model.TodoListUndoRequest	true	"Undo token"
+// @Produce json
+// @Success	200	{object}	web.StandartResponse
+// @Failure 400 {object} 	handler.ResponseErrors "Bad request"
+// @Failure 401 {object} 	handler.ResponseErrors "Unauthorized"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Router  /user/{id}/todolists/undo [post]
+func (t *TodoListController) UndoDeleteTodoLists(c *gin.Context) {
+	var request model.TodoListUndoRequest
+	ctx := context.Background()
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": err.Error(),
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID)}
+	errService := t.Service.UndoDeleteTodoLists(ctx, request, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly undo delete", nil))
+}
+
+// EmptyTrash godoc
+// @Summary Empty the trash
+// @Description Delete every Todolist in the trash for good, with their comments and attachments
+// @Tags Trash
+// @Param id	path	string	true "Must Be UUID Format"
+// @Produce json
+// @Success	200	{object}	web.StandartResponse
+// @Failure 400 {object} 	handler.ResponseErrors "Bad request"
+// @Failure 401 {object} 	handler.ResponseErrors "Unauthorized"
+// @Router  /user/{id}/trash [delete]
+func (t *TodoListController) EmptyTrash(c *gin.Context) {
+	ctx := context.Background()
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID)}
+	errService := t.Service.EmptyTrash(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly empty trash", nil))
+}
+
+// GetRevisions godoc
+// @Summary Get the history of a Todolist
+// @Description Retrieve the revisions of a Todolist newest first, with who changed which fields and when
+// @Tags Revision
+// @Param id	path	string	true "Must Be UUID Format"
+// @Param id	query	int		true "ID todolist"
+// @Produce json
+// @Success	200	{object}	web.StandartResponse
+// @Failure 400 {object} 	handler.ResponseErrors "Bad request"
+// @Failure 401 {object} 	handler.ResponseErrors "Unauthorized"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Router  /user/{id}/todolist/revisions [get]
+func (t *TodoListController) GetRevisions(c *gin.Context) {
+	var query web.TodoListByIDQuery
+	ctx := context.Background()
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	revisions, errService := t.Service.FindRevisions(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly get revisions", map[string]interface{}{
+		"revisions": revisions,
+	}))
+}
+
+// RevertTodoList godoc
+// @Summary Revert a Todolist to a revision
+// @Description Write the fields of a previous revision back as a new update
+// @Tags Revision
+// @Param id	path	string	true "Must Be UUID Format"
+// @Param id	query	int		true "ID todolist"
+// @Param request	body	model.TodoListRevertRequest	true	"Revision number"
+// @Produce json
+// @Success	200	{object}	web.StandartResponse
+// @Failure 400 {object} 	handler.ResponseErrors "Bad request"
+// @Failure 401 {object} 	handler.ResponseErrors "Unauthorized"
+// @Failure 403 {object} 	handler.ResponseErrors "Forbidden"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Failure 409 {object} 	handler.ResponseErrors "Conflict"
+// @Router  /user/{id}/todolist/revert [post]
+func (t *TodoListController) RevertTodoList(c *gin.Context) {
+	var request model.TodoListRevertRequest
+	var query web.TodoListByIDQuery
+	ctx := context.Background()
+	errQuery := c.ShouldBindQuery(&query)
+	if errQuery != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": err.Error(),
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	errService := t.Service.RevertTodoList(ctx, request, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly revert todo list", nil))
+}
@@ -22,15 +22,19 @@ type UsersController struct {
 // @Summary Get Users array
 // @Description Retrieve a list of all users as JSON
 // @Tags Admin
-// @Param page query int true "Page number"
+// @Param page query int false "Page number"
+// @Param limit query int false "Page size, capped by the server"
+// @Param cursor query string false "Cursor of the page to fetch, from pagination.NextCursor or pagination.PrevCursor"
+// @Param paginate query string false "Set to cursor to start keyset pagination" Enums(offset, cursor)
 // @Produce json
 // @Success 200 {object} web.StandartResponse
+// @Header 200 {string} Link "Links to the next and previous pages"
 // @Failure 400 {object} handler.ResponseErrors "Bad request"
 // @Failure 401 {object} handler.ResponseErrors "Unauthorized"
 // @Failure 403 {object} handler.ResponseErrors "Forbidden"
 // @Router /admin/users [get]
 func (u *UsersController) GetAll(c *gin.Context) {
-	var queryParams web.GetAllQuery
+	var queryParams web.UsersQuery
 	ctx := context.Background()
 	c.ShouldBindQuery(&queryParams)
 
@@ -40,6 +44,10 @@ func (u *UsersController) GetAll(c *gin.Context) {
 		c.JSON(responseErrors.Status, responseErrors)
 		return
 	}
+	pagination.SetLinks(c.Request.URL)
+	if link := pagination.LinkHeader(); link != "" {
+		c.Header("Link", link)
+	}
 	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "Succesefully Get All", map[string]interface{}{
 		"users":      users,
 		"pagination": pagination,
@@ -52,8 +60,10 @@ func (u *UsersController) GetAll(c *gin.Context) {
 // @Description Retrieve user details by ID
 // @Tags Admin
 // @Param id path string true "Must be in UUID format"
+// @Param If-None-Match header string false "ETag of the cached user"
 // @Produce json
 // @Success 200 {object} web.StandartResponse
+// @Success 304 "Not Modified"
 // @Failure 400 {object} handler.ResponseErrors "Bad request format"
 // @Failure 404 {object} handler.ResponseErrors "User not found"
 // @Router /admin/user/{id} [get]
@@ -73,6 +83,11 @@ func (u *UsersController) GetByID(c *gin.Context) {
 		c.JSON(responseErrors.Status, responseErrors)
 		return
 	}
+	c.Header("ETag", web.ETag(user.Version))
+	if ifNoneMatch := c.GetHeader("If-None-Match"); ifNoneMatch != "" && web.MatchWeakETag(ifNoneMatch, user.Version) {
+		c.Status(http.StatusNotModified)
+		return
+	}
 	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "Succesefully Get By ID", map[string]interface{}{
 		"user": user,
 	}))
@@ -85,6 +100,7 @@ func (u *UsersController) GetByID(c *gin.Context) {
 // @Tags Admin
 // @Param search query string true "Search users by key"
 // @Param page query int true "Page number"
+// @Param limit query int false "Page size, capped by the server"
 // @Produce json
 // @Success 200 {object} web.StandartResponse
 // @Failure 400 {object} handler.ResponseErrors "Invalid query parameters"
@@ -324,10 +340,13 @@ func (u *UsersController) LoginUser(c *gin.Context) {
 // @Tags All
 // @Param request body model.UserLoginUpdateRequest true "Update Request Body"
 // @Param id path string true "Must be in UUID format"
+// @Param If-Match header string false "ETag the user must still have"
 // @Produce json
 // @Success 200 {object} web.StandartResponse
 // @Failure 400 {object} handler.ResponseErrors "Bad request format"
 // @Failure 404 {object} handler.ResponseErrors "User not found"
+// @Failure 412 {object} handler.ResponseErrors "Precondition Failed"
+// @Failure 428 {object} handler.ResponseErrors "Precondition Required"
 // @Router /user/{id} [put]
 func (u *UsersController) UpdateUserID(c *gin.Context) {
 	var userRequest model.UserLoginUpdateRequest
@@ -342,13 +361,14 @@ func (u *UsersController) UpdateUserID(c *gin.Context) {
 		c.JSON(responseErrors.Status, responseErrors)
 		return
 	}
-	err = u.Service.UpdateUserID(ctx, userRequest, ID)
+	version, err := u.Service.UpdateUserID(ctx, userRequest, ID, c.GetHeader("If-Match"))
 	fmt.Println(err, ID, userRequest)
 	if err != nil {
 		responseErrors := handler.NewResponseErrors(err)
 		c.JSON(responseErrors.Status, responseErrors)
 		return
 	}
+	c.Header("ETag", web.ETag(version))
 	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "Succesefully Update", map[string]interface{}{
 		"id": ID,
 	}))
@@ -398,11 +418,14 @@ func (u *UsersController) LogoutUser(c *gin.Context) {
 // @Description Delete user by ID
 // @Tags Admin
 // @Param id path string true "Must be in UUID format"
+// @Param If-Match header string false "ETag the user must still have"
 // @Produce json
 // @Success 200 {object} web.StandartResponse
 // @Failure 400 {object} handler.ResponseErrors "Bad request format"
 // @Failure 401 {object} handler.ResponseErrors "Unauthorized"
 // @Failure 404 {object} handler.ResponseErrors "User not found"
+// @Failure 412 {object} handler.ResponseErrors "Precondition Failed"
+// @Failure 428 {object} handler.ResponseErrors "Precondition Required"
 // @Router /admin/user/{id} [delete]
 func (u *UsersController) DeleteUserByID(c *gin.Context) {
 	ctx := context.Background()
@@ -415,7 +438,7 @@ func (u *UsersController) DeleteUserByID(c *gin.Context) {
 		c.JSON(responseErrors.Status, responseErrors)
 		return
 	}
-	err = u.Service.DeleteUserByID(ctx, ID)
+	err = u.Service.DeleteUserByID(ctx, ID, c.GetHeader("If-Match"))
 	if err != nil {
 		responseErrors := handler.NewResponseErrors(err)
 		c.JSON(responseErrors.Status, responseErrors)
@@ -0,0 +1,339 @@
+package controller
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"go_gin/internal/domain/model"
+	"go_gin/internal/domain/model/web"
+	"go_gin/internal/handler"
+	"net/http"
+)
+
+type ViewController struct {
+	Service model.ViewService
+}
+
+func NewViewController(service model.ViewService) *ViewController {
+	return &ViewController{Service: service}
+}
+
+// GetViews godoc
+// @Summary Get Views
+// @Description Retrieve the views of a user and the views shared with them as JSON
+// @Tags View
+// @Param id	path	string	true	"Must Be UUID Format"
+// @Produce json
+// @Success	200 {object}	web.StandartResponse
+// @Failure 400 {object} handler.ResponseErrors "Bad request"
+// @Failure 401 {object} handler.ResponseErrors "Unauthorized"
+// @Router /user/{id}/views	[get]
+func (v *ViewController) GetViews(c *gin.Context) {
+	ctx := context.Background()
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID)}
+	views, errService := v.Service.FindViews(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly get views", map[string]interface{}{
+		"views": views,
+	}))
+}
+
+// CreateView godoc
+// @Summary Create View
+// @Description Save a view, a named query with a sort and an optional grouping (status, priority or completed)
+// @Tags View
+// @Param id	path	string	true	"Must Be UUID Format"
+// @Param request	body	model.ViewRequest	true	"View"
+// @Produce json
+// @Success	200 {object}	web.StandartResponse
+// @Failure 400 {object} handler.ResponseErrors "Bad request"
+// @Failure 401 {object} handler.ResponseErrors "Unauthorized"
+// @Failure 409 {object} handler.ResponseErrors "Conflict"
+// @Router /user/{id}/view	[post]
+func (v *ViewController) CreateView(c *gin.Context) {
+	var request model.ViewRequest
+	ctx := context.Background()
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": err.Error(),
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID)}
+	view, errService := v.Service.CreateView(ctx, request, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly create view", map[string]interface{}{
+		"view": view,
+	}))
+}
+
+// UpdateView godoc
+// @Summary Update View
+// @Description Change a view, only its owner can
+// @Tags View
+// @Param id	path	string	true	"Must Be UUID Format"
+// @Param view_id	query	int	true	"ID view"
+// @Param request	body	model.ViewRequest	true	"View"
+// @Produce json
+// @Success	200 {object}	web.StandartResponse
+// @Failure 400 {object} handler.ResponseErrors "Bad request"
+// @Failure 401 {object} handler.ResponseErrors "Unauthorized"
+// @Failure 403 {object} handler.ResponseErrors "Forbidden"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Router /user/{id}/view	[put]
+func (v *ViewController) UpdateView(c *gin.Context) {
+	var request model.ViewRequest
+	var query web.ViewByIDQuery
+	errQuery := c.ShouldBindQuery(&query)
+	ctx := context.Background()
+	if errQuery != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": err.Error(),
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	errService := v.Service.UpdateView(ctx, request, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly update view", nil))
+}
+
+// DeleteView godoc
+// @Summary Delete View
+// @Description Delete a view and its shares, only its owner can
+// @Tags View
+// @Param id	path	string	true	"Must Be UUID Format"
+// @Param view_id	query	int	true	"ID view"
+// @Produce json
+// @Success	200 {object}	web.StandartResponse
+// @Failure 400 {object} handler.ResponseErrors "Bad request"
+// @Failure 401 {object} handler.ResponseErrors "Unauthorized"
+// @Failure 403 {object} handler.ResponseErrors "Forbidden"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Router /user/{id}/view	[delete]
+func (v *ViewController) DeleteView(c *gin.Context) {
+	var query web.ViewByIDQuery
+	ctx := context.Background()
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	errService := v.Service.DeleteView(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly delete view", nil))
+}
+
+// GetViewShares godoc
+// @Summary Get View Shares
+// @Description Retrieve the users a view is shared with, only its owner can
+// @Tags View
+// @Param id	path	string	true	"Must Be UUID Format"
+// @Param view_id	query	int	true	"ID view"
+// @Produce json
+// @Success	200 {object}	web.StandartResponse
+// @Failure 400 {object} handler.ResponseErrors "Bad request"
+// @Failure 401 {object} handler.ResponseErrors "Unauthorized"
+// @Failure 403 {object} handler.ResponseErrors "Forbidden"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Router /user/{id}/view/shares	[get]
+func (v *ViewController) GetViewShares(c *gin.Context) {
+	var query web.ViewByIDQuery
+	ctx := context.Background()
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	shares, errService := v.Service.FindViewShares(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly get view shares", map[string]interface{}{
+		"shares": shares,
+	}))
+}
+
+// ShareView godoc
+// @Summary Share View
+// @Description Share the definition of a view with another user, who runs it against their own todolists
+// @Tags View
+// @Param id	path	string	true	"Must Be UUID Format"
+// @Param view_id	query	int	true	"ID view"
+// @Param request	body	model.ViewShareRequest	true	"View Share"
+// @Produce json
+// @Success	200 {object}	web.StandartResponse
+// @Failure 400 {object} handler.ResponseErrors "Bad request"
+// @Failure 401 {object} handler.ResponseErrors "Unauthorized"
+// @Failure 403 {object} handler.ResponseErrors "Forbidden"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Failure 409 {object} handler.ResponseErrors "Conflict"
+// @Router /user/{id}/view/shares	[post]
+func (v *ViewController) ShareView(c *gin.Context) {
+	var request model.ViewShareRequest
+	var query web.ViewByIDQuery
+	errQuery := c.ShouldBindQuery(&query)
+	ctx := context.Background()
+	if errQuery != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": err.Error(),
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	errService := v.Service.ShareView(ctx, request, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly share view", nil))
+}
+
+// UnshareView godoc
+// @Summary Unshare View
+// @Description Revoke the share of a view, the owner can revoke any share and a user can leave a view shared with them
+// @Tags View
+// @Param id	path	string	true	"Must Be UUID Format"
+// @Param view_id	query	int	true	"ID view"
+// @Param user_id	query	string	true	"ID user the view is shared with"
+// @Produce json
+// @Success	200 {object}	web.StandartResponse
+// @Failure 400 {object} handler.ResponseErrors "Bad request"
+// @Failure 401 {object} handler.ResponseErrors "Unauthorized"
+// @Failure 403 {object} handler.ResponseErrors "Forbidden"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Router /user/{id}/view/share	[delete]
+func (v *ViewController) UnshareView(c *gin.Context) {
+	var query web.ViewShareQuery
+	ctx := context.Background()
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	errService := v.Service.UnshareView(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly unshare view", nil))
+}
+
+// GetViewTodoLists godoc
+// @Summary Run View
+// @Description Retrieve the todolists matching a view, with the count of every group when the view is grouped
+// @Tags View
+// @Param id	path	string	true	"Must Be UUID Format"
+// @Param view_id	query	int	true	"ID view"
+// @Param page	query	int	false	"Page"
+// @Param limit	query	int	false	"Page size"
+// @Param paginate	query	string	false	"offset or cursor"
+// @Param cursor	query	string	false	"Cursor"
+// @Param fields	query	string	false	"Fields"
+// @Produce json
+// @Success	200 {object}	web.StandartResponse
+// @Failure 400 {object} handler.ResponseErrors "Bad request"
+// @Failure 401 {object} handler.ResponseErrors "Unauthorized"
+// @Failed	404	{object} 	handler.ResponseErrors "Not Found"
+// @Router /user/{id}/view/todolists	[get]
+func (v *ViewController) GetViewTodoLists(c *gin.Context) {
+	var query web.ViewTodoListsQuery
+	ctx := context.Background()
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  http.StatusBadRequest,
+			"message": "query params invalid",
+		})
+		return
+	}
+	userID := c.Param("id")
+	params := web.Params{UserID: web.UserID(userID), Query: query}
+	responses, groups, pagination, errService := v.Service.FindViewTodoLists(ctx, params)
+	if errService != nil {
+		responseErrors := handler.NewResponseErrors(errService)
+		c.JSON(responseErrors.Status, responseErrors)
+		return
+	}
+	var todolists interface{} = responses
+	if fields, _ := web.ParseFields(query.Fields, web.TodoListFields); len(fields) > 0 {
+		todolists, err = responses.SelectFields(fields)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"status":  http.StatusInternalServerError,
+				"message": err.Error(),
+			})
+			return
+		}
+	}
+	pagination.SetLinks(c.Request.URL)
+	if link := pagination.LinkHeader(); link != "" {
+		c.Header("Link", link)
+	}
+	data := map[string]interface{}{
+		"todolist":   todolists,
+		"pagination": pagination,
+	}
+	if groups != nil {
+		data["groups"] = groups
+	}
+	c.JSON(http.StatusOK, web.NewStandartResponse(http.StatusOK, "successfuly get view todolists", data))
+}
@@ -19,7 +19,7 @@ func NewPGStore(database *config.DB) *PGStore {
 }
 
 func (s *PGStore) Connect() (*gorm.DB, error) {
-	dsn := fmt.Sprintf("host=%v user=%v password=%v dbname=%v port=%v sslmode=disable TimeZone=America/New_York", s.Database.Host, s.Database.Username, s.Database.Password, s.Database.DatabaseName, s.Database.Port)
+	dsn := fmt.Sprintf("host=%v user=%v password=%v dbname=%v port=%v sslmode=disable TimeZone=UTC", s.Database.Host, s.Database.Username, s.Database.Password, s.Database.DatabaseName, s.Database.Port)
 	//Best Perfomance Config GORM
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
 		Logger:                 logger.Default.LogMode(logger.Info),
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE todolist
+    ADD COLUMN status VARCHAR(32) NOT NULL DEFAULT 'todo',
+    ADD COLUMN started_at TIMESTAMP NULL,
+    ADD COLUMN completed_at TIMESTAMP NULL;
+
+UPDATE todolist SET status = 'done', completed_at = updated_at WHERE completed = TRUE;
+
+CREATE INDEX IF NOT EXISTS todolist_user_id_status_idx ON todolist (user_id, status);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS todolist_user_id_status_idx;
+ALTER TABLE todolist
+    DROP COLUMN IF EXISTS status,
+    DROP COLUMN IF EXISTS started_at,
+    DROP COLUMN IF EXISTS completed_at;
+-- +goose StatementEnd
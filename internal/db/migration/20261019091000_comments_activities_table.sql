@@ -0,0 +1,58 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS comments (
+    comment_id SERIAL PRIMARY KEY,
+    task_id INT NOT NULL REFERENCES todolist(task_id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id),
+    body TEXT NOT NULL,
+    edited BOOLEAN DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL
+);
+CREATE INDEX IF NOT EXISTS comments_task_id_idx ON comments (task_id);
+
+CREATE TRIGGER update_at_trigger
+    BEFORE UPDATE ON comments
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at();
+
+CREATE TABLE IF NOT EXISTS comment_revisions (
+    revision_id SERIAL PRIMARY KEY,
+    comment_id INT NOT NULL REFERENCES comments(comment_id) ON DELETE CASCADE,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS comment_revisions_comment_id_idx ON comment_revisions (comment_id);
+
+CREATE TABLE IF NOT EXISTS task_activities (
+    activity_id SERIAL PRIMARY KEY,
+    task_id INT NOT NULL REFERENCES todolist(task_id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id),
+    field VARCHAR(64) NOT NULL,
+    old_value TEXT,
+    new_value TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS task_activities_task_id_idx ON task_activities (task_id);
+
+CREATE TABLE IF NOT EXISTS notifications (
+    notification_id SERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id),
+    actor_id UUID NOT NULL REFERENCES users(id),
+    task_id INT NULL REFERENCES todolist(task_id) ON DELETE CASCADE,
+    type VARCHAR(32) NOT NULL,
+    message TEXT,
+    read_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS notifications_user_id_idx ON notifications (user_id, read_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS notifications;
+DROP TABLE IF EXISTS task_activities;
+DROP TABLE IF EXISTS comment_revisions;
+DROP TABLE IF EXISTS comments;
+-- +goose StatementEnd
@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS projects (
+    project_id SERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id),
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS projects_user_id_idx ON projects (user_id);
+
+CREATE TRIGGER update_at_trigger
+    BEFORE UPDATE ON projects
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at();
+
+ALTER TABLE todolist ADD COLUMN project_id INT NULL REFERENCES projects(project_id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS todolist_project_id_idx ON todolist (project_id);
+
+CREATE TABLE IF NOT EXISTS shares (
+    share_id SERIAL PRIMARY KEY,
+    owner_id UUID NOT NULL REFERENCES users(id),
+    user_id UUID NOT NULL REFERENCES users(id),
+    task_id INT NULL REFERENCES todolist(task_id) ON DELETE CASCADE,
+    project_id INT NULL REFERENCES projects(project_id) ON DELETE CASCADE,
+    permission VARCHAR(16) NOT NULL CHECK (permission IN ('viewer', 'editor')),
+    status VARCHAR(16) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'declined')),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    responded_at TIMESTAMP NULL,
+    CHECK ((task_id IS NULL) <> (project_id IS NULL))
+);
+CREATE UNIQUE INDEX IF NOT EXISTS shares_task_user_idx ON shares (task_id, user_id) WHERE task_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS shares_project_user_idx ON shares (project_id, user_id) WHERE project_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS shares_user_id_idx ON shares (user_id, status);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS shares;
+DROP INDEX IF EXISTS todolist_project_id_idx;
+ALTER TABLE todolist DROP COLUMN IF EXISTS project_id;
+DROP TABLE IF EXISTS projects;
+-- +goose StatementEnd
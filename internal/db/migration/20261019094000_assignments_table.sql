@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE todolist ADD COLUMN assignee_id UUID NULL REFERENCES users(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS todolist_assignee_id_idx ON todolist (assignee_id);
+
+CREATE TABLE IF NOT EXISTS assignments (
+    assignment_id SERIAL PRIMARY KEY,
+    task_id INT NOT NULL REFERENCES todolist(task_id) ON DELETE CASCADE,
+    assigned_by UUID NOT NULL REFERENCES users(id),
+    old_assignee_id UUID NULL REFERENCES users(id) ON DELETE SET NULL,
+    new_assignee_id UUID NULL REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS assignments_task_id_idx ON assignments (task_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS assignments;
+DROP INDEX IF EXISTS todolist_assignee_id_idx;
+ALTER TABLE todolist DROP COLUMN IF EXISTS assignee_id;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS task_dependencies (
+    dependency_id SERIAL PRIMARY KEY,
+    blocker_id INT NOT NULL REFERENCES todolist(task_id) ON DELETE CASCADE,
+    blocked_id INT NOT NULL REFERENCES todolist(task_id) ON DELETE CASCADE,
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (blocker_id <> blocked_id),
+    UNIQUE (blocker_id, blocked_id)
+);
+CREATE INDEX IF NOT EXISTS task_dependencies_blocked_id_idx ON task_dependencies (blocked_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS task_dependencies;
+-- +goose StatementEnd
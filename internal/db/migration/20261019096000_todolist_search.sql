@@ -0,0 +1,54 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN IF NOT EXISTS search_language VARCHAR(63) NOT NULL DEFAULT 'simple';
+ALTER TABLE todolist ADD COLUMN IF NOT EXISTS search_vector TSVECTOR;
+
+CREATE OR REPLACE FUNCTION todolist_search_vector(language REGCONFIG, task_name TEXT, description TEXT) RETURNS TSVECTOR AS $$
+    SELECT setweight(to_tsvector(language, COALESCE(task_name, '')), 'A') ||
+           setweight(to_tsvector(language, COALESCE(description, '')), 'B')
+$$ LANGUAGE SQL IMMUTABLE;
+
+-- the vector of a task is built with the search language of its owner
+CREATE OR REPLACE FUNCTION todolist_search_vector_update() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector := todolist_search_vector(
+        COALESCE((SELECT search_language FROM users WHERE id = NEW.user_id), 'simple')::REGCONFIG,
+        NEW.task_name, NEW.description);
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER todolist_search_vector_trigger
+    BEFORE INSERT OR UPDATE OF task_name, description, user_id ON todolist
+    FOR EACH ROW EXECUTE FUNCTION todolist_search_vector_update();
+
+CREATE OR REPLACE FUNCTION users_search_language_update() RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE todolist SET search_vector = todolist_search_vector(NEW.search_language::REGCONFIG, task_name, description)
+    WHERE user_id = NEW.id;
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER users_search_language_trigger
+    AFTER UPDATE OF search_language ON users
+    FOR EACH ROW WHEN (OLD.search_language IS DISTINCT FROM NEW.search_language)
+    EXECUTE FUNCTION users_search_language_update();
+
+UPDATE todolist t SET search_vector = todolist_search_vector(u.search_language::REGCONFIG, t.task_name, t.description)
+FROM users u WHERE u.id = t.user_id;
+
+CREATE INDEX IF NOT EXISTS todolist_search_vector_idx ON todolist USING GIN (search_vector);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS users_search_language_trigger ON users;
+DROP TRIGGER IF EXISTS todolist_search_vector_trigger ON todolist;
+DROP FUNCTION IF EXISTS users_search_language_update();
+DROP FUNCTION IF EXISTS todolist_search_vector_update();
+DROP FUNCTION IF EXISTS todolist_search_vector(REGCONFIG, TEXT, TEXT);
+DROP INDEX IF EXISTS todolist_search_vector_idx;
+ALTER TABLE todolist DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE users DROP COLUMN IF EXISTS search_language;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE todolist ADD COLUMN IF NOT EXISTS position VARCHAR(255) COLLATE "C";
+
+-- existing todolists keep the order they were created in, with fixed width keys of fractional.Digits
+UPDATE todolist SET position = ranked.position
+FROM (
+    SELECT task_id, LPAD(ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY task_id)::TEXT, 10, '0') || 'V' AS position
+    FROM todolist
+) AS ranked
+WHERE todolist.task_id = ranked.task_id;
+
+ALTER TABLE todolist ALTER COLUMN position SET NOT NULL;
+CREATE INDEX IF NOT EXISTS todolist_user_id_position_idx ON todolist (user_id, position, task_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS todolist_user_id_position_idx;
+ALTER TABLE todolist DROP COLUMN IF EXISTS position;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS board_columns (
+    column_id SERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    status VARCHAR(32),
+    wip_limit INT CHECK (wip_limit > 0),
+    position VARCHAR(255) COLLATE "C" NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, name)
+);
+CREATE INDEX IF NOT EXISTS board_columns_user_id_position_idx ON board_columns (user_id, position);
+
+ALTER TABLE todolist ADD COLUMN IF NOT EXISTS column_id INT REFERENCES board_columns(column_id) ON DELETE SET NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE todolist DROP COLUMN IF EXISTS column_id;
+DROP TABLE IF EXISTS board_columns;
+-- +goose StatementEnd
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS templates (
+    template_id SERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS template_items (
+    item_id SERIAL PRIMARY KEY,
+    template_id INT NOT NULL REFERENCES templates(template_id) ON DELETE CASCADE,
+    sort_order INT NOT NULL,
+    task_name VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL,
+    due_offset VARCHAR(16) NOT NULL,
+    priority INT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS template_items_template_id_idx ON template_items (template_id, sort_order);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS template_items;
+DROP TABLE IF EXISTS templates;
+-- +goose StatementEnd
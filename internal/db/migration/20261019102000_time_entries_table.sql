@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE todolist ADD COLUMN IF NOT EXISTS estimate_minutes INT CHECK (estimate_minutes >= 0);
+
+CREATE TABLE IF NOT EXISTS time_entries (
+    entry_id SERIAL PRIMARY KEY,
+    task_id INT NOT NULL REFERENCES todolist(task_id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    started_at TIMESTAMP NOT NULL,
+    ended_at TIMESTAMP,
+    note VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (ended_at IS NULL OR ended_at > started_at)
+);
+CREATE INDEX IF NOT EXISTS time_entries_task_id_idx ON time_entries (task_id);
+CREATE INDEX IF NOT EXISTS time_entries_user_id_started_at_idx ON time_entries (user_id, started_at);
+-- a user has at most one running timer
+CREATE UNIQUE INDEX IF NOT EXISTS time_entries_running_idx ON time_entries (user_id) WHERE ended_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS time_entries;
+ALTER TABLE todolist DROP COLUMN IF EXISTS estimate_minutes;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE todolist ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP NULL;
+-- the trash of a user and the purge of expired todolists
+CREATE INDEX IF NOT EXISTS todolist_deleted_at_idx ON todolist (deleted_at, user_id) WHERE deleted_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM todolist WHERE deleted_at IS NOT NULL;
+ALTER TABLE todolist DROP COLUMN IF EXISTS deleted_at;
+-- +goose StatementEnd
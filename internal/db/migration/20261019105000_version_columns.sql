@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE todolist ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1;
+
+-- the version moves on whenever a column other than those named in the trigger arguments changes, so it
+-- backs the ETag of the row whichever query wrote it
+CREATE OR REPLACE FUNCTION bump_version()
+RETURNS TRIGGER AS $$
+DECLARE
+    ignored TEXT[] := COALESCE(TG_ARGV, '{}') || ARRAY['version', 'updated_at'];
+BEGIN
+    IF (to_jsonb(NEW) - ignored) IS DISTINCT FROM (to_jsonb(OLD) - ignored) THEN
+        NEW.version = OLD.version + 1;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER todolist_version_trigger
+    BEFORE UPDATE ON todolist
+    FOR EACH ROW
+    EXECUTE FUNCTION bump_version('search_vector');
+
+CREATE TRIGGER users_version_trigger
+    BEFORE UPDATE ON users
+    FOR EACH ROW
+    EXECUTE FUNCTION bump_version('refresh_token');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS users_version_trigger ON users;
+DROP TRIGGER IF EXISTS todolist_version_trigger ON todolist;
+DROP FUNCTION IF EXISTS bump_version();
+ALTER TABLE users DROP COLUMN IF EXISTS version;
+ALTER TABLE todolist DROP COLUMN IF EXISTS version;
+-- +goose StatementEnd
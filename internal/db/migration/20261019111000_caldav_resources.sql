@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+-- a todolist created by a CalDAV client keeps the name and UID the client gave it, the others are served as
+-- todolist-<task_id>.ics
+CREATE TABLE IF NOT EXISTS caldav_resources (
+    task_id INT PRIMARY KEY REFERENCES todolist(task_id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    uid VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, name)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS caldav_resources;
+-- +goose StatementEnd
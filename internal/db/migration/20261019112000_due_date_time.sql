@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+-- a due date is a whole day kept as its midnight in UTC, or a moment when due_timed is set. due_timezone is the IANA
+-- zone a timed due date was given in and is shown in
+ALTER TABLE todolist
+    ALTER COLUMN due_date TYPE TIMESTAMPTZ USING due_date::timestamp AT TIME ZONE 'UTC',
+    ADD COLUMN due_timed BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN due_timezone VARCHAR(64) NOT NULL DEFAULT '';
+
+ALTER TABLE todolist_revisions
+    ALTER COLUMN due_date TYPE TIMESTAMPTZ USING due_date::timestamp AT TIME ZONE 'UTC',
+    ADD COLUMN due_timed BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN due_timezone VARCHAR(64) NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE todolist_revisions
+    DROP COLUMN IF EXISTS due_timezone,
+    DROP COLUMN IF EXISTS due_timed,
+    ALTER COLUMN due_date TYPE DATE USING (due_date AT TIME ZONE 'UTC')::date;
+
+ALTER TABLE todolist
+    DROP COLUMN IF EXISTS due_timezone,
+    DROP COLUMN IF EXISTS due_timed,
+    ALTER COLUMN due_date TYPE DATE USING (due_date AT TIME ZONE 'UTC')::date;
+-- +goose StatementEnd
@@ -0,0 +1,163 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the connection used to run in America/New_York, so every TIMESTAMP holds New York wall time. It now runs in UTC,
+-- the columns become TIMESTAMPTZ read from that wall time so that existing rows keep their moment
+ALTER TABLE users
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'America/New_York',
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ USING updated_at AT TIME ZONE 'America/New_York',
+    ALTER COLUMN deleted_at TYPE TIMESTAMPTZ USING deleted_at AT TIME ZONE 'America/New_York';
+
+ALTER TABLE todolist
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'America/New_York',
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ USING updated_at AT TIME ZONE 'America/New_York',
+    ALTER COLUMN started_at TYPE TIMESTAMPTZ USING started_at AT TIME ZONE 'America/New_York',
+    ALTER COLUMN completed_at TYPE TIMESTAMPTZ USING completed_at AT TIME ZONE 'America/New_York',
+    ALTER COLUMN deleted_at TYPE TIMESTAMPTZ USING deleted_at AT TIME ZONE 'America/New_York';
+
+ALTER TABLE comments
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'America/New_York',
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ USING updated_at AT TIME ZONE 'America/New_York',
+    ALTER COLUMN deleted_at TYPE TIMESTAMPTZ USING deleted_at AT TIME ZONE 'America/New_York';
+
+ALTER TABLE comment_revisions
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'America/New_York';
+
+ALTER TABLE task_activities
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'America/New_York';
+
+ALTER TABLE notifications
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'America/New_York',
+    ALTER COLUMN read_at TYPE TIMESTAMPTZ USING read_at AT TIME ZONE 'America/New_York';
+
+ALTER TABLE attachments
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'America/New_York';
+
+ALTER TABLE projects
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'America/New_York',
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ USING updated_at AT TIME ZONE 'America/New_York';
+
+ALTER TABLE shares
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'America/New_York',
+    ALTER COLUMN responded_at TYPE TIMESTAMPTZ USING responded_at AT TIME ZONE 'America/New_York';
+
+ALTER TABLE assignments
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'America/New_York';
+
+ALTER TABLE task_dependencies
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'America/New_York';
+
+ALTER TABLE saved_searches
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'America/New_York',
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ USING updated_at AT TIME ZONE 'America/New_York';
+
+ALTER TABLE views
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'America/New_York',
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ USING updated_at AT TIME ZONE 'America/New_York';
+
+ALTER TABLE view_shares
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'America/New_York';
+
+ALTER TABLE board_columns
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'America/New_York',
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ USING updated_at AT TIME ZONE 'America/New_York';
+
+ALTER TABLE templates
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'America/New_York',
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ USING updated_at AT TIME ZONE 'America/New_York';
+
+ALTER TABLE time_entries
+    ALTER COLUMN started_at TYPE TIMESTAMPTZ USING started_at AT TIME ZONE 'America/New_York',
+    ALTER COLUMN ended_at TYPE TIMESTAMPTZ USING ended_at AT TIME ZONE 'America/New_York',
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'America/New_York',
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ USING updated_at AT TIME ZONE 'America/New_York';
+
+ALTER TABLE todolist_revisions
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'America/New_York';
+
+ALTER TABLE calendar_tokens
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'America/New_York';
+
+ALTER TABLE caldav_resources
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'America/New_York';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'America/New_York',
+    ALTER COLUMN updated_at TYPE TIMESTAMP USING updated_at AT TIME ZONE 'America/New_York',
+    ALTER COLUMN deleted_at TYPE TIMESTAMP USING deleted_at AT TIME ZONE 'America/New_York';
+
+ALTER TABLE todolist
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'America/New_York',
+    ALTER COLUMN updated_at TYPE TIMESTAMP USING updated_at AT TIME ZONE 'America/New_York',
+    ALTER COLUMN started_at TYPE TIMESTAMP USING started_at AT TIME ZONE 'America/New_York',
+    ALTER COLUMN completed_at TYPE TIMESTAMP USING completed_at AT TIME ZONE 'America/New_York',
+    ALTER COLUMN deleted_at TYPE TIMESTAMP USING deleted_at AT TIME ZONE 'America/New_York';
+
+ALTER TABLE comments
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'America/New_York',
+    ALTER COLUMN updated_at TYPE TIMESTAMP USING updated_at AT TIME ZONE 'America/New_York',
+    ALTER COLUMN deleted_at TYPE TIMESTAMP USING deleted_at AT TIME ZONE 'America/New_York';
+
+ALTER TABLE comment_revisions
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'America/New_York';
+
+ALTER TABLE task_activities
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'America/New_York';
+
+ALTER TABLE notifications
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'America/New_York',
+    ALTER COLUMN read_at TYPE TIMESTAMP USING read_at AT TIME ZONE 'America/New_York';
+
+ALTER TABLE attachments
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'America/New_York';
+
+ALTER TABLE projects
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'America/New_York',
+    ALTER COLUMN updated_at TYPE TIMESTAMP USING updated_at AT TIME ZONE 'America/New_York';
+
+ALTER TABLE shares
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'America/New_York',
+    ALTER COLUMN responded_at TYPE TIMESTAMP USING responded_at AT TIME ZONE 'America/New_York';
+
+ALTER TABLE assignments
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'America/New_York';
+
+ALTER TABLE task_dependencies
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'America/New_York';
+
+ALTER TABLE saved_searches
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'America/New_York',
+    ALTER COLUMN updated_at TYPE TIMESTAMP USING updated_at AT TIME ZONE 'America/New_York';
+
+ALTER TABLE views
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'America/New_York',
+    ALTER COLUMN updated_at TYPE TIMESTAMP USING updated_at AT TIME ZONE 'America/New_York';
+
+ALTER TABLE view_shares
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'America/New_York';
+
+ALTER TABLE board_columns
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'America/New_York',
+    ALTER COLUMN updated_at TYPE TIMESTAMP USING updated_at AT TIME ZONE 'America/New_York';
+
+ALTER TABLE templates
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'America/New_York',
+    ALTER COLUMN updated_at TYPE TIMESTAMP USING updated_at AT TIME ZONE 'America/New_York';
+
+ALTER TABLE time_entries
+    ALTER COLUMN started_at TYPE TIMESTAMP USING started_at AT TIME ZONE 'America/New_York',
+    ALTER COLUMN ended_at TYPE TIMESTAMP USING ended_at AT TIME ZONE 'America/New_York',
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'America/New_York',
+    ALTER COLUMN updated_at TYPE TIMESTAMP USING updated_at AT TIME ZONE 'America/New_York';
+
+ALTER TABLE todolist_revisions
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'America/New_York';
+
+ALTER TABLE calendar_tokens
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'America/New_York';
+
+ALTER TABLE caldav_resources
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'America/New_York';
+-- +goose StatementEnd
@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+-- change_xid is the transaction that last wrote the todolist. A CalDAV sync token holds a snapshot and the todolists
+-- changed since are those whose transaction the snapshot does not see, which holds however long a transaction takes
+-- to commit and whatever the clocks of the app instances say
+ALTER TABLE todolist ADD COLUMN IF NOT EXISTS change_xid XID8 NOT NULL DEFAULT pg_current_xact_id();
+
+CREATE OR REPLACE FUNCTION set_change_xid()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.change_xid = pg_current_xact_id();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER todolist_change_xid_trigger
+    BEFORE UPDATE ON todolist
+    FOR EACH ROW
+    EXECUTE FUNCTION set_change_xid();
+
+DROP TRIGGER IF EXISTS todolist_version_trigger ON todolist;
+CREATE TRIGGER todolist_version_trigger
+    BEFORE UPDATE ON todolist
+    FOR EACH ROW
+    EXECUTE FUNCTION bump_version('search_vector', 'change_xid');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS todolist_version_trigger ON todolist;
+CREATE TRIGGER todolist_version_trigger
+    BEFORE UPDATE ON todolist
+    FOR EACH ROW
+    EXECUTE FUNCTION bump_version('search_vector');
+
+DROP TRIGGER IF EXISTS todolist_change_xid_trigger ON todolist;
+DROP FUNCTION IF EXISTS set_change_xid();
+ALTER TABLE todolist DROP COLUMN IF EXISTS change_xid;
+-- +goose StatementEnd
@@ -7,6 +7,43 @@ import (
 	"go_gin/internal/domain/model"
 )
 
+// versioning is what the goose migrations add to the tables for the ETags and the CalDAV sync tokens, which
+// AutoMigrate knows nothing of: versions start at 1 and move on with every change, change_xid follows the transaction
+// that last wrote a todolist.
+var versioning = []string{
+	`ALTER TABLE todolist ALTER COLUMN version SET DEFAULT 1`,
+	`UPDATE todolist SET version = 1 WHERE version IS NULL`,
+	`ALTER TABLE todolist ALTER COLUMN version SET NOT NULL`,
+	`ALTER TABLE users ALTER COLUMN version SET DEFAULT 1`,
+	`UPDATE users SET version = 1 WHERE version IS NULL`,
+	`ALTER TABLE users ALTER COLUMN version SET NOT NULL`,
+	`ALTER TABLE todolist ADD COLUMN IF NOT EXISTS change_xid XID8 NOT NULL DEFAULT pg_current_xact_id()`,
+	`CREATE OR REPLACE FUNCTION bump_version()
+RETURNS TRIGGER AS $$
+DECLARE
+    ignored TEXT[] := COALESCE(TG_ARGV, '{}') || ARRAY['version', 'updated_at'];
+BEGIN
+    IF (to_jsonb(NEW) - ignored) IS DISTINCT FROM (to_jsonb(OLD) - ignored) THEN
+        NEW.version = OLD.version + 1;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql`,
+	`CREATE OR REPLACE FUNCTION set_change_xid()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.change_xid = pg_current_xact_id();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql`,
+	`DROP TRIGGER IF EXISTS todolist_version_trigger ON todolist`,
+	`CREATE TRIGGER todolist_version_trigger BEFORE UPDATE ON todolist FOR EACH ROW EXECUTE FUNCTION bump_version('search_vector', 'change_xid')`,
+	`DROP TRIGGER IF EXISTS todolist_change_xid_trigger ON todolist`,
+	`CREATE TRIGGER todolist_change_xid_trigger BEFORE UPDATE ON todolist FOR EACH ROW EXECUTE FUNCTION set_change_xid()`,
+	`DROP TRIGGER IF EXISTS users_version_trigger ON users`,
+	`CREATE TRIGGER users_version_trigger BEFORE UPDATE ON users FOR EACH ROW EXECUTE FUNCTION bump_version('refresh_token')`,
+}
+
 // just for emergency
 func migration() {
 	pgstore := db.NewPGStore(config.Database)
@@ -23,6 +60,18 @@ func migration() {
 	if err != nil {
 		panic(fmt.Errorf("error migrating users %s", err.Error()))
 	}
+
+	err = dbs.AutoMigrate(&model.Comment{}, &model.CommentRevision{}, &model.TaskActivity{}, &model.Notification{}, &model.Attachment{}, &model.Project{}, &model.Share{}, &model.Assignment{}, &model.TaskDependency{}, &model.TodoListTag{}, &model.SavedSearch{}, &model.View{}, &model.ViewShare{}, &model.BoardColumn{}, &model.Template{}, &model.TemplateItem{}, &model.TimeEntry{}, &model.TodoListRevision{}, &model.CalendarToken{}, &model.CalDAVResource{})
+	if err != nil {
+		panic(fmt.Errorf("error migrating comments %s", err.Error()))
+	}
+
+	for _, statement := range versioning {
+		err = dbs.Exec(statement).Error
+		if err != nil {
+			panic(fmt.Errorf("error migrating versions %s", err.Error()))
+		}
+	}
 }
 
 func main() {
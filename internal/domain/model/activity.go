@@ -0,0 +1,84 @@
+package model
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	ActivityComment = "comment"
+	ActivityChange  = "change"
+)
+
+type TaskActivity struct {
+	ActivityID int       `json:"activity_id" gorm:"primaryKey;column:activity_id"`
+	TaskID     int       `json:"task_id" gorm:"column:task_id"`
+	UserID     uuid.UUID `json:"user_id" gorm:"column:user_id"`
+	Field      string    `json:"field" gorm:"column:field"`
+	OldValue   string    `json:"old_value" gorm:"column:old_value"`
+	NewValue   string    `json:"new_value" gorm:"column:new_value"`
+	CreatedAt  time.Time `json:"created_at" gorm:"column:created_at"`
+}
+
+func (a *TaskActivity) TableName() string {
+	return "task_activities"
+}
+
+type ActivityResponse struct {
+	Type      string           `json:"type"`
+	UserID    uuid.UUID        `json:"user_id"`
+	CreatedAt time.Time        `json:"created_at"`
+	Comment   *CommentResponse `json:"comment,omitempty"`
+	Field     string           `json:"field,omitempty"`
+	OldValue  string           `json:"old_value,omitempty"`
+	NewValue  string           `json:"new_value,omitempty"`
+}
+
+func (a *TaskActivity) ToActivityResponse() *ActivityResponse {
+	return &ActivityResponse{
+		Type:      ActivityChange,
+		UserID:    a.UserID,
+		CreatedAt: a.CreatedAt,
+		Field:     a.Field,
+		OldValue:  a.OldValue,
+		NewValue:  a.NewValue,
+	}
+}
+
+func (c *Comment) ToActivityResponse() *ActivityResponse {
+	return &ActivityResponse{
+		Type:      ActivityComment,
+		UserID:    c.UserID,
+		CreatedAt: c.CreatedAt,
+		Comment:   c.ToCommentResponse(),
+	}
+}
+
+// formatActivityDue shows a whole day as 2006-01-02 and a timed due date in RFC 3339, in the timezone it was given in.
+func formatActivityDue(todolist TodoList) string {
+	due := todolist.LocalDueDate()
+	if due == nil {
+		return ""
+	}
+	if !todolist.DueTimed {
+		return due.Format(time.DateOnly)
+	}
+	return due.Format(time.RFC3339)
+}
+
+// DiffTodoList lists the user visible fields that differ between before and after as activities made by userID.
+func DiffTodoList(before, after TodoList, userID uuid.UUID) TaskActivities {
+	var activities TaskActivities
+	add := func(field, oldValue, newValue string) {
+		if oldValue != newValue {
+			activities = append(activities, TaskActivity{TaskID: before.TaskID, UserID: userID, Field: field, OldValue: oldValue, NewValue: newValue})
+		}
+	}
+	add("task_name", before.TaskName, after.TaskName)
+	add("description", before.Description, after.Description)
+	add("due_date", formatActivityDue(before), formatActivityDue(after))
+	add("priority", fmt.Sprint(before.Priority), fmt.Sprint(after.Priority))
+	add("status", string(before.Status), string(after.Status))
+	return activities
+}
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	NotificationAssignment = "assignment"
+)
+
+// Assignment records every change of a todolist assignee, OldAssigneeID and NewAssigneeID are nil when unassigned.
+type Assignment struct {
+	AssignmentID  int        `json:"assignment_id" gorm:"primaryKey;column:assignment_id"`
+	TaskID        int        `json:"task_id" gorm:"column:task_id"`
+	AssignedBy    uuid.UUID  `json:"assigned_by" gorm:"column:assigned_by"`
+	OldAssigneeID *uuid.UUID `json:"old_assignee_id" gorm:"column:old_assignee_id"`
+	NewAssigneeID *uuid.UUID `json:"new_assignee_id" gorm:"column:new_assignee_id"`
+	CreatedAt     time.Time  `json:"created_at" gorm:"column:created_at"`
+}
+
+func (a *Assignment) TableName() string {
+	return "assignments"
+}
+
+type TodoListAssignRequest struct {
+	AssigneeID *uuid.UUID `json:"assignee_id"`
+}
+
+// SameAssignee reports whether a and b point to the same user or are both unassigned.
+func SameAssignee(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
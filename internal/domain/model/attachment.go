@@ -0,0 +1,44 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type Attachment struct {
+	AttachmentID int       `json:"attachment_id" gorm:"primaryKey;column:attachment_id"`
+	TaskID       int       `json:"task_id" gorm:"column:task_id"`
+	UserID       uuid.UUID `json:"user_id" gorm:"column:user_id"`
+	FileName     string    `json:"file_name" gorm:"column:file_name"`
+	ContentType  string    `json:"content_type" gorm:"column:content_type"`
+	Size         int64     `json:"size" gorm:"column:size"`
+	Checksum     string    `json:"checksum" gorm:"column:checksum"`
+	StorageKey   string    `json:"-" gorm:"column:storage_key"`
+	CreatedAt    time.Time `json:"created_at" gorm:"column:created_at"`
+}
+
+func (a *Attachment) TableName() string {
+	return "attachments"
+}
+
+type AttachmentResponse struct {
+	AttachmentID int       `json:"attachment_id"`
+	TaskID       int       `json:"task_id"`
+	FileName     string    `json:"file_name"`
+	ContentType  string    `json:"content_type"`
+	Size         int64     `json:"size"`
+	Checksum     string    `json:"checksum"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func (a *Attachment) ToAttachmentResponse() *AttachmentResponse {
+	return &AttachmentResponse{
+		AttachmentID: a.AttachmentID,
+		TaskID:       a.TaskID,
+		FileName:     a.FileName,
+		ContentType:  a.ContentType,
+		Size:         a.Size,
+		Checksum:     a.Checksum,
+		CreatedAt:    a.CreatedAt,
+	}
+}
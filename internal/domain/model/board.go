@@ -0,0 +1,112 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// BoardColumn is a column of the kanban board of a user. A column backed by a status holds the todolists in that
+// status, a custom lane (no status) holds the todolists moved into it whatever their status.
+type BoardColumn struct {
+	ColumnID  int         `json:"column_id" gorm:"primaryKey;column:column_id"`
+	UserID    uuid.UUID   `json:"user_id" gorm:"column:user_id"`
+	Name      string      `json:"name" gorm:"column:name"`
+	Status    *TaskStatus `json:"status" gorm:"column:status"`
+	WipLimit  *int        `json:"wip_limit" gorm:"column:wip_limit"`
+	Position  string      `json:"position" gorm:"column:position"`
+	CreatedAt time.Time   `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt time.Time   `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (b *BoardColumn) TableName() string {
+	return "board_columns"
+}
+
+type BoardColumnRequest struct {
+	Name     string      `json:"name" validate:"required,max=100"`
+	Status   *TaskStatus `json:"status"`
+	WipLimit *int        `json:"wip_limit" validate:"omitempty,min=1"`
+}
+
+// BoardCardMoveRequest moves a card into ColumnID so it ends up at Index among the cards of the column.
+type BoardCardMoveRequest struct {
+	ColumnID int `json:"column_id" validate:"required"`
+	Index    int `json:"index" validate:"min=0"`
+}
+
+type BoardColumnResponse struct {
+	ColumnID  int               `json:"column_id"`
+	Name      string            `json:"name"`
+	Status    *TaskStatus       `json:"status"`
+	WipLimit  *int              `json:"wip_limit"`
+	Count     int               `json:"count"`
+	OverLimit bool              `json:"over_limit"`
+	Cards     TodoListResponses `json:"cards"`
+}
+
+// BoardResponse is the whole board, Unplaced holds the todolists whose status has no column.
+type BoardResponse struct {
+	Columns  []BoardColumnResponse `json:"columns"`
+	Unplaced TodoListResponses     `json:"unplaced"`
+}
+
+func (b *BoardColumnRequest) ToBoardColumn(userID uuid.UUID) *BoardColumn {
+	return &BoardColumn{
+		UserID:   userID,
+		Name:     b.Name,
+		Status:   b.Status,
+		WipLimit: b.WipLimit,
+	}
+}
+
+// Holds reports whether todolist belongs in the column, following PlaceCards.
+func (b *BoardColumn) Holds(todolist TodoList) bool {
+	if todolist.ColumnID != nil && *todolist.ColumnID == b.ColumnID {
+		return b.Status == nil || *b.Status == todolist.Status
+	}
+	return b.Status != nil && *b.Status == todolist.Status
+}
+
+// PlaceCards spreads todolists, already in board order, over columns. A todolist stays in the column it was moved
+// to unless that column is backed by another status than its own, then it goes to the first column of its status.
+func PlaceCards(columns BoardColumns, todolists TodoLists) (cards map[int]TodoLists, unplaced TodoLists) {
+	byID := make(map[int]BoardColumn, len(columns))
+	byStatus := make(map[TaskStatus]int, len(columns))
+	for i := len(columns) - 1; i >= 0; i-- {
+		byID[columns[i].ColumnID] = columns[i]
+		if columns[i].Status != nil {
+			byStatus[*columns[i].Status] = columns[i].ColumnID
+		}
+	}
+	cards = make(map[int]TodoLists, len(columns))
+	for _, todolist := range todolists {
+		if todolist.ColumnID != nil {
+			if column, ok := byID[*todolist.ColumnID]; ok && column.Holds(todolist) {
+				cards[column.ColumnID] = append(cards[column.ColumnID], todolist)
+				continue
+			}
+		}
+		if columnID, ok := byStatus[todolist.Status]; ok {
+			cards[columnID] = append(cards[columnID], todolist)
+			continue
+		}
+		unplaced = append(unplaced, todolist)
+	}
+	return cards, unplaced
+}
+
+func (b *BoardColumn) ToBoardColumnResponse(cards TodoLists) *BoardColumnResponse {
+	responses := cards.ToTodoListResponses()
+	if responses == nil {
+		responses = TodoListResponses{}
+	}
+	return &BoardColumnResponse{
+		ColumnID:  b.ColumnID,
+		Name:      b.Name,
+		Status:    b.Status,
+		WipLimit:  b.WipLimit,
+		Count:     len(cards),
+		OverLimit: b.WipLimit != nil && len(cards) > *b.WipLimit,
+		Cards:     responses,
+	}
+}
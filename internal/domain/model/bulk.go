@@ -0,0 +1,91 @@
+package model
+
+// TodoListPatch holds the fields a bulk update sets, the ones left out keep their value. PriorityBy moves the
+// priority relative to its current value, never below 1. DueTimezone reads a timed DueDate, the todolist keeps its
+// own timezone when it is empty.
+type TodoListPatch struct {
+	Priority    *int        `json:"priority" validate:"omitempty,min=1,excluded_with=PriorityBy"`
+	PriorityBy  *int        `json:"priority_by" validate:"omitempty,ne=0"`
+	DueDate     *Date       `json:"due_date"`
+	DueTimezone string      `json:"due_timezone" validate:"omitempty,max=64,timezone"`
+	ProjectID   *int        `json:"project_id" validate:"omitempty,min=1"`
+	Status      *TaskStatus `json:"status" validate:"omitempty,min=1,max=32"`
+	Completed   *bool       `json:"completed" validate:"excluded_with=Status"`
+}
+
+// TodoListBulkUpdateRequest applies Patch to the todolists in IDs, or to the todolists of the user matching Filter
+// written in the search query language. DryRun reports what would change without writing anything.
+type TodoListBulkUpdateRequest struct {
+	IDs    []int         `json:"ids" validate:"required_without=Filter,excluded_with=Filter,dive,min=1"`
+	Filter string        `json:"filter" validate:"omitempty,max=512"`
+	Patch  TodoListPatch `json:"patch"`
+	DryRun bool          `json:"dry_run"`
+}
+
+type BulkResult string
+
+const (
+	BulkUpdated   BulkResult = "updated"
+	BulkUnchanged BulkResult = "unchanged"
+	BulkFailed    BulkResult = "failed"
+)
+
+// TodoListBulkResult is the outcome of a bulk update for one todolist.
+type TodoListBulkResult struct {
+	TaskID  int              `json:"task_id"`
+	Result  BulkResult       `json:"result"`
+	Changes []RevisionChange `json:"changes,omitempty"`
+	Error   string           `json:"error,omitempty"`
+}
+
+// TodoListBulkUpdateResponse reports a bulk update item by item. Applied is false after a dry run or when an item
+// failed, nothing was written then.
+type TodoListBulkUpdateResponse struct {
+	DryRun    bool                 `json:"dry_run"`
+	Applied   bool                 `json:"applied"`
+	Matched   int                  `json:"matched"`
+	Updated   int                  `json:"updated"`
+	Unchanged int                  `json:"unchanged"`
+	Failed    int                  `json:"failed"`
+	Results   []TodoListBulkResult `json:"results"`
+}
+
+// IsEmpty tells whether the patch sets no field at all.
+func (p TodoListPatch) IsEmpty() bool {
+	return p.Priority == nil && p.PriorityBy == nil && p.DueDate == nil && p.ProjectID == nil && p.Status == nil && p.Completed == nil
+}
+
+// Apply returns todolist with the fields of the patch set, along with the status and completed flag to resolve
+// through the workflow.
+func (p TodoListPatch) Apply(todolist TodoList) (patched TodoList, status TaskStatus, completed bool) {
+	patched = todolist
+	if p.Priority != nil {
+		patched.Priority = *p.Priority
+	}
+	if p.PriorityBy != nil {
+		patched.Priority = max(1, patched.Priority+*p.PriorityBy)
+	}
+	if p.DueDate != nil {
+		timezone := p.DueTimezone
+		if timezone == "" {
+			timezone = todolist.DueTimezone
+		}
+		dueDate, timed := p.DueDate.Due(timezone)
+		patched.DueDate, patched.DueTimed, patched.DueTimezone = &dueDate, timed, ""
+		if timed {
+			patched.DueTimezone = timezone
+		}
+	}
+	if p.ProjectID != nil {
+		patched.ProjectID = p.ProjectID
+	}
+	status, completed = todolist.Status, todolist.Completed
+	if p.Completed != nil {
+		// an empty status lets the workflow derive it from the flag
+		status, completed = "", *p.Completed
+	}
+	if p.Status != nil {
+		status = *p.Status
+	}
+	return
+}
@@ -0,0 +1,79 @@
+package model
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CalDAVResource is the name and UID a CalDAV client gave to a todolist it created.
+type CalDAVResource struct {
+	TaskID    int       `json:"task_id" gorm:"primaryKey;column:task_id"`
+	UserID    uuid.UUID `json:"user_id" gorm:"column:user_id"`
+	Name      string    `json:"name" gorm:"column:name"`
+	UID       string    `json:"uid" gorm:"column:uid"`
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at"`
+}
+
+func (c *CalDAVResource) TableName() string {
+	return "caldav_resources"
+}
+
+// CalDAVObject is a todolist as a calendar object resource, Data is a VCALENDAR holding its VTODO.
+type CalDAVObject struct {
+	Name   string
+	TaskID int
+	ETag   string
+	Data   string
+}
+
+// CalDAVCollection is the VTODO collection of a user. After a sync, Objects are the ones changed since the token and
+// Deleted the names of the ones removed.
+type CalDAVCollection struct {
+	SyncToken string
+	Objects   []CalDAVObject
+	Deleted   []string
+}
+
+// CalDAVName is the name a todolist is served under when no client named it.
+func CalDAVName(taskID int) string {
+	return fmt.Sprintf("todolist-%d.ics", taskID)
+}
+
+// ParseCalDAVName returns the task_id of a name made by CalDAVName.
+func ParseCalDAVName(name string) (int, bool) {
+	digits, ok := strings.CutPrefix(name, "todolist-")
+	if !ok {
+		return 0, false
+	}
+	taskID, err := strconv.Atoi(strings.TrimSuffix(digits, ".ics"))
+	return taskID, err == nil && taskID > 0 && name == CalDAVName(taskID)
+}
+
+const calDAVSyncPrefix = "urn:todolist:sync:"
+
+// calDAVSnapshot is the text of a pg_snapshot, xmin:xmax:xip,...
+var calDAVSnapshot = regexp.MustCompile(`^[0-9]+:[0-9]+:([0-9]+(,[0-9]+)*)?$`)
+
+// CalDAVSyncToken is the sync token of a collection read at takenAt under snapshot.
+func CalDAVSyncToken(takenAt time.Time, snapshot string) string {
+	return calDAVSyncPrefix + strconv.FormatInt(takenAt.UnixMicro(), 10) + ":" + snapshot
+}
+
+// ParseCalDAVSyncToken returns when the token was taken and its snapshot.
+func ParseCalDAVSyncToken(token string) (time.Time, string, error) {
+	invalid := fmt.Errorf("sync token %q is not valid", token)
+	value, ok := strings.CutPrefix(token, calDAVSyncPrefix)
+	if !ok {
+		return time.Time{}, "", invalid
+	}
+	digits, snapshot, _ := strings.Cut(value, ":")
+	micro, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil || !calDAVSnapshot.MatchString(snapshot) {
+		return time.Time{}, "", invalid
+	}
+	return time.UnixMicro(micro), snapshot, nil
+}
@@ -0,0 +1,101 @@
+package model
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"go_gin/pkg/ical"
+	"strconv"
+	"time"
+)
+
+// CalendarToken is the secret of the calendar feed of a user, only its sha-256 is kept.
+type CalendarToken struct {
+	UserID    uuid.UUID `json:"user_id" gorm:"primaryKey;column:user_id"`
+	TokenHash string    `json:"-" gorm:"column:token_hash"`
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at"`
+}
+
+func (c *CalendarToken) TableName() string {
+	return "calendar_tokens"
+}
+
+// CalendarTokenResponse is shown once when a feed token is created, URL is the path calendar apps subscribe to.
+type CalendarTokenResponse struct {
+	Token     string    `json:"token"`
+	URL       string    `json:"url"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ICalPriority turns a priority around into the 1 (highest) to 9 (lowest) scale of iCalendar, the ICS import turns
+// it back for priorities from 1 to 9.
+func ICalPriority(priority int) int {
+	return min(9, max(1, 10-priority))
+}
+
+// ToVTodo is the todolist as a VTODO of the feed identified by host.
+func (t *TodoList) ToVTodo(host string, now time.Time) ical.Component {
+	todo := ical.Component{Name: "VTODO"}
+	todo.Add("UID", fmt.Sprintf("todolist-%d@%s", t.TaskID, host))
+	todo.Add("DTSTAMP", ical.FormatDateTime(now))
+	todo.Add("CREATED", ical.FormatDateTime(t.CreatedAt))
+	todo.Add("LAST-MODIFIED", ical.FormatDateTime(t.UpdatedAt))
+	todo.Add("SEQUENCE", strconv.Itoa(t.Version))
+	todo.AddText("SUMMARY", t.TaskName)
+	if t.Description != "" {
+		todo.AddText("DESCRIPTION", t.Description)
+	}
+	switch {
+	case t.DueDate != nil && t.DueTimed:
+		todo.Add("DUE", ical.FormatDateTime(*t.DueDate))
+	case t.DueDate != nil:
+		todo.Add("DUE", ical.FormatDate(*t.DueDate), "VALUE=DATE")
+	}
+	todo.Add("PRIORITY", strconv.Itoa(ICalPriority(t.Priority)))
+	switch {
+	case t.Completed:
+		todo.Add("STATUS", "COMPLETED")
+		if t.CompletedAt != nil {
+			todo.Add("COMPLETED", ical.FormatDateTime(*t.CompletedAt))
+		}
+		todo.Add("PERCENT-COMPLETE", "100")
+	case t.StartedAt != nil:
+		todo.Add("STATUS", "IN-PROCESS")
+	default:
+		todo.Add("STATUS", "NEEDS-ACTION")
+	}
+	// a rule saved before its values were checked is left out rather than written into the calendar
+	if t.Recurrence != "" && ical.ValidateRecurrence(t.Recurrence) == nil {
+		todo.Add("RRULE", t.Recurrence)
+	}
+	return todo
+}
+
+// ToVEvent is the due date of the todolist as a VEVENT, for calendar apps that do not show VTODO. A whole day is an
+// all-day event, a timed due date an event without duration.
+func (t *TodoList) ToVEvent(host string, now time.Time) ical.Component {
+	event := ical.Component{Name: "VEVENT"}
+	event.Add("UID", fmt.Sprintf("todolist-due-%d@%s", t.TaskID, host))
+	event.Add("DTSTAMP", ical.FormatDateTime(now))
+	event.Add("LAST-MODIFIED", ical.FormatDateTime(t.UpdatedAt))
+	event.Add("SEQUENCE", strconv.Itoa(t.Version))
+	summary := t.TaskName
+	if t.Completed {
+		summary = "✓ " + summary
+	}
+	event.AddText("SUMMARY", summary)
+	if t.Description != "" {
+		event.AddText("DESCRIPTION", t.Description)
+	}
+	if t.DueTimed {
+		event.Add("DTSTART", ical.FormatDateTime(*t.DueDate))
+	} else {
+		event.Add("DTSTART", ical.FormatDate(*t.DueDate), "VALUE=DATE")
+		event.Add("DTEND", ical.FormatDate(t.DueDate.AddDate(0, 0, 1)), "VALUE=DATE")
+	}
+	event.Add("PRIORITY", strconv.Itoa(ICalPriority(t.Priority)))
+	event.Add("TRANSP", "TRANSPARENT")
+	if t.Recurrence != "" && ical.ValidateRecurrence(t.Recurrence) == nil {
+		event.Add("RRULE", t.Recurrence)
+	}
+	return event
+}
@@ -0,0 +1,84 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"regexp"
+	"time"
+)
+
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@])@([\w.\-]+)`)
+
+type Comment struct {
+	CommentID int            `json:"comment_id" gorm:"primaryKey;column:comment_id"`
+	TaskID    int            `json:"task_id" gorm:"column:task_id"`
+	UserID    uuid.UUID      `json:"user_id" gorm:"column:user_id"`
+	Body      string         `json:"body" gorm:"column:body"`
+	Edited    bool           `json:"edited" gorm:"column:edited"`
+	CreatedAt time.Time      `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"column:updated_at"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"column:deleted_at"`
+	User      User           `gorm:"foreignKey:user_id;references:id" json:"user"`
+}
+
+func (c *Comment) TableName() string {
+	return "comments"
+}
+
+// Mentions returns the usernames referenced as @username in the comment body, without duplicates.
+func (c *Comment) Mentions() []string {
+	var usernames []string
+	seen := map[string]bool{}
+	for _, match := range mentionPattern.FindAllStringSubmatch(c.Body, -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			usernames = append(usernames, match[1])
+		}
+	}
+	return usernames
+}
+
+type CommentRevision struct {
+	RevisionID int       `json:"revision_id" gorm:"primaryKey;column:revision_id"`
+	CommentID  int       `json:"comment_id" gorm:"column:comment_id"`
+	Body       string    `json:"body" gorm:"column:body"`
+	CreatedAt  time.Time `json:"created_at" gorm:"column:created_at"`
+}
+
+func (c *CommentRevision) TableName() string {
+	return "comment_revisions"
+}
+
+type CommentRequest struct {
+	Body string `json:"body" validate:"required,max=10000"`
+}
+
+type CommentResponse struct {
+	CommentID int       `json:"comment_id"`
+	TaskID    int       `json:"task_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Body      string    `json:"body"`
+	Edited    bool      `json:"edited"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (c *CommentRequest) ToComment(taskID int, userID uuid.UUID) *Comment {
+	return &Comment{
+		TaskID: taskID,
+		UserID: userID,
+		Body:   c.Body,
+	}
+}
+
+func (c *Comment) ToCommentResponse() *CommentResponse {
+	return &CommentResponse{
+		CommentID: c.CommentID,
+		TaskID:    c.TaskID,
+		UserID:    c.UserID,
+		Body:      c.Body,
+		Edited:    c.Edited,
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
@@ -0,0 +1,34 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// TaskDependency says BlockedID cannot start or be done before BlockerID is done.
+type TaskDependency struct {
+	DependencyID int       `json:"dependency_id" gorm:"primaryKey;column:dependency_id"`
+	BlockerID    int       `json:"blocker_id" gorm:"column:blocker_id"`
+	BlockedID    int       `json:"blocked_id" gorm:"column:blocked_id"`
+	CreatedBy    uuid.UUID `json:"created_by" gorm:"column:created_by"`
+	CreatedAt    time.Time `json:"created_at" gorm:"column:created_at"`
+}
+
+func (d *TaskDependency) TableName() string {
+	return "task_dependencies"
+}
+
+type DependencyRequest struct {
+	BlockerID int `json:"blocker_id" validate:"required,min=1"`
+}
+
+type TodoListDependencies struct {
+	BlockedBy TodoListResponses `json:"blocked_by"`
+	Blocks    TodoListResponses `json:"blocks"`
+}
+
+type ProjectPlan struct {
+	Order        TodoListResponses `json:"order"`
+	CriticalPath TodoListResponses `json:"critical_path"`
+	CriticalDays float64           `json:"critical_days"`
+}
@@ -0,0 +1,95 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// UnmarshalJSON reads a date given as {"year", "month", "day", "time"}, as a "2006-01-02" day or as an RFC 3339 time,
+// see ParseDate.
+func (d *Date) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		date, err := ParseDate(text)
+		if err != nil {
+			return err
+		}
+		*d = date
+		return nil
+	}
+	type plain Date
+	return json.Unmarshal(data, (*plain)(d))
+}
+
+// ParseDate reads a "2006-01-02" day, or a time of day to the minute given in RFC 3339 or as "2006-01-02T15:04"
+// without an offset, which is then read in the timezone of the todolist.
+func ParseDate(text string) (Date, error) {
+	text = strings.TrimSpace(text)
+	if day, err := time.Parse(time.DateOnly, text); err == nil {
+		return Date{Year: day.Year(), Month: int(day.Month()), Day: day.Day()}, nil
+	}
+	if moment, err := time.Parse(time.RFC3339, text); err == nil {
+		date := timedDate(moment)
+		_, offset := moment.Zone()
+		date.offset = &offset
+		return date, nil
+	}
+	for _, layout := range []string{"2006-01-02T15:04:05", "2006-01-02T15:04"} {
+		if moment, err := time.Parse(layout, text); err == nil {
+			return timedDate(moment), nil
+		}
+	}
+	return Date{}, fmt.Errorf("%q is neither a 2006-01-02 date nor an RFC 3339 time", text)
+}
+
+func timedDate(moment time.Time) Date {
+	return Date{Year: moment.Year(), Month: int(moment.Month()), Day: moment.Day(), Time: moment.Format("15:04")}
+}
+
+// Due is the moment d stands for and whether it has a time of day. A whole day is kept as its midnight in UTC, a time
+// of day is read with the UTC offset it came with, else in timezone, an IANA name, else in UTC.
+func (d Date) Due(timezone string) (time.Time, bool) {
+	if d.Time == "" {
+		return time.Date(d.Year, time.Month(d.Month), d.Day, 0, 0, 0, 0, time.UTC), false
+	}
+	clock, _ := time.Parse("15:04", d.Time)
+	location := dueLocation(timezone)
+	if d.offset != nil {
+		location = time.FixedZone("", *d.offset)
+	}
+	return time.Date(d.Year, time.Month(d.Month), d.Day, clock.Hour(), clock.Minute(), 0, 0, location).UTC(), true
+}
+
+// LocalDueDate is the due date shown in the timezone it was given in, a whole day stays at its midnight in UTC.
+func (t *TodoList) LocalDueDate() *time.Time {
+	if t.DueDate == nil || !t.DueTimed {
+		return t.DueDate
+	}
+	local := t.DueDate.In(dueLocation(t.DueTimezone))
+	return &local
+}
+
+// dueLocations caches the zones of timed due dates, time.LoadLocation reads the zone database on every call.
+var dueLocations sync.Map
+
+// dueLocation is the IANA zone named timezone, UTC when it is empty or unknown.
+func dueLocation(timezone string) *time.Location {
+	if timezone == "" {
+		return time.UTC
+	}
+	if location, ok := dueLocations.Load(timezone); ok {
+		return location.(*time.Location)
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return time.UTC
+	}
+	dueLocations.Store(timezone, location)
+	return location
+}
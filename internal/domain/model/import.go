@@ -0,0 +1,75 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// TodoListImportError is a record of an import that cannot be created, Line is where it is in the file.
+type TodoListImportError struct {
+	Line     int    `json:"line"`
+	TaskName string `json:"task_name,omitempty"`
+	Error    string `json:"error"`
+}
+
+// TodoListImportWarning is what was left out of the todolist created from a record, such as a due date that cannot
+// be read.
+type TodoListImportWarning struct {
+	Line     int    `json:"line"`
+	TaskName string `json:"task_name,omitempty"`
+	Warning  string `json:"warning"`
+}
+
+// TodoListImportResponse reports an import. Nothing is created when a record fails or on a dry run, warnings do not
+// stop it.
+type TodoListImportResponse struct {
+	DryRun   bool                    `json:"dry_run"`
+	Total    int                     `json:"total"`
+	Created  int                     `json:"created"`
+	Failed   int                     `json:"failed"`
+	Errors   []TodoListImportError   `json:"errors"`
+	Warnings []TodoListImportWarning `json:"warnings"`
+}
+
+// NewTodoListImportRequest reads the fields of an import record into a request. A due date may be a day or a time,
+// due_timed set to false keeps only its day, and a missing priority is 1.
+func NewTodoListImportRequest(fields map[string]string) (request TodoListRequest, err error) {
+	request = TodoListRequest{
+		TaskName:    strings.TrimSpace(fields["task_name"]),
+		Description: strings.TrimSpace(fields["description"]),
+		Status:      TaskStatus(strings.TrimSpace(fields["status"])),
+		Recurrence:  strings.TrimSpace(fields["recurrence"]),
+		DueTimezone: strings.TrimSpace(fields["due_timezone"]),
+		Priority:    1,
+	}
+	if due := strings.TrimSpace(fields["due_date"]); due != "" {
+		date, errDate := ParseDate(due)
+		if errDate != nil {
+			return request, fmt.Errorf("due_date %q is not a date", due)
+		}
+		if timed, errTimed := strconv.ParseBool(strings.TrimSpace(fields["due_timed"])); errTimed == nil && !timed {
+			// an export shows a whole day as its midnight in UTC
+			date.Time, date.offset = "", nil
+		}
+		request.DueDate = &date
+	}
+	if priority := strings.TrimSpace(fields["priority"]); priority != "" {
+		if request.Priority, err = strconv.Atoi(priority); err != nil {
+			return request, fmt.Errorf("priority %q is not a number", priority)
+		}
+	}
+	if completed := strings.TrimSpace(fields["completed"]); completed != "" {
+		if request.Completed, err = strconv.ParseBool(completed); err != nil {
+			return request, fmt.Errorf("completed %q is not a boolean", completed)
+		}
+	}
+	if project := strings.TrimSpace(fields["project_id"]); project != "" {
+		projectID, errProject := strconv.Atoi(project)
+		if errProject != nil {
+			return request, fmt.Errorf("project_id %q is not a number", project)
+		}
+		request.ProjectID = &projectID
+	}
+	return request, nil
+}
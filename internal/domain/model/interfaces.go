@@ -6,6 +6,9 @@ import (
 	"github.com/google/uuid"
 	"go_gin/internal/domain/model/web"
 	"gorm.io/gorm"
+	"io"
+	"mime/multipart"
+	"time"
 )
 
 type CustomSeeds interface {
@@ -14,9 +17,11 @@ type CustomSeeds interface {
 
 type UsersRepository interface {
 	GetUsers(ctx context.Context, DB *gorm.DB, query web.GetAllValue) Users
+	GetUsersAfter(ctx context.Context, DB *gorm.DB, query web.GetAllValue) (Users, *web.Cursor, *web.Cursor, error)
 	GetUsersBySearch(ctx context.Context, DB *gorm.DB, query web.SearchValue) Users
 	GetUserByID(ctx context.Context, DB *gorm.DB, ID uuid.UUID) (User, error)
 	GetUserByEmail(ctx context.Context, DB *gorm.DB, Email string) (User, error)
+	GetUsersByUsernames(ctx context.Context, DB *gorm.DB, usernames []string) Users
 	CreateUser(ctx context.Context, DB *gorm.DB, user User) error
 	CreateUsers(ctx context.Context, DB *gorm.DB, users Users) error
 	UpdateUserID(ctx context.Context, DB *gorm.DB, user User, ID uuid.UUID)
@@ -25,30 +30,210 @@ type UsersRepository interface {
 	RestoreUserByID(ctx context.Context, DB *gorm.DB, ID uuid.UUID)
 	RestoreUsersByIDs(ctx context.Context, DB *gorm.DB, IDs []uuid.UUID)
 	UsersExistByID(ctx context.Context, DB *gorm.DB, ID uuid.UUID) bool
+	LockUserVersion(ctx context.Context, DB *gorm.DB, ID uuid.UUID) int
 	UsersExistByIDs(ctx context.Context, DB *gorm.DB, IDs []uuid.UUID) bool
+	SearchLanguageExists(ctx context.Context, DB *gorm.DB, language string) bool
 }
 
 type TodoListRepository interface {
-	GetTodoListsSearch(ctx context.Context, DB *gorm.DB, query web.SearchValue, userID uuid.UUID) TodoLists
-	GetTodoLists(ctx context.Context, DB *gorm.DB, query web.GetAllValue, userID uuid.UUID) TodoLists
+	GetTodoListsSearch(ctx context.Context, DB *gorm.DB, query web.SearchValue, userID uuid.UUID, language string) TodoListSearchHits
+	CountTodoListsSearch(ctx context.Context, DB *gorm.DB, query web.SearchValue, userID uuid.UUID, language string) int64
+	GetTodoLists(ctx context.Context, DB *gorm.DB, query web.TodoListsValue, userID uuid.UUID) TodoLists
+	GetTodoListsAfter(ctx context.Context, DB *gorm.DB, query web.TodoListsValue, userID uuid.UUID) (TodoLists, *web.Cursor, *web.Cursor, error)
+	CountTodoListsByGroup(ctx context.Context, DB *gorm.DB, query web.TodoListsValue, userID uuid.UUID, column string) TodoListGroups
+	GetTodoListIDs(ctx context.Context, DB *gorm.DB, query web.TodoListsValue, userID uuid.UUID) []int
+	GetTodoListsAfterID(ctx context.Context, DB *gorm.DB, query web.TodoListsValue, userID uuid.UUID, afterID int, limit int) TodoLists
+	GetDueTodoLists(ctx context.Context, DB *gorm.DB, userID uuid.UUID) TodoLists
+	GetTodoListsChangedSince(ctx context.Context, DB *gorm.DB, userID uuid.UUID, snapshot string) TodoLists
+	GetChangeSnapshot(ctx context.Context, DB *gorm.DB) string
+	GetTodoListIDsTrashedSince(ctx context.Context, DB *gorm.DB, userID uuid.UUID, snapshot string) []int
+	CountTodoLists(ctx context.Context, DB *gorm.DB, query web.TodoListsValue, userID uuid.UUID) int64
 	GetTodoListByID(ctx context.Context, DB *gorm.DB, ID int, userID uuid.UUID) (TodoList, error)
-	CreateTodoList(ctx context.Context, DB *gorm.DB, todolist TodoList) error
+	GetTodoListByTaskID(ctx context.Context, DB *gorm.DB, ID int) (TodoList, error)
+	GetSharedTodoLists(ctx context.Context, DB *gorm.DB, query web.GetAllValue, userID uuid.UUID) TodoLists
+	CountSharedTodoLists(ctx context.Context, DB *gorm.DB, userID uuid.UUID) int64
+	GetTodoListsByProjectID(ctx context.Context, DB *gorm.DB, projectID int) TodoLists
+	GetAssignedTodoLists(ctx context.Context, DB *gorm.DB, query web.GetAllValue, userID uuid.UUID) TodoLists
+	CountAssignedTodoLists(ctx context.Context, DB *gorm.DB, userID uuid.UUID) int64
+	CreateTodoList(ctx context.Context, DB *gorm.DB, todolist TodoList) (TodoList, error)
 	CreateTodoLists(ctx context.Context, DB *gorm.DB, todolists TodoLists) error
 	UpdateTodoListByID(ctx context.Context, DB *gorm.DB, todolist TodoList, ID int, userID uuid.UUID)
-	DeleteTodoListByID(ctx context.Context, DB *gorm.DB, ID int, userID uuid.UUID)
-	DeleteTodoListsByIDs(ctx context.Context, DB *gorm.DB, IDs []int, userID uuid.UUID)
+	UpdateTodoListStatus(ctx context.Context, DB *gorm.DB, todolist TodoList, ID int, userID uuid.UUID)
+	UpdateTodoListAssignee(ctx context.Context, DB *gorm.DB, ID int, assigneeID *uuid.UUID)
+	GetAdjacentPosition(ctx context.Context, DB *gorm.DB, todolist TodoList, excludeID int, after bool) string
+	UpdateTodoListPosition(ctx context.Context, DB *gorm.DB, ID int, position string)
+	RebalanceTodoLists(ctx context.Context, DB *gorm.DB, userID uuid.UUID)
+	GetBoardTodoLists(ctx context.Context, DB *gorm.DB, userID uuid.UUID) TodoLists
+	UpdateTodoListColumn(ctx context.Context, DB *gorm.DB, ID int, columnID *int)
+	UpdateTodoListEstimate(ctx context.Context, DB *gorm.DB, ID int, estimate *int)
+	CountTodoListsByStatus(ctx context.Context, DB *gorm.DB, userID uuid.UUID) map[TaskStatus]int64
+	DeleteTodoListByID(ctx context.Context, DB *gorm.DB, ID int, userID uuid.UUID, deletedAt time.Time)
+	DeleteTodoListsByIDs(ctx context.Context, DB *gorm.DB, IDs []int, userID uuid.UUID, deletedAt time.Time)
+	GetTrashedTodoLists(ctx context.Context, DB *gorm.DB, query web.GetAllValue, userID uuid.UUID) TodoLists
+	CountTrashedTodoLists(ctx context.Context, DB *gorm.DB, userID uuid.UUID) int64
+	TrashedTodoListsExistByIDs(ctx context.Context, DB *gorm.DB, IDs []int, userID uuid.UUID) bool
+	RestoreTodoListsByIDs(ctx context.Context, DB *gorm.DB, IDs []int, userID uuid.UUID)
+	RestoreTodoListsDeletedAt(ctx context.Context, DB *gorm.DB, userID uuid.UUID, deletedAt time.Time) int64
+	GetTrashedTodoListIDs(ctx context.Context, DB *gorm.DB, userID *uuid.UUID, deletedBefore *time.Time) []int
+	PurgeTodoListsByIDs(ctx context.Context, DB *gorm.DB, IDs []int)
+	LockTodoListVersion(ctx context.Context, DB *gorm.DB, ID int) int
 	TodoListExistByID(ctx context.Context, DB *gorm.DB, ID int, userID uuid.UUID) bool
 	TodoListsExistByIDs(ctx context.Context, DB *gorm.DB, IDs []int, userID uuid.UUID) bool
 }
 
+type CommentRepository interface {
+	GetCommentsByTaskID(ctx context.Context, DB *gorm.DB, taskID int) Comments
+	GetCommentByID(ctx context.Context, DB *gorm.DB, ID int, taskID int) (Comment, error)
+	CreateComment(ctx context.Context, DB *gorm.DB, comment Comment) (Comment, error)
+	UpdateCommentBody(ctx context.Context, DB *gorm.DB, ID int, body string)
+	DeleteCommentByID(ctx context.Context, DB *gorm.DB, ID int)
+	CreateCommentRevision(ctx context.Context, DB *gorm.DB, revision CommentRevision) error
+	GetCommentRevisions(ctx context.Context, DB *gorm.DB, ID int) CommentRevisions
+}
+
+type RevisionRepository interface {
+	GetRevisionsByTaskID(ctx context.Context, DB *gorm.DB, taskID int) TodoListRevisions
+	GetRevision(ctx context.Context, DB *gorm.DB, taskID int, number int) (TodoListRevision, error)
+	GetLastRevisionNumber(ctx context.Context, DB *gorm.DB, taskID int) int
+	CreateRevisions(ctx context.Context, DB *gorm.DB, revisions TodoListRevisions) error
+}
+
+type ActivityRepository interface {
+	CreateActivities(ctx context.Context, DB *gorm.DB, activities TaskActivities) error
+	GetActivitiesByTaskID(ctx context.Context, DB *gorm.DB, taskID int) TaskActivities
+}
+
+type NotificationRepository interface {
+	CreateNotifications(ctx context.Context, DB *gorm.DB, notifications Notifications) error
+	GetNotifications(ctx context.Context, DB *gorm.DB, query web.GetAllValue, userID uuid.UUID) Notifications
+	CountNotifications(ctx context.Context, DB *gorm.DB, userID uuid.UUID) int64
+	MarkNotificationsRead(ctx context.Context, DB *gorm.DB, IDs []int, userID uuid.UUID)
+}
+
+type AttachmentRepository interface {
+	GetAttachmentsByTaskIDs(ctx context.Context, DB *gorm.DB, taskIDs []int) Attachments
+	GetAttachmentByID(ctx context.Context, DB *gorm.DB, ID int, taskID int) (Attachment, error)
+	CreateAttachment(ctx context.Context, DB *gorm.DB, attachment Attachment) (Attachment, error)
+	DeleteAttachmentByID(ctx context.Context, DB *gorm.DB, ID int)
+}
+
+type AssignmentRepository interface {
+	CreateAssignment(ctx context.Context, DB *gorm.DB, assignment Assignment) error
+	GetAssignmentsByTaskID(ctx context.Context, DB *gorm.DB, taskID int) Assignments
+}
+
+type DependencyRepository interface {
+	CreateDependency(ctx context.Context, DB *gorm.DB, dependency TaskDependency) error
+	DeleteDependency(ctx context.Context, DB *gorm.DB, blockerID int, blockedID int) int64
+	LockDependencies(ctx context.Context, DB *gorm.DB)
+	DependencyPathExists(ctx context.Context, DB *gorm.DB, from int, to int) bool
+	GetBlockers(ctx context.Context, DB *gorm.DB, taskID int) TodoLists
+	GetBlocking(ctx context.Context, DB *gorm.DB, taskID int) TodoLists
+	CountOpenBlockers(ctx context.Context, DB *gorm.DB, taskID int) int64
+	GetDependenciesByTaskIDs(ctx context.Context, DB *gorm.DB, taskIDs []int) TaskDependencies
+}
+
+type ProjectRepository interface {
+	GetProjects(ctx context.Context, DB *gorm.DB, userID uuid.UUID) Projects
+	GetProjectByID(ctx context.Context, DB *gorm.DB, ID int, userID uuid.UUID) (Project, error)
+	CreateProject(ctx context.Context, DB *gorm.DB, project Project) (Project, error)
+	UpdateProjectByID(ctx context.Context, DB *gorm.DB, project Project, ID int, userID uuid.UUID)
+	DeleteProjectByID(ctx context.Context, DB *gorm.DB, ID int, userID uuid.UUID)
+	ProjectExistByID(ctx context.Context, DB *gorm.DB, ID int, userID uuid.UUID) bool
+}
+
+type ShareRepository interface {
+	CreateShare(ctx context.Context, DB *gorm.DB, share Share) (Share, error)
+	GetShareByID(ctx context.Context, DB *gorm.DB, ID int) (Share, error)
+	GetSharesReceived(ctx context.Context, DB *gorm.DB, userID uuid.UUID, status string) Shares
+	GetSharesSent(ctx context.Context, DB *gorm.DB, ownerID uuid.UUID) Shares
+	UpdateShareStatus(ctx context.Context, DB *gorm.DB, ID int, status string)
+	DeleteShareByID(ctx context.Context, DB *gorm.DB, ID int)
+	FindGrant(ctx context.Context, DB *gorm.DB, taskID int, projectID *int, userID uuid.UUID) (SharePermission, bool)
+}
+
+type TagRepository interface {
+	GetTagsByTaskID(ctx context.Context, DB *gorm.DB, taskID int) TodoListTags
+	ReplaceTags(ctx context.Context, DB *gorm.DB, taskID int, tags []string)
+}
+
+type ViewRepository interface {
+	GetViews(ctx context.Context, DB *gorm.DB, userID uuid.UUID) Views
+	GetViewByID(ctx context.Context, DB *gorm.DB, ID int) (View, error)
+	CreateView(ctx context.Context, DB *gorm.DB, view View) (View, error)
+	UpdateViewByID(ctx context.Context, DB *gorm.DB, view View, ID int) error
+	DeleteViewByID(ctx context.Context, DB *gorm.DB, ID int)
+	GetViewShares(ctx context.Context, DB *gorm.DB, ID int) ViewShares
+	CreateViewShare(ctx context.Context, DB *gorm.DB, share ViewShare) error
+	DeleteViewShare(ctx context.Context, DB *gorm.DB, ID int, userID uuid.UUID) int64
+	ViewSharedWith(ctx context.Context, DB *gorm.DB, ID int, userID uuid.UUID) bool
+}
+
+type BoardRepository interface {
+	GetColumns(ctx context.Context, DB *gorm.DB, userID uuid.UUID) BoardColumns
+	GetColumnByID(ctx context.Context, DB *gorm.DB, ID int, userID uuid.UUID) (BoardColumn, error)
+	LockColumnByID(ctx context.Context, DB *gorm.DB, ID int, userID uuid.UUID) (BoardColumn, error)
+	CreateColumns(ctx context.Context, DB *gorm.DB, columns BoardColumns) (BoardColumns, error)
+	UpdateColumnByID(ctx context.Context, DB *gorm.DB, column BoardColumn, ID int, userID uuid.UUID) error
+	DeleteColumnByID(ctx context.Context, DB *gorm.DB, ID int, userID uuid.UUID)
+}
+
+type TemplateRepository interface {
+	GetTemplates(ctx context.Context, DB *gorm.DB, userID uuid.UUID) Templates
+	GetTemplateByID(ctx context.Context, DB *gorm.DB, ID int, userID uuid.UUID) (Template, error)
+	CreateTemplate(ctx context.Context, DB *gorm.DB, template Template) (Template, error)
+	UpdateTemplateByID(ctx context.Context, DB *gorm.DB, template Template, ID int, userID uuid.UUID) error
+	DeleteTemplateByID(ctx context.Context, DB *gorm.DB, ID int, userID uuid.UUID)
+}
+
+type TimeEntryRepository interface {
+	GetRunningTimeEntry(ctx context.Context, DB *gorm.DB, userID uuid.UUID) (TimeEntry, error)
+	GetTimeEntryByID(ctx context.Context, DB *gorm.DB, ID int, userID uuid.UUID) (TimeEntry, error)
+	GetTimeEntriesByTaskID(ctx context.Context, DB *gorm.DB, taskID int) TimeEntries
+	GetTimeEntriesForReport(ctx context.Context, DB *gorm.DB, query web.TimeReportValue, userID uuid.UUID) TimeEntries
+	CreateTimeEntry(ctx context.Context, DB *gorm.DB, entry TimeEntry) (TimeEntry, error)
+	StopTimeEntry(ctx context.Context, DB *gorm.DB, ID int, endedAt time.Time)
+	DeleteTimeEntryByID(ctx context.Context, DB *gorm.DB, ID int)
+}
+
+type CalendarRepository interface {
+	SaveCalendarToken(ctx context.Context, DB *gorm.DB, token CalendarToken)
+	GetCalendarTokenByHash(ctx context.Context, DB *gorm.DB, hash string) (CalendarToken, error)
+	DeleteCalendarToken(ctx context.Context, DB *gorm.DB, userID uuid.UUID) int64
+}
+
+type CalDAVRepository interface {
+	GetCalDAVResources(ctx context.Context, DB *gorm.DB, userID uuid.UUID) CalDAVResources
+	GetCalDAVResourceByName(ctx context.Context, DB *gorm.DB, userID uuid.UUID, name string) (CalDAVResource, error)
+	GetCalDAVResourceByTaskID(ctx context.Context, DB *gorm.DB, taskID int) (CalDAVResource, error)
+	LockCalDAVName(ctx context.Context, DB *gorm.DB, userID uuid.UUID, name string)
+	CreateCalDAVResource(ctx context.Context, DB *gorm.DB, resource CalDAVResource) error
+}
+
+type StatisticsRepository interface {
+	GetStatisticsTotals(ctx context.Context, DB *gorm.DB, query web.StatisticsValue, userID *uuid.UUID, now time.Time) StatisticsTotals
+	GetStatisticsCounts(ctx context.Context, DB *gorm.DB, query web.StatisticsValue, userID *uuid.UUID, column string) StatisticsCounts
+	GetPriorityCounts(ctx context.Context, DB *gorm.DB, query web.StatisticsValue, userID *uuid.UUID) PriorityCounts
+	GetCompletionDays(ctx context.Context, DB *gorm.DB, query web.StatisticsValue, userID *uuid.UUID) []string
+}
+
+type SavedSearchRepository interface {
+	GetSavedSearches(ctx context.Context, DB *gorm.DB, userID uuid.UUID) SavedSearches
+	GetSavedSearchByID(ctx context.Context, DB *gorm.DB, ID int, userID uuid.UUID) (SavedSearch, error)
+	CreateSavedSearch(ctx context.Context, DB *gorm.DB, search SavedSearch) (SavedSearch, error)
+	UpdateSavedSearchByID(ctx context.Context, DB *gorm.DB, search SavedSearch, ID int, userID uuid.UUID) error
+	DeleteSavedSearchByID(ctx context.Context, DB *gorm.DB, ID int, userID uuid.UUID)
+	SavedSearchExistByID(ctx context.Context, DB *gorm.DB, ID int, userID uuid.UUID) bool
+}
+
 type UsersService interface {
 	FindUsersBySearch(ctx context.Context, params web.SearchQuery) (UsersResponses, web.Pagination, error)
-	FindUsers(ctx context.Context, params web.GetAllQuery) (UsersResponses, web.Pagination, error)
+	FindUsers(ctx context.Context, params web.UsersQuery) (UsersResponses, web.Pagination, error)
 	FindUserByID(ctx context.Context, ID uuid.UUID) (UserResponse, error)
 	CreateUser(ctx context.Context, user UserRequest) error
 	CreateUsers(ctx context.Context, users UsersRequests) error
-	UpdateUserID(ctx context.Context, user UserLoginUpdateRequest, ID uuid.UUID) error
-	DeleteUserByID(ctx context.Context, ID uuid.UUID) error
+	UpdateUserID(ctx context.Context, user UserLoginUpdateRequest, ID uuid.UUID, ifMatch string) (int, error)
+	DeleteUserByID(ctx context.Context, ID uuid.UUID, ifMatch string) error
 	DeleteUsersByIDs(ctx context.Context, IDs []uuid.UUID) error
 	RestoreUserByID(ctx context.Context, ID uuid.UUID) error
 	RestoreUsersByIDs(ctx context.Context, IDs []uuid.UUID) error
@@ -58,14 +243,148 @@ type UsersService interface {
 }
 
 type TodoListService interface {
-	FindTodoListsBySearch(ctx context.Context, params web.Params) (responses TodoListResponses, pagination web.Pagination, errService error)
+	FindTodoListsBySearch(ctx context.Context, params web.Params) (responses TodoListSearchResponses, pagination web.Pagination, errService error)
 	FindTodoLists(ctx context.Context, params web.Params) (responses TodoListResponses, pagination web.Pagination, errService error)
 	FindTodoListByID(ctx context.Context, params web.Params) (response TodoListResponse, errService error)
-	CreateTodoList(ctx context.Context, request TodoListRequest, params web.Params) (errService error)
+	CreateTodoList(ctx context.Context, request TodoListRequest, params web.Params) (taskID int, errService error)
 	CreatesTodoLists(ctx context.Context, requests TodoListRequests, params web.Params) (errService error)
-	UpdateTodoList(ctx context.Context, request TodoListRequest, params web.Params) (errService error)
-	DeleteTodoList(ctx context.Context, params web.Params) (errService error)
-	DeletesTodoLists(ctx context.Context, params web.Params) (errService error)
+	ImportTodoLists(ctx context.Context, body io.Reader, params web.Params) (response TodoListImportResponse, errService error)
+	ExportTodoLists(ctx context.Context, params web.Params, out io.Writer) (errService error)
+	UpdateTodoList(ctx context.Context, request TodoListRequest, params web.Params) (version int, errService error)
+	UpdateTodoListStatus(ctx context.Context, request TodoListStatusRequest, params web.Params) (errService error)
+	UpdatesTodoLists(ctx context.Context, request TodoListBulkUpdateRequest, params web.Params) (response TodoListBulkUpdateResponse, errService error)
+	FindTodoListsGroupByStatus(ctx context.Context, params web.Params) (groups []TodoListStatusGroup, errService error)
+	FindSharedTodoLists(ctx context.Context, params web.Params) (responses TodoListResponses, pagination web.Pagination, errService error)
+	FindAssignedTodoLists(ctx context.Context, params web.Params) (responses TodoListResponses, pagination web.Pagination, errService error)
+	AssignTodoList(ctx context.Context, request TodoListAssignRequest, params web.Params) (errService error)
+	MoveTodoList(ctx context.Context, request TodoListMoveRequest, params web.Params) (response TodoListResponse, errService error)
+	FindAssignments(ctx context.Context, params web.Params) (assignments Assignments, errService error)
+	DeleteTodoList(ctx context.Context, params web.Params) (response TodoListUndoResponse, errService error)
+	DeletesTodoLists(ctx context.Context, params web.Params) (response TodoListUndoResponse, errService error)
+	FindTrashedTodoLists(ctx context.Context, params web.Params) (responses TodoListResponses, pagination web.Pagination, errService error)
+	RestoreTodoList(ctx context.Context, params web.Params) (errService error)
+	RestoresTodoLists(ctx context.Context, params web.Params) (errService error)
+	UndoDeleteTodoLists(ctx context.Context, request TodoListUndoRequest, params web.Params) (errService error)
+	EmptyTrash(ctx context.Context, params web.Params) (errService error)
+	PurgeTrash(ctx context.Context, deletedBefore time.Time) (purged int, errService error)
+	FindRevisions(ctx context.Context, params web.Params) (responses []TodoListRevisionResponse, errService error)
+	RevertTodoList(ctx context.Context, request TodoListRevertRequest, params web.Params) (errService error)
+}
+
+type CommentService interface {
+	FindComments(ctx context.Context, params web.Params) (responses CommentResponses, errService error)
+	CreateComment(ctx context.Context, request CommentRequest, params web.Params) (response CommentResponse, errService error)
+	UpdateComment(ctx context.Context, request CommentRequest, params web.Params) (errService error)
+	DeleteComment(ctx context.Context, params web.Params) (errService error)
+	FindCommentHistory(ctx context.Context, params web.Params) (revisions CommentRevisions, errService error)
+	FindActivities(ctx context.Context, params web.Params) (responses ActivityResponses, errService error)
+}
+
+type NotificationService interface {
+	FindNotifications(ctx context.Context, params web.Params) (notifications Notifications, pagination web.Pagination, errService error)
+	ReadNotifications(ctx context.Context, params web.Params) (errService error)
+}
+
+type AttachmentService interface {
+	FindAttachments(ctx context.Context, params web.Params) (responses AttachmentResponses, errService error)
+	CreateAttachment(ctx context.Context, file *multipart.FileHeader, params web.Params) (response AttachmentResponse, errService error)
+	OpenAttachment(ctx context.Context, params web.Params) (attachment Attachment, reader io.ReadSeekCloser, errService error)
+	DeleteAttachment(ctx context.Context, params web.Params) (errService error)
+}
+
+type DependencyService interface {
+	FindDependencies(ctx context.Context, params web.Params) (dependencies TodoListDependencies, errService error)
+	CreateDependency(ctx context.Context, request DependencyRequest, params web.Params) (errService error)
+	DeleteDependency(ctx context.Context, params web.Params) (errService error)
+	FindProjectPlan(ctx context.Context, params web.Params) (plan ProjectPlan, errService error)
+}
+
+type ProjectService interface {
+	FindProjects(ctx context.Context, params web.Params) (responses ProjectResponses, errService error)
+	CreateProject(ctx context.Context, request ProjectRequest, params web.Params) (response ProjectResponse, errService error)
+	UpdateProject(ctx context.Context, request ProjectRequest, params web.Params) (errService error)
+	DeleteProject(ctx context.Context, params web.Params) (errService error)
+}
+
+type ShareService interface {
+	FindSharesReceived(ctx context.Context, params web.Params) (shares Shares, errService error)
+	FindSharesSent(ctx context.Context, params web.Params) (shares Shares, errService error)
+	CreateShare(ctx context.Context, request ShareRequest, params web.Params) (share Share, errService error)
+	RespondShare(ctx context.Context, params web.Params) (errService error)
+	DeleteShare(ctx context.Context, params web.Params) (errService error)
+}
+
+type TagService interface {
+	FindTags(ctx context.Context, params web.Params) (tags []string, errService error)
+	UpdateTags(ctx context.Context, request TagsRequest, params web.Params) (tags []string, errService error)
+}
+
+type ViewService interface {
+	FindViews(ctx context.Context, params web.Params) (responses ViewResponses, errService error)
+	CreateView(ctx context.Context, request ViewRequest, params web.Params) (response ViewResponse, errService error)
+	UpdateView(ctx context.Context, request ViewRequest, params web.Params) (errService error)
+	DeleteView(ctx context.Context, params web.Params) (errService error)
+	FindViewShares(ctx context.Context, params web.Params) (shares ViewShares, errService error)
+	ShareView(ctx context.Context, request ViewShareRequest, params web.Params) (errService error)
+	UnshareView(ctx context.Context, params web.Params) (errService error)
+	FindViewTodoLists(ctx context.Context, params web.Params) (responses TodoListResponses, groups TodoListGroups, pagination web.Pagination, errService error)
+}
+
+type BoardService interface {
+	FindBoard(ctx context.Context, params web.Params) (response BoardResponse, errService error)
+	CreateColumn(ctx context.Context, request BoardColumnRequest, params web.Params) (response BoardColumnResponse, errService error)
+	UpdateColumn(ctx context.Context, request BoardColumnRequest, params web.Params) (errService error)
+	DeleteColumn(ctx context.Context, params web.Params) (errService error)
+	MoveCard(ctx context.Context, request BoardCardMoveRequest, params web.Params) (response TodoListResponse, errService error)
+}
+
+type TemplateService interface {
+	FindTemplates(ctx context.Context, params web.Params) (responses TemplateResponses, errService error)
+	FindTemplateByID(ctx context.Context, params web.Params) (response TemplateResponse, errService error)
+	CreateTemplate(ctx context.Context, request TemplateRequest, params web.Params) (response TemplateResponse, errService error)
+	UpdateTemplate(ctx context.Context, request TemplateRequest, params web.Params) (errService error)
+	DeleteTemplate(ctx context.Context, params web.Params) (errService error)
+	InstantiateTemplate(ctx context.Context, request TemplateInstantiateRequest, params web.Params) (responses TodoListResponses, errService error)
+}
+
+type TimeService interface {
+	StartTimer(ctx context.Context, request TimerStartRequest, params web.Params) (response TimeEntryResponse, errService error)
+	StopTimer(ctx context.Context, params web.Params) (response TimeEntryResponse, errService error)
+	FindRunningTimer(ctx context.Context, params web.Params) (response *TimeEntryResponse, errService error)
+	CreateTimeEntry(ctx context.Context, request TimeEntryRequest, params web.Params) (response TimeEntryResponse, errService error)
+	DeleteTimeEntry(ctx context.Context, params web.Params) (errService error)
+	FindTimeSummary(ctx context.Context, params web.Params) (response TimeSummary, errService error)
+	UpdateEstimate(ctx context.Context, request TodoListEstimateRequest, params web.Params) (errService error)
+	FindTimeReport(ctx context.Context, params web.Params) (rows TimeReportRows, errService error)
+}
+
+type CalendarService interface {
+	CreateCalendarToken(ctx context.Context, params web.Params) (response CalendarTokenResponse, errService error)
+	DeleteCalendarToken(ctx context.Context, params web.Params) (errService error)
+	WriteCalendarFeed(ctx context.Context, token string, out io.Writer) (errService error)
+}
+
+type CalDAVService interface {
+	FindCalDAVCollection(ctx context.Context, params web.Params, syncToken string) (collection CalDAVCollection, errService error)
+	FindCalDAVObject(ctx context.Context, params web.Params, name string) (object CalDAVObject, errService error)
+	PutCalDAVObject(ctx context.Context, params web.Params, name string, body io.Reader) (object CalDAVObject, created bool, errService error)
+	DeleteCalDAVObject(ctx context.Context, params web.Params, name string) (errService error)
+}
+
+type QuickAddService interface {
+	QuickAddTodoList(ctx context.Context, request QuickAddRequest, params web.Params) (response QuickAddResponse, errService error)
+}
+
+type StatisticsService interface {
+	FindStatistics(ctx context.Context, params web.Params) (response StatisticsResponse, errService error)
+	FindAllStatistics(ctx context.Context, params web.Params) (response StatisticsResponse, errService error)
+}
+
+type SavedSearchService interface {
+	FindSavedSearches(ctx context.Context, params web.Params) (responses SavedSearchResponses, errService error)
+	CreateSavedSearch(ctx context.Context, request SavedSearchRequest, params web.Params) (response SavedSearchResponse, errService error)
+	UpdateSavedSearch(ctx context.Context, request SavedSearchRequest, params web.Params) (errService error)
+	DeleteSavedSearch(ctx context.Context, params web.Params) (errService error)
 }
 
 type UsersController interface {
@@ -91,6 +410,145 @@ type TodoListController interface {
 	CreateTodoList(c *gin.Context)
 	CreatesTodoLists(c *gin.Context)
 	UpdateTodoList(c *gin.Context)
+	UpdateTodoLists(c *gin.Context)
+	ImportTodoLists(c *gin.Context)
+	ExportTodoLists(c *gin.Context)
+	UpdateTodoListStatus(c *gin.Context)
+	GetTodoListGroupByStatus(c *gin.Context)
+	GetSharedTodoLists(c *gin.Context)
+	GetAssignedTodoLists(c *gin.Context)
+	AssignTodoList(c *gin.Context)
+	MoveTodoList(c *gin.Context)
+	GetAssignments(c *gin.Context)
 	DeleteTodoList(c *gin.Context)
 	DeleteTodoLists(c *gin.Context)
+	GetTrashedTodoLists(c *gin.Context)
+	RestoreTodoList(c *gin.Context)
+	RestoreTodoLists(c *gin.Context)
+	UndoDeleteTodoLists(c *gin.Context)
+	EmptyTrash(c *gin.Context)
+	GetRevisions(c *gin.Context)
+	RevertTodoList(c *gin.Context)
+}
+
+type CommentController interface {
+	GetComments(c *gin.Context)
+	CreateComment(c *gin.Context)
+	UpdateComment(c *gin.Context)
+	DeleteComment(c *gin.Context)
+	GetCommentHistory(c *gin.Context)
+	GetActivities(c *gin.Context)
+}
+
+type NotificationController interface {
+	GetNotifications(c *gin.Context)
+	ReadNotifications(c *gin.Context)
+}
+
+type AttachmentController interface {
+	GetAttachments(c *gin.Context)
+	CreateAttachment(c *gin.Context)
+	DownloadAttachment(c *gin.Context)
+	DeleteAttachment(c *gin.Context)
+}
+
+type DependencyController interface {
+	GetDependencies(c *gin.Context)
+	CreateDependency(c *gin.Context)
+	DeleteDependency(c *gin.Context)
+	GetProjectPlan(c *gin.Context)
+}
+
+type ProjectController interface {
+	GetProjects(c *gin.Context)
+	CreateProject(c *gin.Context)
+	UpdateProject(c *gin.Context)
+	DeleteProject(c *gin.Context)
+}
+
+type ShareController interface {
+	GetSharesReceived(c *gin.Context)
+	GetSharesSent(c *gin.Context)
+	CreateShare(c *gin.Context)
+	RespondShare(c *gin.Context)
+	DeleteShare(c *gin.Context)
+}
+
+type TagController interface {
+	GetTags(c *gin.Context)
+	UpdateTags(c *gin.Context)
+}
+
+type ViewController interface {
+	GetViews(c *gin.Context)
+	CreateView(c *gin.Context)
+	UpdateView(c *gin.Context)
+	DeleteView(c *gin.Context)
+	GetViewShares(c *gin.Context)
+	ShareView(c *gin.Context)
+	UnshareView(c *gin.Context)
+	GetViewTodoLists(c *gin.Context)
+}
+
+type BoardController interface {
+	GetBoard(c *gin.Context)
+	CreateColumn(c *gin.Context)
+	UpdateColumn(c *gin.Context)
+	DeleteColumn(c *gin.Context)
+	MoveCard(c *gin.Context)
+}
+
+type TemplateController interface {
+	GetTemplates(c *gin.Context)
+	GetTemplateByID(c *gin.Context)
+	CreateTemplate(c *gin.Context)
+	UpdateTemplate(c *gin.Context)
+	DeleteTemplate(c *gin.Context)
+	InstantiateTemplate(c *gin.Context)
+}
+
+type TimeController interface {
+	StartTimer(c *gin.Context)
+	StopTimer(c *gin.Context)
+	GetRunningTimer(c *gin.Context)
+	CreateTimeEntry(c *gin.Context)
+	DeleteTimeEntry(c *gin.Context)
+	GetTimeSummary(c *gin.Context)
+	UpdateEstimate(c *gin.Context)
+	GetTimeReport(c *gin.Context)
+}
+
+type CalendarController interface {
+	CreateCalendarToken(c *gin.Context)
+	DeleteCalendarToken(c *gin.Context)
+	GetCalendarFeed(c *gin.Context)
+}
+
+type CalDAVController interface {
+	WellKnown(c *gin.Context)
+	Options(c *gin.Context)
+	PropfindRoot(c *gin.Context)
+	PropfindHome(c *gin.Context)
+	PropfindCollection(c *gin.Context)
+	PropfindObject(c *gin.Context)
+	Report(c *gin.Context)
+	GetObject(c *gin.Context)
+	PutObject(c *gin.Context)
+	DeleteObject(c *gin.Context)
+}
+
+type QuickAddController interface {
+	QuickAddTodoList(c *gin.Context)
+}
+
+type StatisticsController interface {
+	GetStatistics(c *gin.Context)
+	GetAllStatistics(c *gin.Context)
+}
+
+type SavedSearchController interface {
+	GetSavedSearches(c *gin.Context)
+	CreateSavedSearch(c *gin.Context)
+	UpdateSavedSearch(c *gin.Context)
+	DeleteSavedSearch(c *gin.Context)
 }
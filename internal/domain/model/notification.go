@@ -0,0 +1,25 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	NotificationMention = "mention"
+)
+
+type Notification struct {
+	NotificationID int        `json:"notification_id" gorm:"primaryKey;column:notification_id"`
+	UserID         uuid.UUID  `json:"user_id" gorm:"column:user_id"`
+	ActorID        uuid.UUID  `json:"actor_id" gorm:"column:actor_id"`
+	TaskID         *int       `json:"task_id" gorm:"column:task_id"`
+	Type           string     `json:"type" gorm:"column:type"`
+	Message        string     `json:"message" gorm:"column:message"`
+	ReadAt         *time.Time `json:"read_at" gorm:"column:read_at"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"column:created_at"`
+}
+
+func (n *Notification) TableName() string {
+	return "notifications"
+}
@@ -0,0 +1,50 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type Project struct {
+	ProjectID   int       `json:"project_id" gorm:"primaryKey;column:project_id"`
+	UserID      uuid.UUID `json:"user_id" gorm:"column:user_id"`
+	Name        string    `json:"name" gorm:"column:name"`
+	Description string    `json:"description" gorm:"column:description"`
+	CreatedAt   time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (p *Project) TableName() string {
+	return "projects"
+}
+
+type ProjectRequest struct {
+	Name        string `json:"name" validate:"required,max=255"`
+	Description string `json:"description"`
+}
+
+type ProjectResponse struct {
+	ProjectID   int       `json:"project_id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func (p *ProjectRequest) ToProject(userID uuid.UUID) *Project {
+	return &Project{
+		UserID:      userID,
+		Name:        p.Name,
+		Description: p.Description,
+	}
+}
+
+func (p *Project) ToProjectResponse() *ProjectResponse {
+	return &ProjectResponse{
+		ProjectID:   p.ProjectID,
+		Name:        p.Name,
+		Description: p.Description,
+		CreatedAt:   p.CreatedAt,
+		UpdatedAt:   p.UpdatedAt,
+	}
+}
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"go_gin/pkg/quickadd"
+	"time"
+)
+
+// QuickAddRequest is a task written as free text such as "Pay rent every 1st of month !high #home". Dates are read
+// in Timezone, an IANA name, UTC when it is empty. DryRun only shows how the text is read.
+type QuickAddRequest struct {
+	Text     string `json:"text" validate:"required,max=1024"`
+	Timezone string `json:"timezone" validate:"omitempty,max=64"`
+	DryRun   bool   `json:"dry_run"`
+}
+
+// QuickAddResponse shows the todolist read from a quick add, TaskID is set once it is created.
+type QuickAddResponse struct {
+	DryRun  bool            `json:"dry_run"`
+	TaskID  int             `json:"task_id,omitempty"`
+	Request TodoListRequest `json:"request"`
+	Tags    []string        `json:"tags"`
+}
+
+// NewQuickAddTodoListRequest turns a parsed task into a request. The description repeats the title, a task without a
+// date is due today and one without a priority gets 1. A time of day is kept in the timezone of now.
+func NewQuickAddTodoListRequest(task quickadd.Task, now time.Time) TodoListRequest {
+	due := now
+	if task.Due != nil {
+		due = *task.Due
+	}
+	date := Date{Year: due.Year(), Month: int(due.Month()), Day: due.Day()}
+	request := TodoListRequest{
+		TaskName:    task.Title,
+		Description: task.Title,
+		DueDate:     &date,
+		Priority:    task.Priority,
+		Recurrence:  task.Recurrence,
+	}
+	if task.HasTime {
+		date.Time = due.Format("15:04")
+		request.DueTimezone = now.Location().String()
+	}
+	if request.Priority == 0 {
+		request.Priority = 1
+	}
+	return request
+}
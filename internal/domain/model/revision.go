@@ -0,0 +1,158 @@
+package model
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+// TodoListRevision is the state of the editable fields of a todolist after a change. The first revision of a
+// todolist is its state before its first recorded change.
+type TodoListRevision struct {
+	RevisionID  int        `json:"revision_id" gorm:"primaryKey;column:revision_id"`
+	TaskID      int        `json:"task_id" gorm:"column:task_id"`
+	Number      int        `json:"number" gorm:"column:number"`
+	UserID      *uuid.UUID `json:"user_id" gorm:"column:user_id"`
+	ProjectID   *int       `json:"project_id" gorm:"column:project_id"`
+	TaskName    string     `json:"task_name" gorm:"column:task_name"`
+	Description string     `json:"description" gorm:"column:description"`
+	DueDate     *time.Time `json:"due_date" gorm:"column:due_date"`
+	DueTimed    bool       `json:"due_timed" gorm:"column:due_timed"`
+	DueTimezone string     `json:"due_timezone" gorm:"column:due_timezone"`
+	Priority    int        `json:"priority" gorm:"column:priority"`
+	Completed   bool       `json:"completed" gorm:"column:completed"`
+	Status      TaskStatus `json:"status" gorm:"column:status"`
+	Changed     string     `json:"changed" gorm:"column:changed"`
+	CreatedAt   time.Time  `json:"created_at" gorm:"column:created_at"`
+}
+
+func (t *TodoListRevision) TableName() string {
+	return "todolist_revisions"
+}
+
+type RevisionChange struct {
+	Field    string `json:"field"`
+	OldValue string `json:"old_value"`
+	NewValue string `json:"new_value"`
+}
+
+type TodoListRevisionResponse struct {
+	RevisionID  int              `json:"revision_id"`
+	Number      int              `json:"number"`
+	UserID      *uuid.UUID       `json:"user_id"`
+	ProjectID   *int             `json:"project_id"`
+	TaskName    string           `json:"task_name"`
+	Description string           `json:"description"`
+	DueDate     *time.Time       `json:"due_date"`
+	DueTimed    bool             `json:"due_timed"`
+	DueTimezone string           `json:"due_timezone"`
+	Priority    int              `json:"priority"`
+	Completed   bool             `json:"completed"`
+	Status      TaskStatus       `json:"status"`
+	Changes     []RevisionChange `json:"changes"`
+	CreatedAt   time.Time        `json:"created_at"`
+}
+
+type TodoListRevertRequest struct {
+	Number int `json:"number" validate:"required,min=1"`
+}
+
+// revisionFields are the versioned fields of todolist in a stable order, formatted like activities.
+func revisionFields(todolist TodoList) [][2]string {
+	project := ""
+	if todolist.ProjectID != nil {
+		project = fmt.Sprint(*todolist.ProjectID)
+	}
+	return [][2]string{
+		{"task_name", todolist.TaskName},
+		{"description", todolist.Description},
+		{"due_date", formatActivityDue(todolist)},
+		{"priority", fmt.Sprint(todolist.Priority)},
+		{"completed", fmt.Sprint(todolist.Completed)},
+		{"status", string(todolist.Status)},
+		{"project_id", project},
+	}
+}
+
+// DiffRevision lists the versioned fields that differ between before and after.
+func DiffRevision(before, after TodoList) []RevisionChange {
+	changes := []RevisionChange{}
+	old, current := revisionFields(before), revisionFields(after)
+	for i := range old {
+		if old[i][1] != current[i][1] {
+			changes = append(changes, RevisionChange{Field: old[i][0], OldValue: old[i][1], NewValue: current[i][1]})
+		}
+	}
+	return changes
+}
+
+// NewTodoListRevision snapshots todolist as revision number made by userID, changes lists the fields it changed.
+func NewTodoListRevision(todolist TodoList, number int, userID uuid.UUID, changes []RevisionChange) *TodoListRevision {
+	fields := make([]string, len(changes))
+	for i, change := range changes {
+		fields[i] = change.Field
+	}
+	return &TodoListRevision{
+		TaskID:      todolist.TaskID,
+		Number:      number,
+		UserID:      &userID,
+		ProjectID:   todolist.ProjectID,
+		TaskName:    todolist.TaskName,
+		Description: todolist.Description,
+		DueDate:     todolist.DueDate,
+		DueTimed:    todolist.DueTimed,
+		DueTimezone: todolist.DueTimezone,
+		Priority:    todolist.Priority,
+		Completed:   todolist.Completed,
+		Status:      todolist.Status,
+		Changed:     strings.Join(fields, ","),
+	}
+}
+
+// ToTodoList returns the todolist of userID as it was at the revision.
+func (t *TodoListRevision) ToTodoList(userID uuid.UUID) *TodoList {
+	return &TodoList{
+		TaskID:      t.TaskID,
+		UserID:      userID,
+		ProjectID:   t.ProjectID,
+		TaskName:    t.TaskName,
+		Description: t.Description,
+		DueDate:     t.DueDate,
+		DueTimed:    t.DueTimed,
+		DueTimezone: t.DueTimezone,
+		Priority:    t.Priority,
+		Completed:   t.Completed,
+		Status:      t.Status,
+	}
+}
+
+// ToTodoListRevisionResponses returns the history newest first, each revision with its diff to the one before it.
+func (t TodoListRevisions) ToTodoListRevisionResponses() []TodoListRevisionResponse {
+	responses := make([]TodoListRevisionResponse, 0, len(t))
+	for i := len(t) - 1; i >= 0; i-- {
+		revision := t[i]
+		state := revision.ToTodoList(uuid.Nil)
+		changes := []RevisionChange{}
+		if i > 0 {
+			changes = DiffRevision(*t[i-1].ToTodoList(uuid.Nil), *state)
+		}
+		responses = append(responses, TodoListRevisionResponse{
+			RevisionID:  revision.RevisionID,
+			Number:      revision.Number,
+			UserID:      revision.UserID,
+			ProjectID:   revision.ProjectID,
+			TaskName:    revision.TaskName,
+			Description: revision.Description,
+			DueDate:     state.LocalDueDate(),
+			DueTimed:    revision.DueTimed,
+			DueTimezone: revision.DueTimezone,
+			Priority:    revision.Priority,
+			Completed:   revision.Completed,
+			Status:      revision.Status,
+			Changes:     changes,
+			CreatedAt:   revision.CreatedAt,
+		})
+	}
+	return responses
+}
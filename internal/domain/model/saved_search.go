@@ -0,0 +1,51 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// SavedSearch is a todolist query of the query language kept under a name, see querylang.Parse.
+type SavedSearch struct {
+	SearchID  int       `json:"search_id" gorm:"primaryKey;column:search_id"`
+	UserID    uuid.UUID `json:"user_id" gorm:"column:user_id"`
+	Name      string    `json:"name" gorm:"column:name"`
+	Query     string    `json:"query" gorm:"column:query"`
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (s *SavedSearch) TableName() string {
+	return "saved_searches"
+}
+
+type SavedSearchRequest struct {
+	Name  string `json:"name" validate:"required,max=100"`
+	Query string `json:"query" validate:"required,max=512"`
+}
+
+type SavedSearchResponse struct {
+	SearchID  int       `json:"search_id"`
+	Name      string    `json:"name"`
+	Query     string    `json:"query"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (s *SavedSearchRequest) ToSavedSearch(userID uuid.UUID) *SavedSearch {
+	return &SavedSearch{
+		UserID: userID,
+		Name:   s.Name,
+		Query:  s.Query,
+	}
+}
+
+func (s *SavedSearch) ToSavedSearchResponse() *SavedSearchResponse {
+	return &SavedSearchResponse{
+		SearchID:  s.SearchID,
+		Name:      s.Name,
+		Query:     s.Query,
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
+	}
+}
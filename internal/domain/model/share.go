@@ -0,0 +1,88 @@
+package model
+
+import (
+	"database/sql/driver"
+	"github.com/google/uuid"
+	"time"
+)
+
+type SharePermission string
+
+func (sp *SharePermission) Scan(value interface{}) error {
+	if value == nil {
+		*sp = ""
+		return nil
+	}
+	switch v := value.(type) {
+	case []byte:
+		*sp = SharePermission(v)
+	case string:
+		*sp = SharePermission(v)
+	}
+	return nil
+}
+
+func (sp SharePermission) Value() (driver.Value, error) {
+	return string(sp), nil
+}
+
+const (
+	PermissionViewer SharePermission = "viewer"
+	PermissionEditor SharePermission = "editor"
+)
+
+// Allows reports whether a grant of sp covers an action that needs required.
+func (sp SharePermission) Allows(required SharePermission) bool {
+	switch required {
+	case PermissionViewer:
+		return sp == PermissionViewer || sp == PermissionEditor
+	case PermissionEditor:
+		return sp == PermissionEditor
+	default:
+		return false
+	}
+}
+
+const (
+	ShareStatusPending  = "pending"
+	ShareStatusAccepted = "accepted"
+	ShareStatusDeclined = "declined"
+)
+
+const (
+	NotificationShareInvitation = "share_invitation"
+)
+
+type Share struct {
+	ShareID     int             `json:"share_id" gorm:"primaryKey;column:share_id"`
+	OwnerID     uuid.UUID       `json:"owner_id" gorm:"column:owner_id"`
+	UserID      uuid.UUID       `json:"user_id" gorm:"column:user_id"`
+	TaskID      *int            `json:"task_id" gorm:"column:task_id"`
+	ProjectID   *int            `json:"project_id" gorm:"column:project_id"`
+	Permission  SharePermission `json:"permission" gorm:"column:permission"`
+	Status      string          `json:"status" gorm:"column:status;default:pending"`
+	CreatedAt   time.Time       `json:"created_at" gorm:"column:created_at"`
+	RespondedAt *time.Time      `json:"responded_at" gorm:"column:responded_at"`
+}
+
+func (s *Share) TableName() string {
+	return "shares"
+}
+
+type ShareRequest struct {
+	UserID     uuid.UUID       `json:"user_id" validate:"required"`
+	TaskID     *int            `json:"task_id" validate:"required_without=ProjectID,excluded_with=ProjectID"`
+	ProjectID  *int            `json:"project_id" validate:"required_without=TaskID,excluded_with=TaskID"`
+	Permission SharePermission `json:"permission" validate:"required,eq=viewer|eq=editor"`
+}
+
+func (s *ShareRequest) ToShare(ownerID uuid.UUID) *Share {
+	return &Share{
+		OwnerID:    ownerID,
+		UserID:     s.UserID,
+		TaskID:     s.TaskID,
+		ProjectID:  s.ProjectID,
+		Permission: s.Permission,
+		Status:     ShareStatusPending,
+	}
+}
@@ -0,0 +1,147 @@
+package model
+
+import (
+	"go_gin/internal/domain/model/web"
+	"time"
+)
+
+// StatisticsTotals are the counts of a statistics range, read in a single aggregation. CreatedCompleted counts the
+// todolists created in the range that are completed, LeadSeconds is the average time from creation to completion of
+// the todolists completed in the range. Overdue is counted now, whatever the range.
+type StatisticsTotals struct {
+	Users            int64    `gorm:"column:users"`
+	Created          int64    `gorm:"column:created"`
+	CreatedCompleted int64    `gorm:"column:created_completed"`
+	Completed        int64    `gorm:"column:completed"`
+	LeadSeconds      *float64 `gorm:"column:lead_seconds"`
+	Overdue          int64    `gorm:"column:overdue"`
+}
+
+// StatisticsCount is the number of todolists in the period starting on the 2006-01-02 day Period.
+type StatisticsCount struct {
+	Period string `gorm:"column:period"`
+	Count  int64  `gorm:"column:count"`
+}
+
+type StatisticsPeriod struct {
+	Period    string `json:"period"`
+	Created   int64  `json:"created"`
+	Completed int64  `json:"completed"`
+}
+
+type PriorityCount struct {
+	Priority int   `json:"priority" gorm:"column:priority"`
+	Count    int64 `json:"count" gorm:"column:count"`
+}
+
+// StatisticsResponse sums up the todolists of a user, or of every user for Users set, from From to To included.
+// CompletionRate is the share of the todolists created in the range that are completed, streaks are days in a row
+// with at least one todolist completed.
+type StatisticsResponse struct {
+	From             string            `json:"from"`
+	To               string            `json:"to"`
+	Period           string            `json:"period"`
+	Users            *int64            `json:"users,omitempty"`
+	Created          int64             `json:"created"`
+	Completed        int64             `json:"completed"`
+	CompletionRate   float64           `json:"completion_rate"`
+	AverageLeadHours *float64          `json:"average_lead_hours"`
+	Overdue          int64             `json:"overdue"`
+	CurrentStreak    int               `json:"current_streak"`
+	LongestStreak    int               `json:"longest_streak"`
+	Priorities       PriorityCounts    `json:"priorities"`
+	Series           StatisticsPeriods `json:"series"`
+}
+
+// NewStatisticsResponse puts together the aggregations of query, days are the 2006-01-02 days a todolist was
+// completed on in ascending order. The current streak is counted back from the last day of the range, or from today
+// when the range goes past it.
+func NewStatisticsResponse(query web.StatisticsValue, totals StatisticsTotals, created, completed StatisticsCounts, priorities PriorityCounts, days []string, now time.Time) StatisticsResponse {
+	last := query.To.AddDate(0, 0, -1)
+	if today := now.UTC(); today.Before(last) {
+		last = today
+	}
+	response := StatisticsResponse{
+		From:       query.From.Format(time.DateOnly),
+		To:         query.To.AddDate(0, 0, -1).Format(time.DateOnly),
+		Period:     query.Period,
+		Created:    totals.Created,
+		Completed:  totals.Completed,
+		Overdue:    totals.Overdue,
+		Priorities: priorities,
+		Series:     NewStatisticsSeries(query, created, completed),
+	}
+	if response.Priorities == nil {
+		response.Priorities = PriorityCounts{}
+	}
+	if totals.Created > 0 {
+		response.CompletionRate = float64(totals.CreatedCompleted) / float64(totals.Created)
+	}
+	if totals.LeadSeconds != nil {
+		hours := *totals.LeadSeconds / 3600
+		response.AverageLeadHours = &hours
+	}
+	response.CurrentStreak, response.LongestStreak = Streaks(days, last)
+	return response
+}
+
+// NewStatisticsSeries lists every day or week of query with the todolists created and completed in it, periods
+// without any come with zeros.
+func NewStatisticsSeries(query web.StatisticsValue, created, completed StatisticsCounts) StatisticsPeriods {
+	counts := make(map[string]*StatisticsPeriod)
+	series := StatisticsPeriods{}
+	for start := PeriodStart(query.From, query.Period); start.Before(query.To); {
+		period := StatisticsPeriod{Period: start.Format(time.DateOnly)}
+		series = append(series, period)
+		if query.Period == "week" {
+			start = start.AddDate(0, 0, 7)
+		} else {
+			start = start.AddDate(0, 0, 1)
+		}
+	}
+	for i := range series {
+		counts[series[i].Period] = &series[i]
+	}
+	for _, count := range created {
+		if period, ok := counts[count.Period]; ok {
+			period.Created = count.Count
+		}
+	}
+	for _, count := range completed {
+		if period, ok := counts[count.Period]; ok {
+			period.Completed = count.Count
+		}
+	}
+	return series
+}
+
+// Streaks returns the current and the longest run of consecutive days among days, ascending 2006-01-02 days. The
+// current run ends on last, or on the day before when nothing was completed on last yet.
+func Streaks(days []string, last time.Time) (current int, longest int) {
+	done := make(map[string]bool, len(days))
+	run := 0
+	var previous time.Time
+	for _, day := range days {
+		date, err := time.Parse(time.DateOnly, day)
+		if err != nil {
+			continue
+		}
+		done[day] = true
+		if run > 0 && previous.AddDate(0, 0, 1).Equal(date) {
+			run++
+		} else {
+			run = 1
+		}
+		previous = date
+		longest = max(longest, run)
+	}
+	day := time.Date(last.Year(), last.Month(), last.Day(), 0, 0, 0, 0, time.UTC)
+	if !done[day.Format(time.DateOnly)] {
+		day = day.AddDate(0, 0, -1)
+	}
+	for done[day.Format(time.DateOnly)] {
+		current++
+		day = day.AddDate(0, 0, -1)
+	}
+	return current, longest
+}
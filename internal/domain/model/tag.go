@@ -0,0 +1,31 @@
+package model
+
+import "strings"
+
+type TodoListTag struct {
+	TaskID int    `json:"task_id" gorm:"primaryKey;column:task_id"`
+	Tag    string `json:"tag" gorm:"primaryKey;column:tag"`
+}
+
+func (t *TodoListTag) TableName() string {
+	return "todolist_tags"
+}
+
+type TagsRequest struct {
+	Tags []string `json:"tags" validate:"max=32,dive,required,max=64"`
+}
+
+// Normalize lowercases the tags and drops the duplicates so tag:Work and tag:work are the same tag.
+func (t *TagsRequest) Normalize() []string {
+	tags := []string{}
+	seen := map[string]bool{}
+	for _, tag := range t.Tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+	return tags
+}
@@ -0,0 +1,155 @@
+package model
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// Template is a named, reusable set of todolists whose due dates are relative to the day it is instantiated.
+type Template struct {
+	TemplateID  int           `json:"template_id" gorm:"primaryKey;column:template_id"`
+	UserID      uuid.UUID     `json:"user_id" gorm:"column:user_id"`
+	Name        string        `json:"name" gorm:"column:name"`
+	Description string        `json:"description" gorm:"column:description"`
+	Items       TemplateItems `json:"items" gorm:"foreignKey:TemplateID;references:TemplateID"`
+	CreatedAt   time.Time     `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt   time.Time     `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (t *Template) TableName() string {
+	return "templates"
+}
+
+// TemplateItem is one todolist of a template, Due is an offset from the start date such as "+3d" or "+2w".
+type TemplateItem struct {
+	ItemID      int    `json:"item_id" gorm:"primaryKey;column:item_id"`
+	TemplateID  int    `json:"template_id" gorm:"column:template_id"`
+	SortOrder   int    `json:"sort_order" gorm:"column:sort_order"`
+	TaskName    string `json:"task_name" gorm:"column:task_name"`
+	Description string `json:"description" gorm:"column:description"`
+	Due         string `json:"due" gorm:"column:due_offset"`
+	Priority    int    `json:"priority" gorm:"column:priority"`
+}
+
+func (t *TemplateItem) TableName() string {
+	return "template_items"
+}
+
+type TemplateItemRequest struct {
+	TaskName    string `json:"task_name" validate:"required,max=255"`
+	Description string `json:"description" validate:"required"`
+	Due         string `json:"due" validate:"required,max=16"`
+	Priority    int    `json:"priority" validate:"min=1"`
+}
+
+type TemplateRequest struct {
+	Name        string                `json:"name" validate:"required,max=100"`
+	Description string                `json:"description" validate:"max=1000"`
+	Items       []TemplateItemRequest `json:"items" validate:"required,min=1,max=100,dive"`
+}
+
+// TemplateInstantiateRequest creates the todolists of a template for the user UserID with due dates counted from
+// StartDate, so a template can be run for a new hire from the manager's account. Every todolist is assigned to
+// AssigneeID when set.
+type TemplateInstantiateRequest struct {
+	UserID     *uuid.UUID `json:"user_id" validate:"required"`
+	StartDate  *Date      `json:"start_date" validate:"required"`
+	AssigneeID *uuid.UUID `json:"assignee_id"`
+	ProjectID  *int       `json:"project_id"`
+}
+
+type TemplateItemResponse struct {
+	TaskName    string `json:"task_name"`
+	Description string `json:"description"`
+	Due         string `json:"due"`
+	Priority    int    `json:"priority"`
+}
+
+type TemplateResponse struct {
+	TemplateID  int                    `json:"template_id"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Items       []TemplateItemResponse `json:"items"`
+	CreatedAt   time.Time              `json:"created_at"`
+	UpdatedAt   time.Time              `json:"updated_at"`
+}
+
+var dueOffsetPattern = regexp.MustCompile(`^([+-]?)(\d{1,4})([dw])$`)
+
+// ParseDueOffset returns the number of days of an offset such as "+3d", "2w" or "-1d".
+func ParseDueOffset(due string) (int, error) {
+	match := dueOffsetPattern.FindStringSubmatch(due)
+	if match == nil {
+		return 0, fmt.Errorf("due %q must be an offset such as +3d or +2w", due)
+	}
+	days, _ := strconv.Atoi(match[2])
+	if match[3] == "w" {
+		days *= 7
+	}
+	if match[1] == "-" {
+		days = -days
+	}
+	return days, nil
+}
+
+func (t *TemplateRequest) ToTemplate(userID uuid.UUID) *Template {
+	template := &Template{
+		UserID:      userID,
+		Name:        t.Name,
+		Description: t.Description,
+	}
+	for i, item := range t.Items {
+		template.Items = append(template.Items, TemplateItem{
+			SortOrder:   i,
+			TaskName:    item.TaskName,
+			Description: item.Description,
+			Due:         item.Due,
+			Priority:    item.Priority,
+		})
+	}
+	return template
+}
+
+// ToTodoListRequests turns the items into todolists due their offset after start, they all start in the initial status.
+func (t *Template) ToTodoListRequests(start time.Time, request TemplateInstantiateRequest) (TodoListRequests, error) {
+	var requests TodoListRequests
+	for _, item := range t.Items {
+		days, err := ParseDueOffset(item.Due)
+		if err != nil {
+			return nil, err
+		}
+		due := start.AddDate(0, 0, days)
+		requests = append(requests, TodoListRequest{
+			TaskName:    item.TaskName,
+			Description: item.Description,
+			DueDate:     &Date{Year: due.Year(), Month: int(due.Month()), Day: due.Day()},
+			Priority:    item.Priority,
+			ProjectID:   request.ProjectID,
+			AssigneeID:  request.AssigneeID,
+		})
+	}
+	return requests, nil
+}
+
+func (t *Template) ToTemplateResponse() *TemplateResponse {
+	response := &TemplateResponse{
+		TemplateID:  t.TemplateID,
+		Name:        t.Name,
+		Description: t.Description,
+		Items:       []TemplateItemResponse{},
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+	}
+	for _, item := range t.Items {
+		response.Items = append(response.Items, TemplateItemResponse{
+			TaskName:    item.TaskName,
+			Description: item.Description,
+			Due:         item.Due,
+			Priority:    item.Priority,
+		})
+	}
+	return response
+}
@@ -0,0 +1,184 @@
+package model
+
+import (
+	"bytes"
+	"encoding/csv"
+	"github.com/google/uuid"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// TimeEntry is time spent by a user on a todolist, a running timer has no EndedAt yet.
+type TimeEntry struct {
+	EntryID   int        `json:"entry_id" gorm:"primaryKey;column:entry_id"`
+	TaskID    int        `json:"task_id" gorm:"column:task_id"`
+	UserID    uuid.UUID  `json:"user_id" gorm:"column:user_id"`
+	StartedAt time.Time  `json:"started_at" gorm:"column:started_at"`
+	EndedAt   *time.Time `json:"ended_at" gorm:"column:ended_at"`
+	Note      string     `json:"note" gorm:"column:note"`
+	// ProjectID is the project of the todolist, only loaded for reports
+	ProjectID *int      `json:"project_id" gorm:"->;column:project_id"`
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (t *TimeEntry) TableName() string {
+	return "time_entries"
+}
+
+// TimeEntryRequest logs time by hand, EndedAt must come after StartedAt.
+type TimeEntryRequest struct {
+	StartedAt time.Time `json:"started_at" validate:"required"`
+	EndedAt   time.Time `json:"ended_at" validate:"required,gtfield=StartedAt"`
+	Note      string    `json:"note" validate:"max=255"`
+}
+
+type TimerStartRequest struct {
+	Note string `json:"note" validate:"max=255"`
+}
+
+type TodoListEstimateRequest struct {
+	EstimateMinutes *int `json:"estimate_minutes" validate:"omitempty,min=0"`
+}
+
+type TimeEntryResponse struct {
+	EntryID   int        `json:"entry_id"`
+	TaskID    int        `json:"task_id"`
+	UserID    uuid.UUID  `json:"user_id"`
+	StartedAt time.Time  `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at"`
+	Running   bool       `json:"running"`
+	Minutes   int64      `json:"minutes"`
+	Note      string     `json:"note"`
+}
+
+// TimeSummary compares the estimate of a todolist with the time logged on it, running timers count up to now.
+type TimeSummary struct {
+	TaskID           int                 `json:"task_id"`
+	EstimateMinutes  *int                `json:"estimate_minutes"`
+	ActualMinutes    int64               `json:"actual_minutes"`
+	RemainingMinutes *int64              `json:"remaining_minutes"`
+	Entries          []TimeEntryResponse `json:"entries"`
+}
+
+// TimeReportRow is the time logged in one period, by project for a user report and by user for a project report.
+type TimeReportRow struct {
+	Period    string     `json:"period"`
+	ProjectID *int       `json:"project_id,omitempty"`
+	UserID    *uuid.UUID `json:"user_id,omitempty"`
+	Minutes   int64      `json:"minutes"`
+}
+
+// Minutes is the length of the entry in whole minutes, a running entry is measured up to now.
+func (t *TimeEntry) Minutes(now time.Time) int64 {
+	end := now
+	if t.EndedAt != nil {
+		end = *t.EndedAt
+	}
+	return int64(end.Sub(t.StartedAt) / time.Minute)
+}
+
+func (t *TimeEntryRequest) ToTimeEntry(taskID int, userID uuid.UUID) *TimeEntry {
+	endedAt := t.EndedAt
+	return &TimeEntry{
+		TaskID:    taskID,
+		UserID:    userID,
+		StartedAt: t.StartedAt,
+		EndedAt:   &endedAt,
+		Note:      t.Note,
+	}
+}
+
+func (t *TimeEntry) ToTimeEntryResponse(now time.Time) *TimeEntryResponse {
+	return &TimeEntryResponse{
+		EntryID:   t.EntryID,
+		TaskID:    t.TaskID,
+		UserID:    t.UserID,
+		StartedAt: t.StartedAt,
+		EndedAt:   t.EndedAt,
+		Running:   t.EndedAt == nil,
+		Minutes:   t.Minutes(now),
+		Note:      t.Note,
+	}
+}
+
+// PeriodStart truncates at to its day, or to the Monday of its week when period is "week".
+func PeriodStart(at time.Time, period string) time.Time {
+	day := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, at.Location())
+	if period == "week" {
+		day = day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	}
+	return day
+}
+
+// BuildTimeReport sums finished entries per period of their start, per user when byUser is set and per project
+// otherwise. Rows come ordered by period, then project or user.
+func BuildTimeReport(entries TimeEntries, period string, byUser bool) TimeReportRows {
+	type key struct {
+		period  string
+		project int
+		user    uuid.UUID
+	}
+	totals := make(map[key]*TimeReportRow)
+	var keys []key
+	for _, entry := range entries {
+		if entry.EndedAt == nil {
+			continue
+		}
+		k := key{period: PeriodStart(entry.StartedAt, period).Format(time.DateOnly)}
+		if byUser {
+			k.user = entry.UserID
+		} else if entry.ProjectID != nil {
+			k.project = *entry.ProjectID
+		}
+		row, ok := totals[k]
+		if !ok {
+			row = &TimeReportRow{Period: k.period}
+			if byUser {
+				user := entry.UserID
+				row.UserID = &user
+			} else {
+				row.ProjectID = entry.ProjectID
+			}
+			totals[k] = row
+			keys = append(keys, k)
+		}
+		row.Minutes += entry.Minutes(*entry.EndedAt)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].period != keys[j].period {
+			return keys[i].period < keys[j].period
+		}
+		if keys[i].project != keys[j].project {
+			return keys[i].project < keys[j].project
+		}
+		return keys[i].user.String() < keys[j].user.String()
+	})
+	rows := make(TimeReportRows, 0, len(keys))
+	for _, k := range keys {
+		rows = append(rows, *totals[k])
+	}
+	return rows
+}
+
+// CSV writes the report with a header line, an empty project or user cell stands for none.
+func (t TimeReportRows) CSV() ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	records := [][]string{{"period", "project_id", "user_id", "minutes"}}
+	for _, row := range t {
+		project, user := "", ""
+		if row.ProjectID != nil {
+			project = strconv.Itoa(*row.ProjectID)
+		}
+		if row.UserID != nil {
+			user = row.UserID.String()
+		}
+		records = append(records, []string{row.Period, project, user, strconv.FormatInt(row.Minutes, 10)})
+	}
+	if err := writer.WriteAll(records); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
@@ -2,20 +2,34 @@ package model
 
 import (
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 	"time"
 )
 
 type TodoList struct {
-	TaskID      int        `json:"task_id" gorm:"primaryKey;column:task_id"`
-	UserID      uuid.UUID  `json:"user_id" gorm:"column:user_id"`
-	TaskName    string     `json:"task_name" gorm:"column:task_name"`
-	Description string     `json:"description" gorm:"column:description"`
-	DueDate     *time.Time `json:"due_date" gorm:"column:due_date"`
-	Priority    int        `json:"priority" gorm:"column:priority"`
-	Completed   bool       `json:"completed" gorm:"column:completed"`
-	CreatedAt   time.Time  `json:"created_at" gorm:"column:created_at"`
-	UpdatedAt   time.Time  `json:"updated_at" gorm:"column:updated_at"`
-	User        User       `gorm:"foreignKey:user_id;references:id" json:"user"`
+	TaskID          int            `json:"task_id" gorm:"primaryKey;column:task_id"`
+	UserID          uuid.UUID      `json:"user_id" gorm:"column:user_id"`
+	ProjectID       *int           `json:"project_id" gorm:"column:project_id"`
+	ColumnID        *int           `json:"column_id" gorm:"column:column_id"`
+	AssigneeID      *uuid.UUID     `json:"assignee_id" gorm:"column:assignee_id"`
+	TaskName        string         `json:"task_name" gorm:"column:task_name"`
+	Description     string         `json:"description" gorm:"column:description"`
+	DueDate         *time.Time     `json:"due_date" gorm:"column:due_date"`
+	DueTimed        bool           `json:"due_timed" gorm:"column:due_timed"`
+	DueTimezone     string         `json:"due_timezone" gorm:"column:due_timezone"`
+	Priority        int            `json:"priority" gorm:"column:priority"`
+	EstimateMinutes *int           `json:"estimate_minutes" gorm:"column:estimate_minutes"`
+	Recurrence      string         `json:"recurrence" gorm:"column:recurrence"`
+	Completed       bool           `json:"completed" gorm:"column:completed"`
+	Status          TaskStatus     `json:"status" gorm:"column:status;default:todo"`
+	Position        string         `json:"position" gorm:"column:position"`
+	StartedAt       *time.Time     `json:"started_at" gorm:"column:started_at"`
+	CompletedAt     *time.Time     `json:"completed_at" gorm:"column:completed_at"`
+	CreatedAt       time.Time      `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt       time.Time      `json:"updated_at" gorm:"column:updated_at"`
+	DeletedAt       gorm.DeletedAt `json:"deleted_at" gorm:"column:deleted_at"`
+	Version         int            `json:"version" gorm:"column:version;<-:false"`
+	User            User           `gorm:"foreignKey:user_id;references:id" json:"user"`
 }
 
 func (t *TodoList) TableName() string {
@@ -23,44 +37,142 @@ func (t *TodoList) TableName() string {
 }
 
 type TodoListResponse struct {
-	TaskID      int        `json:"task_id" gorm:"primaryKey;column:task_id"`
-	TaskName    string     `json:"task_name" gorm:"column:task_name"`
-	Description string     `json:"description" gorm:"column:description"`
-	DueDate     *time.Time `json:"due_date" gorm:"column:due_date"`
-	Priority    int        `json:"priority" gorm:"column:priority"`
-	Completed   bool       `json:"completed" gorm:"column:completed"`
-	CreatedAt   time.Time  `json:"created_at" gorm:"column:created_at"`
-	UpdatedAt   time.Time  `json:"updated_at" gorm:"column:updated_at"`
+	TaskID          int        `json:"task_id" gorm:"primaryKey;column:task_id"`
+	ProjectID       *int       `json:"project_id" gorm:"column:project_id"`
+	ColumnID        *int       `json:"column_id" gorm:"column:column_id"`
+	AssigneeID      *uuid.UUID `json:"assignee_id" gorm:"column:assignee_id"`
+	TaskName        string     `json:"task_name" gorm:"column:task_name"`
+	Description     string     `json:"description" gorm:"column:description"`
+	DueDate         *time.Time `json:"due_date" gorm:"column:due_date"`
+	DueTimed        bool       `json:"due_timed" gorm:"column:due_timed"`
+	DueTimezone     string     `json:"due_timezone" gorm:"column:due_timezone"`
+	Priority        int        `json:"priority" gorm:"column:priority"`
+	EstimateMinutes *int       `json:"estimate_minutes" gorm:"column:estimate_minutes"`
+	Recurrence      string     `json:"recurrence" gorm:"column:recurrence"`
+	Completed       bool       `json:"completed" gorm:"column:completed"`
+	Status          TaskStatus `json:"status" gorm:"column:status"`
+	Position        string     `json:"position" gorm:"column:position"`
+	StartedAt       *time.Time `json:"started_at" gorm:"column:started_at"`
+	CompletedAt     *time.Time `json:"completed_at" gorm:"column:completed_at"`
+	CreatedAt       time.Time  `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt       time.Time  `json:"updated_at" gorm:"column:updated_at"`
+	DeletedAt       *time.Time `json:"deleted_at,omitempty" gorm:"column:deleted_at"`
+	Version         int        `json:"version" gorm:"column:version"`
 }
+
+// TodoListSearchHit is a todolist matched by a full-text search with its rank and highlighted text.
+type TodoListSearchHit struct {
+	TodoList
+	Rank                 float64 `gorm:"column:rank"`
+	TaskNameHighlight    string  `gorm:"column:task_name_highlight"`
+	DescriptionHighlight string  `gorm:"column:description_highlight"`
+}
+
+type TodoListHighlight struct {
+	TaskName    string `json:"task_name"`
+	Description string `json:"description"`
+}
+
+type TodoListSearchResponse struct {
+	TodoListResponse
+	Rank      float64           `json:"rank"`
+	Highlight TodoListHighlight `json:"highlight"`
+}
+
 type TodoListRequest struct {
-	TaskName    string `json:"task_name" gorm:"column:task_name" validate:"required"`
-	Description string `json:"description" gorm:"column:description" validate:"required"`
-	DueDate     *Date  `json:"due_date" gorm:"column:due_date" validate:"required"`
-	Priority    int    `json:"priority" gorm:"column:priority" validate:"min=1"`
-	Completed   bool   `json:"completed" gorm:"column:completed" validate:"eq=true|eq=false"`
+	TaskName    string     `json:"task_name" gorm:"column:task_name" validate:"required"`
+	Description string     `json:"description" gorm:"column:description" validate:"required"`
+	DueDate     *Date      `json:"due_date" gorm:"column:due_date" validate:"required"`
+	Priority    int        `json:"priority" gorm:"column:priority" validate:"min=1"`
+	Completed   bool       `json:"completed" gorm:"column:completed" validate:"eq=true|eq=false"`
+	Status      TaskStatus `json:"status" gorm:"column:status"`
+	ProjectID   *int       `json:"project_id" gorm:"column:project_id"`
+	// Recurrence is an iCalendar RRULE such as FREQ=WEEKLY;BYDAY=MO, shown in the calendar feed
+	Recurrence string `json:"recurrence" gorm:"column:recurrence" validate:"omitempty,max=255"`
+	// AssigneeID is only read on create, reassigning goes through TodoListAssignRequest
+	AssigneeID *uuid.UUID `json:"assignee_id" gorm:"column:assignee_id"`
+	// DueTimezone is the IANA zone a timed due date is read and shown in, a date in RFC 3339 keeps its own offset
+	DueTimezone string `json:"due_timezone" gorm:"column:due_timezone" validate:"omitempty,max=64,timezone"`
+}
+
+type TodoListStatusRequest struct {
+	Status TaskStatus `json:"status" validate:"required"`
+}
+
+// TodoListUndoRequest restores the todolists removed by the delete call that returned Token.
+type TodoListUndoRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// TodoListUndoResponse is returned by delete calls, Token undoes the delete until ExpiresAt.
+type TodoListUndoResponse struct {
+	Token     string    `json:"undo_token"`
+	ExpiresAt time.Time `json:"undo_expires_at"`
+}
+
+// TodoListMoveRequest places a todolist right after AfterID or right before BeforeID, both in the same list.
+type TodoListMoveRequest struct {
+	AfterID  *int `json:"after_id" validate:"required_without=BeforeID,excluded_with=BeforeID"`
+	BeforeID *int `json:"before_id" validate:"required_without=AfterID"`
+}
+
+type TodoListStatusGroup struct {
+	Status   TaskStatus        `json:"status"`
+	Count    int64             `json:"count"`
+	TodoList TodoListResponses `json:"todolist"`
 }
 
 func (t *TodoListRequest) ToTodoList(user_id uuid.UUID) *TodoList {
-	dateTime := time.Date(t.DueDate.Year, time.Month(t.DueDate.Month), t.DueDate.Day, 0, 0, 0, 0, time.UTC)
+	dueDate, timed := t.DueDate.Due(t.DueTimezone)
+	timezone := ""
+	if timed {
+		timezone = t.DueTimezone
+	}
 	return &TodoList{
 		UserID:      user_id,
+		ProjectID:   t.ProjectID,
+		AssigneeID:  t.AssigneeID,
 		TaskName:    t.TaskName,
 		Description: t.Description,
-		DueDate:     &dateTime,
+		DueDate:     &dueDate,
+		DueTimed:    timed,
+		DueTimezone: timezone,
 		Priority:    t.Priority,
+		Recurrence:  t.Recurrence,
 		Completed:   t.Completed,
+		Status:      t.Status,
 	}
 }
 
 func (t *TodoList) ToTodoListResponse() *TodoListResponse {
 	return &TodoListResponse{
-		TaskID:      t.TaskID,
-		TaskName:    t.TaskName,
-		Description: t.Description,
-		DueDate:     t.DueDate,
-		Priority:    t.Priority,
-		Completed:   t.Completed,
-		CreatedAt:   t.CreatedAt,
-		UpdatedAt:   t.UpdatedAt,
+		TaskID:          t.TaskID,
+		ProjectID:       t.ProjectID,
+		ColumnID:        t.ColumnID,
+		AssigneeID:      t.AssigneeID,
+		TaskName:        t.TaskName,
+		Description:     t.Description,
+		DueDate:         t.LocalDueDate(),
+		DueTimed:        t.DueTimed,
+		DueTimezone:     t.DueTimezone,
+		Priority:        t.Priority,
+		EstimateMinutes: t.EstimateMinutes,
+		Recurrence:      t.Recurrence,
+		Completed:       t.Completed,
+		Status:          t.Status,
+		Position:        t.Position,
+		StartedAt:       t.StartedAt,
+		CompletedAt:     t.CompletedAt,
+		CreatedAt:       t.CreatedAt,
+		UpdatedAt:       t.UpdatedAt,
+		DeletedAt:       deletedAt(t.DeletedAt),
+		Version:         t.Version,
+	}
+}
+
+func deletedAt(deleted gorm.DeletedAt) *time.Time {
+	if !deleted.Valid {
+		return nil
 	}
+	return &deleted.Time
 }
@@ -2,7 +2,9 @@ package model
 
 import (
 	"context"
+	"encoding/json"
 	"github.com/google/uuid"
+	"time"
 )
 
 type Operation func(ctx context.Context) error
@@ -10,13 +12,50 @@ type Date struct {
 	Year  int `json:"year" validate:"required,gte=1900,lte=2100"`
 	Month int `json:"month" validate:"required,gte=1,lte=12"`
 	Day   int `json:"day" validate:"required,gte=1,lte=31"`
+	// Time is the 15:04 time of day of a timed due date, empty for a whole day
+	Time string `json:"time,omitempty" validate:"omitempty,datetime=15:04"`
+	// offset is the UTC offset in seconds of a date given in RFC 3339
+	offset *int
 }
 type Users []User
 type UsersResponses []UserResponse
 type UsersRequests []UserRequest
 type TodoLists []TodoList
+type TodoListSearchHits []TodoListSearchHit
+type TodoListSearchResponses []TodoListSearchResponse
 type TodoListRequests []TodoListRequest
 type TodoListResponses []TodoListResponse
+type Comments []Comment
+type CommentResponses []CommentResponse
+type CommentRevisions []CommentRevision
+type TaskActivities []TaskActivity
+type ActivityResponses []ActivityResponse
+type Notifications []Notification
+type Assignments []Assignment
+type TaskDependencies []TaskDependency
+type Attachments []Attachment
+type Projects []Project
+type ProjectResponses []ProjectResponse
+type Shares []Share
+type AttachmentResponses []AttachmentResponse
+type TodoListTags []TodoListTag
+type SavedSearches []SavedSearch
+type SavedSearchResponses []SavedSearchResponse
+type Views []View
+type ViewResponses []ViewResponse
+type ViewShares []ViewShare
+type BoardColumns []BoardColumn
+type Templates []Template
+type TemplateItems []TemplateItem
+type TemplateResponses []TemplateResponse
+type TimeEntries []TimeEntry
+type TimeReportRows []TimeReportRow
+type TodoListRevisions []TodoListRevision
+type TodoListGroups []TodoListGroup
+type CalDAVResources []CalDAVResource
+type StatisticsCounts []StatisticsCount
+type StatisticsPeriods []StatisticsPeriod
+type PriorityCounts []PriorityCount
 
 type TodoListRequestsValidation struct {
 	TodoList []TodoListRequest `json:"todo_list" validate:"required,dive"`
@@ -53,3 +92,99 @@ func (t TodoLists) ToTodoListResponses() TodoListResponses {
 	}
 	return todolists
 }
+
+func (t TodoListSearchHits) ToTodoListSearchResponses() TodoListSearchResponses {
+	var responses TodoListSearchResponses
+	for _, hit := range t {
+		responses = append(responses, TodoListSearchResponse{
+			TodoListResponse: *hit.TodoList.ToTodoListResponse(),
+			Rank:             hit.Rank,
+			Highlight:        TodoListHighlight{TaskName: hit.TaskNameHighlight, Description: hit.DescriptionHighlight},
+		})
+	}
+	return responses
+}
+
+// SelectFields keeps only the given json keys of every todolist, all of them when fields is empty.
+func (t TodoListResponses) SelectFields(fields []string) ([]map[string]interface{}, error) {
+	selected := make([]map[string]interface{}, 0, len(t))
+	for _, todolist := range t {
+		raw, err := json.Marshal(todolist)
+		if err != nil {
+			return nil, err
+		}
+		var full map[string]interface{}
+		if err = json.Unmarshal(raw, &full); err != nil {
+			return nil, err
+		}
+		if len(fields) == 0 {
+			selected = append(selected, full)
+			continue
+		}
+		item := make(map[string]interface{}, len(fields))
+		for _, field := range fields {
+			item[field] = full[field]
+		}
+		selected = append(selected, item)
+	}
+	return selected, nil
+}
+
+func (c Comments) ToCommentResponses() CommentResponses {
+	var comments CommentResponses
+	for _, comment := range c {
+		comments = append(comments, *comment.ToCommentResponse())
+	}
+	return comments
+}
+func (a Attachments) ToAttachmentResponses() AttachmentResponses {
+	var attachments AttachmentResponses
+	for _, attachment := range a {
+		attachments = append(attachments, *attachment.ToAttachmentResponse())
+	}
+	return attachments
+}
+func (t TodoListTags) ToTags() []string {
+	tags := []string{}
+	for _, tag := range t {
+		tags = append(tags, tag.Tag)
+	}
+	return tags
+}
+func (s SavedSearches) ToSavedSearchResponses() SavedSearchResponses {
+	var searches SavedSearchResponses
+	for _, search := range s {
+		searches = append(searches, *search.ToSavedSearchResponse())
+	}
+	return searches
+}
+func (t TimeEntries) ToTimeEntryResponses(now time.Time) []TimeEntryResponse {
+	entries := []TimeEntryResponse{}
+	for _, entry := range t {
+		entries = append(entries, *entry.ToTimeEntryResponse(now))
+	}
+	return entries
+}
+
+func (t Templates) ToTemplateResponses() TemplateResponses {
+	var templates TemplateResponses
+	for _, template := range t {
+		templates = append(templates, *template.ToTemplateResponse())
+	}
+	return templates
+}
+
+func (v Views) ToViewResponses() ViewResponses {
+	var views ViewResponses
+	for _, view := range v {
+		views = append(views, *view.ToViewResponse())
+	}
+	return views
+}
+func (p Projects) ToProjectResponses() ProjectResponses {
+	var projects ProjectResponses
+	for _, project := range p {
+		projects = append(projects, *project.ToProjectResponse())
+	}
+	return projects
+}
@@ -42,10 +42,13 @@ type User struct {
 	Password     string         `json:"password" gorm:"column:password"`
 	RefreshToken sql.NullString `json:"refreshToken" gorm:"column:refresh_token"`
 	Roles        UserRole       `gorm:"type:user_role;default:BASIC" json:"roles"`
-	CreatedAt    time.Time      `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
-	UpdatedAt    time.Time      `json:"updatedAt" gorm:"column:updated_at;autoCreateTime;autoUpdateTime"`
-	DeletedAt    gorm.DeletedAt `json:"deletedAt" gorm:"column:deleted_at"`
-	TodoLists    TodoLists      `json:"todo_lists" gorm:"foreignKey:user_id;references:id"`
+	// SearchLanguage is the text search configuration the todolists of the user are indexed with
+	SearchLanguage string         `json:"searchLanguage" gorm:"column:search_language;default:simple"`
+	CreatedAt      time.Time      `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt      time.Time      `json:"updatedAt" gorm:"column:updated_at;autoCreateTime;autoUpdateTime"`
+	DeletedAt      gorm.DeletedAt `json:"deletedAt" gorm:"column:deleted_at"`
+	Version        int            `json:"version" gorm:"column:version;<-:false"`
+	TodoLists      TodoLists      `json:"todo_lists" gorm:"foreignKey:user_id;references:id"`
 }
 
 func (u *User) TableName() string {
@@ -62,12 +65,14 @@ func (u *User) BeforeCreate(tx *gorm.DB) (err error) {
 }
 
 type UserResponse struct {
-	ID        uuid.UUID      `json:"id" gorm:"primaryKey;column:id"`
-	Username  string         `json:"username" gorm:"column:username"`
-	Email     string         `json:"email" gorm:"column:email;unique"`
-	CreatedAt time.Time      `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
-	UpdatedAt time.Time      `json:"updatedAt" gorm:"column:updated_at;autoCreateTime;autoUpdateTime"`
-	DeletedAt gorm.DeletedAt `json:"deletedAt" gorm:"column:deleted_at"`
+	ID             uuid.UUID      `json:"id" gorm:"primaryKey;column:id"`
+	Username       string         `json:"username" gorm:"column:username"`
+	Email          string         `json:"email" gorm:"column:email;unique"`
+	SearchLanguage string         `json:"searchLanguage" gorm:"column:search_language"`
+	CreatedAt      time.Time      `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt      time.Time      `json:"updatedAt" gorm:"column:updated_at;autoCreateTime;autoUpdateTime"`
+	DeletedAt      gorm.DeletedAt `json:"deletedAt" gorm:"column:deleted_at"`
+	Version        int            `json:"version" gorm:"column:version"`
 }
 
 type UserRequest struct {
@@ -79,19 +84,22 @@ type UserRequest struct {
 }
 
 type UserLoginUpdateRequest struct {
-	Username string `json:"username" gorm:"column:username" validate:"required,min=5,max=100"`
-	Email    string `json:"email" gorm:"column:email;unique" validate:"required,email,max=100"`
-	Password string `json:"password" gorm:"column:password" validate:"required,min=8"`
+	Username       string `json:"username" gorm:"column:username" validate:"required,min=5,max=100"`
+	Email          string `json:"email" gorm:"column:email;unique" validate:"required,email,max=100"`
+	Password       string `json:"password" gorm:"column:password" validate:"required,min=8"`
+	SearchLanguage string `json:"searchLanguage" gorm:"column:search_language" validate:"omitempty,max=63"`
 }
 
 func (u *User) ToUserResponse() *UserResponse {
 	return &UserResponse{
-		ID:        u.ID,
-		Username:  u.Username,
-		Email:     u.Email,
-		CreatedAt: u.CreatedAt,
-		UpdatedAt: u.UpdatedAt,
-		DeletedAt: u.DeletedAt,
+		ID:             u.ID,
+		Username:       u.Username,
+		Email:          u.Email,
+		SearchLanguage: u.SearchLanguage,
+		CreatedAt:      u.CreatedAt,
+		UpdatedAt:      u.UpdatedAt,
+		DeletedAt:      u.DeletedAt,
+		Version:        u.Version,
 	}
 }
 
@@ -107,8 +115,9 @@ func (u *UserRequest) ToUser() *User {
 
 func (u *UserLoginUpdateRequest) ToUser() *User {
 	return &User{
-		Username: u.Username,
-		Email:    u.Email,
-		Password: u.Password,
+		Username:       u.Username,
+		Email:          u.Email,
+		Password:       u.Password,
+		SearchLanguage: u.SearchLanguage,
 	}
 }
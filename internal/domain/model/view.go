@@ -0,0 +1,84 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// View is a named todolist listing: a query of the query language, a sort and an optional grouping.
+// Sharing a view shares its definition, it always runs against the todolists of the user running it.
+type View struct {
+	ViewID    int       `json:"view_id" gorm:"primaryKey;column:view_id"`
+	UserID    uuid.UUID `json:"user_id" gorm:"column:user_id"`
+	Name      string    `json:"name" gorm:"column:name"`
+	Query     string    `json:"query" gorm:"column:query"`
+	Sort      string    `json:"sort" gorm:"column:sort"`
+	GroupBy   string    `json:"group_by" gorm:"column:group_by"`
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (v *View) TableName() string {
+	return "views"
+}
+
+type ViewShare struct {
+	ViewID    int       `json:"view_id" gorm:"primaryKey;column:view_id"`
+	UserID    uuid.UUID `json:"user_id" gorm:"primaryKey;column:user_id"`
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at"`
+}
+
+func (v *ViewShare) TableName() string {
+	return "view_shares"
+}
+
+type ViewRequest struct {
+	Name    string `json:"name" validate:"required,max=100"`
+	Query   string `json:"query" validate:"max=512"`
+	Sort    string `json:"sort" validate:"max=255"`
+	GroupBy string `json:"group_by" validate:"omitempty,oneof=status priority completed"`
+}
+
+type ViewShareRequest struct {
+	UserID uuid.UUID `json:"user_id" validate:"required"`
+}
+
+type ViewResponse struct {
+	ViewID    int       `json:"view_id"`
+	OwnerID   uuid.UUID `json:"owner_id"`
+	Name      string    `json:"name"`
+	Query     string    `json:"query"`
+	Sort      string    `json:"sort"`
+	GroupBy   string    `json:"group_by"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TodoListGroup is one group of a grouped view with the number of todolists in it over all pages.
+type TodoListGroup struct {
+	Key   string `json:"key" gorm:"column:key"`
+	Count int64  `json:"count" gorm:"column:count"`
+}
+
+func (v *ViewRequest) ToView(userID uuid.UUID) *View {
+	return &View{
+		UserID:  userID,
+		Name:    v.Name,
+		Query:   v.Query,
+		Sort:    v.Sort,
+		GroupBy: v.GroupBy,
+	}
+}
+
+func (v *View) ToViewResponse() *ViewResponse {
+	return &ViewResponse{
+		ViewID:    v.ViewID,
+		OwnerID:   v.UserID,
+		Name:      v.Name,
+		Query:     v.Query,
+		Sort:      v.Sort,
+		GroupBy:   v.GroupBy,
+		CreatedAt: v.CreatedAt,
+		UpdatedAt: v.UpdatedAt,
+	}
+}
@@ -0,0 +1,86 @@
+package web
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"go_gin/internal/config"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidCursor = errors.New("cursor is invalid or was issued for another sort")
+
+// Cursor points at the row a keyset page starts after, Values holds the sort keys of that row followed by its ID.
+// A cursor without Values asks for the first page.
+type Cursor struct {
+	Values []string `json:"v"`
+	Prev   bool     `json:"p,omitempty"`
+	Sort   string   `json:"s,omitempty"`
+}
+
+func signCursor(payload string) string {
+	mac := hmac.New(sha256.New, []byte(config.Other.SecretKey))
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// EncodeCursor turns cursor into an opaque token signed with the server secret so clients cannot forge one.
+func EncodeCursor(cursor *Cursor) string {
+	if cursor == nil {
+		return ""
+	}
+	raw, _ := json.Marshal(cursor)
+	payload := base64.RawURLEncoding.EncodeToString(raw)
+	return payload + "." + signCursor(payload)
+}
+
+// DecodeCursor checks the signature of token and that it was issued for the same sort.
+func DecodeCursor(token string, sort string) (*Cursor, error) {
+	payload, signature, found := strings.Cut(token, ".")
+	if !found || !hmac.Equal([]byte(signature), []byte(signCursor(payload))) {
+		return nil, ErrInvalidCursor
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var cursor Cursor
+	if err := json.Unmarshal(raw, &cursor); err != nil || cursor.Sort != sort || len(cursor.Values) == 0 {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+// parsePage reads the page size and the cursor of a listing, an empty cursor with paginate=cursor starts at the first page.
+func parsePage(limit string, token string, paginate string, sort string) (int, *Cursor, error) {
+	size := config.Other.Limit
+	if limit != "" {
+		parsed, err := strconv.Atoi(limit)
+		if err != nil || parsed < 1 {
+			return 0, nil, errors.New("limit must be a positive number")
+		}
+		size = parsed
+	}
+	if size > MaxLimit() {
+		size = MaxLimit()
+	}
+	if token != "" {
+		cursor, err := DecodeCursor(token, sort)
+		return size, cursor, err
+	}
+	if paginate == "cursor" {
+		return size, &Cursor{Sort: sort}, nil
+	}
+	return size, nil, nil
+}
+
+// MaxLimit is the biggest page a client may ask for.
+func MaxLimit() int {
+	if config.Other.MaxLimit > 0 {
+		return config.Other.MaxLimit
+	}
+	return 100
+}
@@ -0,0 +1,37 @@
+package web
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ETag is the strong entity tag of a resource at the given version.
+func ETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// MatchETag tells whether one of the entity tags listed in an If-Match header names the given version. "*" matches
+// any version and, the comparison being strong, a weak tag matches none.
+func MatchETag(header string, version int) bool {
+	return matchETag(header, version, false)
+}
+
+// MatchWeakETag tells whether one of the entity tags listed in an If-None-Match header names the given version. "*"
+// matches any version and weak tags compare like strong ones.
+func MatchWeakETag(header string, version int) bool {
+	return matchETag(header, version, true)
+}
+
+func matchETag(header string, version int, weak bool) bool {
+	etag := ETag(version)
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if weak {
+			tag = strings.TrimPrefix(tag, "W/")
+		}
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+	return false
+}
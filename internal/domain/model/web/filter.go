@@ -0,0 +1,126 @@
+package web
+
+import (
+	"fmt"
+	"go_gin/pkg/querylang"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TodoListSortColumns maps the sort keys accepted by todolist listings to their column.
+var TodoListSortColumns = map[string]string{
+	"task_id":    "task_id",
+	"task_name":  "task_name",
+	"due_date":   "due_date",
+	"priority":   "priority",
+	"status":     "status",
+	"completed":  "completed",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+	"position":   "position",
+}
+
+// TodoListFields are the json keys of a todolist response that can be picked with fields.
+var TodoListFields = []string{
+	"task_id", "project_id", "column_id", "assignee_id", "task_name", "description", "due_date", "due_timed", "due_timezone",
+	"priority", "estimate_minutes", "recurrence", "completed", "status", "position", "started_at", "completed_at", "created_at",
+	"updated_at",
+}
+
+// TodoListImportFields are the fields the columns of an import can be mapped to.
+var TodoListImportFields = []string{
+	"task_name", "description", "due_date", "due_timed", "due_timezone", "priority", "completed", "status", "project_id", "recurrence",
+}
+
+// TodoListQueryFields are the fields of the todolist query language, see querylang.Parse.
+var TodoListQueryFields = map[string]querylang.Kind{
+	"priority":  querylang.KindInt,
+	"project":   querylang.KindInt,
+	"due":       querylang.KindDate,
+	"created":   querylang.KindDate,
+	"updated":   querylang.KindDate,
+	"status":    querylang.KindString,
+	"tag":       querylang.KindString,
+	"completed": querylang.KindBool,
+	"overdue":   querylang.KindBool,
+}
+
+type SortKey struct {
+	Column string
+	Desc   bool
+}
+
+// ParseSort reads a comma separated list of keys, a leading "-" sorts that key descending.
+func ParseSort(sort string, columns map[string]string) ([]SortKey, error) {
+	var keys []SortKey
+	seen := map[string]bool{}
+	for _, key := range strings.Split(sort, ",") {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+		desc := strings.HasPrefix(key, "-")
+		column, ok := columns[strings.TrimPrefix(key, "-")]
+		if !ok {
+			return nil, fmt.Errorf("sort key %s is not supported", key)
+		}
+		if seen[column] {
+			return nil, fmt.Errorf("sort key %s is given twice", key)
+		}
+		seen[column] = true
+		keys = append(keys, SortKey{Column: column, Desc: desc})
+	}
+	return keys, nil
+}
+
+// ParseFields reads a comma separated list of fields, each one must be in allowed.
+func ParseFields(fields string, allowed []string) ([]string, error) {
+	var selected []string
+	for _, field := range strings.Split(fields, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		known := false
+		for _, name := range allowed {
+			known = known || name == field
+		}
+		if !known {
+			return nil, fmt.Errorf("field %s is not supported", field)
+		}
+		selected = append(selected, field)
+	}
+	return selected, nil
+}
+
+func parseBool(value string) (*bool, error) {
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := strconv.ParseBool(value)
+	return &parsed, err
+}
+
+func parseInt(value string) (*int, error) {
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := strconv.Atoi(value)
+	return &parsed, err
+}
+
+// parseDay reads a 2006-01-02 day, end moves it to the start of the next day so ranges include the whole day.
+func parseDay(value string, end bool) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	day, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return nil, err
+	}
+	if end {
+		day = day.AddDate(0, 0, 1)
+	}
+	return &day, nil
+}
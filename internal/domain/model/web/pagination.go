@@ -1,11 +1,88 @@
 package web
 
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Pagination describes a page of a listing, the cursors are only set in cursor mode and the links are filled by SetLinks.
 type Pagination struct {
-	Next      int
-	Current   int
-	Previous  int
-	TotalPage int
-	Data      int
+	Next       int
+	Current    int
+	Previous   int
+	TotalPage  int
+	Data       int
+	Limit      int
+	NextCursor string
+	PrevCursor string
+	NextLink   string
+	PrevLink   string
 }
 
 type Offset int
+
+func NewPagination(page int, limit int, total int64) Pagination {
+	return Pagination{
+		Next:      page + 1,
+		Current:   page,
+		Previous:  page - 1,
+		TotalPage: int(math.Ceil(float64(total) / float64(limit))),
+		Data:      int(total),
+		Limit:     limit,
+	}
+}
+
+// NewCursorPagination describes a keyset page, pages are not numbered in cursor mode.
+func NewCursorPagination(limit int, total int64, next *Cursor, prev *Cursor) Pagination {
+	return Pagination{
+		TotalPage:  int(math.Ceil(float64(total) / float64(limit))),
+		Data:       int(total),
+		Limit:      limit,
+		NextCursor: EncodeCursor(next),
+		PrevCursor: EncodeCursor(prev),
+	}
+}
+
+func pageLink(u *url.URL, key string, value string) string {
+	query := u.Query()
+	query.Del("page")
+	query.Del("cursor")
+	query.Set(key, value)
+	link := *u
+	link.RawQuery = query.Encode()
+	return link.RequestURI()
+}
+
+// SetLinks builds the next and previous links of the page from the request URL.
+func (p *Pagination) SetLinks(u *url.URL) {
+	if p.NextCursor != "" || p.PrevCursor != "" {
+		if p.NextCursor != "" {
+			p.NextLink = pageLink(u, "cursor", p.NextCursor)
+		}
+		if p.PrevCursor != "" {
+			p.PrevLink = pageLink(u, "cursor", p.PrevCursor)
+		}
+		return
+	}
+	if p.Current > 0 && p.Next <= p.TotalPage {
+		p.NextLink = pageLink(u, "page", strconv.Itoa(p.Next))
+	}
+	if p.Previous >= 1 {
+		p.PrevLink = pageLink(u, "page", strconv.Itoa(p.Previous))
+	}
+}
+
+// LinkHeader formats the links as an RFC 8288 Link header, empty when there is no other page.
+func (p *Pagination) LinkHeader() string {
+	var links []string
+	if p.NextLink != "" {
+		links = append(links, fmt.Sprintf(`<%s>; rel="next"`, p.NextLink))
+	}
+	if p.PrevLink != "" {
+		links = append(links, fmt.Sprintf(`<%s>; rel="prev"`, p.PrevLink))
+	}
+	return strings.Join(links, ", ")
+}
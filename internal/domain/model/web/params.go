@@ -12,6 +12,10 @@ func (u UserID) ToUUID() (ID uuid.UUID, err error) {
 type Params struct {
 	UserID UserID `validate:"required"`
 	Query  interface{}
+	// IfMatch is the If-Match header of a write, empty when it was not sent
+	IfMatch string
+	// IfNoneMatch is the If-None-Match header of a write, "*" only creates a resource that does not exist yet
+	IfNoneMatch string
 }
 
 func NewParams(userID string, query interface{}) *Params {
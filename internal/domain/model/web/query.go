@@ -1,12 +1,18 @@
 package web
 
 import (
-	"go_gin/internal/config"
+	"fmt"
+	"github.com/google/uuid"
+	"go_gin/pkg/querylang"
+	"go_gin/pkg/taskio"
 	"strconv"
+	"strings"
+	"time"
 )
 
 type SearchQuery struct {
 	Page   string `form:"page" validate:"numeric"`
+	Limit  string `form:"limit" validate:"omitempty,numeric"`
 	Search string `form:"search"`
 }
 
@@ -24,18 +30,214 @@ type TodoListByIDsValue struct {
 	IDs []int
 }
 
+type CommentByIDQuery struct {
+	ID        string `form:"id" validate:"required,numeric"`
+	CommentID string `form:"comment_id" validate:"required,numeric"`
+}
+type CommentByIDValue struct {
+	ID        int
+	CommentID int
+}
+
+type AttachmentByIDQuery struct {
+	ID           string `form:"id" validate:"required,numeric"`
+	AttachmentID string `form:"attachment_id" validate:"required,numeric"`
+}
+type AttachmentByIDValue struct {
+	ID           int
+	AttachmentID int
+}
+
+type DependencyQuery struct {
+	ID        string `form:"id" validate:"required,numeric"`
+	BlockerID string `form:"blocker_id" validate:"required,numeric"`
+}
+type DependencyValue struct {
+	ID        int
+	BlockerID int
+}
+
+type ViewByIDQuery struct {
+	ViewID string `form:"view_id" validate:"required,numeric"`
+}
+
+type ViewShareQuery struct {
+	ViewID string `form:"view_id" validate:"required,numeric"`
+	UserID string `form:"user_id" validate:"required,uuid"`
+}
+
+// ViewTodoListsQuery runs a view, the paging parameters are the ones of TodoListsQuery.
+type ViewTodoListsQuery struct {
+	ViewID   string `form:"view_id" validate:"required,numeric"`
+	Page     string `form:"page" validate:"omitempty,numeric"`
+	Limit    string `form:"limit" validate:"omitempty,numeric"`
+	Cursor   string `form:"cursor" validate:"omitempty,max=1024"`
+	Paginate string `form:"paginate" validate:"omitempty,oneof=offset cursor"`
+	Fields   string `form:"fields" validate:"omitempty,max=255"`
+}
+
+type BoardColumnByIDQuery struct {
+	ColumnID string `form:"column_id" validate:"required,numeric"`
+}
+
+type TemplateByIDQuery struct {
+	TemplateID string `form:"template_id" validate:"required,numeric"`
+}
+
+type TimeEntryByIDQuery struct {
+	EntryID string `form:"entry_id" validate:"required,numeric"`
+}
+
+// TimeReportQuery asks for the time logged from From to To included, by day or week, as json or csv. With
+// ProjectID it reports the time of every user on the todolists of the project, else the time of the user by project.
+type TimeReportQuery struct {
+	From      string `form:"from" validate:"required,datetime=2006-01-02"`
+	To        string `form:"to" validate:"required,datetime=2006-01-02"`
+	Period    string `form:"period" validate:"omitempty,oneof=day week"`
+	ProjectID string `form:"project_id" validate:"omitempty,numeric"`
+	Format    string `form:"format" validate:"omitempty,oneof=json csv"`
+}
+
+type TimeReportValue struct {
+	From      time.Time
+	To        time.Time
+	Period    string
+	ProjectID *int
+}
+
+// StatisticsQuery asks for the statistics of the todolists from From to To included, counted by day or week.
+type StatisticsQuery struct {
+	From   string `form:"from" validate:"required,datetime=2006-01-02"`
+	To     string `form:"to" validate:"required,datetime=2006-01-02"`
+	Period string `form:"period" validate:"omitempty,oneof=day week"`
+}
+
+type StatisticsValue struct {
+	From   time.Time
+	To     time.Time
+	Period string
+}
+
+// TodoListExportQuery streams the todolists of a user matching Q as Format, with only Fields when given.
+type TodoListExportQuery struct {
+	Format string `form:"format" validate:"omitempty,oneof=csv json ndjson"`
+	Fields string `form:"fields" validate:"omitempty,max=255"`
+	Q      string `form:"q" validate:"omitempty,max=512"`
+}
+
+type TodoListExportValue struct {
+	Format string
+	Fields []string
+	Query  querylang.Node
+}
+
+// TodoListImportQuery reads an import in Format, Mapping renames its columns as column=field pairs. A dry run only
+// reports the records that cannot be imported.
+type TodoListImportQuery struct {
+	Format  string `form:"format" validate:"required,oneof=csv json ndjson todoist trello ics"`
+	Mapping string `form:"mapping" validate:"omitempty,max=1024"`
+	DryRun  string `form:"dry_run" validate:"omitempty,boolean"`
+}
+
+type TodoListImportValue struct {
+	Format  string
+	Mapping taskio.Mapping
+	DryRun  bool
+}
+
+type SavedSearchByIDQuery struct {
+	SearchID string `form:"search_id" validate:"required,numeric"`
+}
+
+type ProjectByIDQuery struct {
+	ProjectID string `form:"project_id" validate:"required,numeric"`
+}
+
+type ShareQuery struct {
+	Status string `form:"status" validate:"omitempty,oneof=pending accepted declined"`
+}
+
+type ShareByIDQuery struct {
+	ShareID string `form:"share_id" validate:"required,numeric"`
+}
+
+type ShareRespondQuery struct {
+	ShareID string `form:"share_id" validate:"required,numeric"`
+	Action  string `form:"action" validate:"required,oneof=accept decline"`
+}
+
+type NotificationByIDsQuery struct {
+	IDs []string `form:"id" validate:"required,dive,numeric"`
+}
+
 type GetAllQuery struct {
-	Page string `form:"page" validate:"numeric"`
+	Page  string `form:"page" validate:"numeric"`
+	Limit string `form:"limit" validate:"omitempty,numeric"`
+}
+
+// UsersQuery lists users by page or, with paginate=cursor or a cursor, by keyset.
+type UsersQuery struct {
+	Page     string `form:"page" validate:"omitempty,numeric"`
+	Limit    string `form:"limit" validate:"omitempty,numeric"`
+	Cursor   string `form:"cursor" validate:"omitempty,max=1024"`
+	Paginate string `form:"paginate" validate:"omitempty,oneof=offset cursor"`
+}
+
+type TodoListsQuery struct {
+	Page        string   `form:"page" validate:"omitempty,numeric"`
+	Limit       string   `form:"limit" validate:"omitempty,numeric"`
+	Cursor      string   `form:"cursor" validate:"omitempty,max=1024"`
+	Paginate    string   `form:"paginate" validate:"omitempty,oneof=offset cursor"`
+	Status      []string `form:"status" validate:"dive,required,max=32"`
+	Completed   string   `form:"completed" validate:"omitempty,boolean"`
+	PriorityMin string   `form:"priority_min" validate:"omitempty,numeric"`
+	PriorityMax string   `form:"priority_max" validate:"omitempty,numeric"`
+	DueFrom     string   `form:"due_from" validate:"omitempty,datetime=2006-01-02"`
+	DueTo       string   `form:"due_to" validate:"omitempty,datetime=2006-01-02"`
+	Overdue     string   `form:"overdue" validate:"omitempty,boolean"`
+	CreatedFrom string   `form:"created_from" validate:"omitempty,datetime=2006-01-02"`
+	CreatedTo   string   `form:"created_to" validate:"omitempty,datetime=2006-01-02"`
+	UpdatedFrom string   `form:"updated_from" validate:"omitempty,datetime=2006-01-02"`
+	UpdatedTo   string   `form:"updated_to" validate:"omitempty,datetime=2006-01-02"`
+	Sort        string   `form:"sort" validate:"omitempty,max=255"`
+	Fields      string   `form:"fields" validate:"omitempty,max=255"`
+	Q           string   `form:"q" validate:"omitempty,max=512"`
+	SearchID    string   `form:"search_id" validate:"omitempty,numeric"`
+}
+
+type TodoListsValue struct {
+	Offset      Offset
+	Page        int
+	Limit       int
+	Cursor      *Cursor
+	Status      []string
+	Completed   *bool
+	PriorityMin *int
+	PriorityMax *int
+	DueFrom     *time.Time
+	DueTo       *time.Time
+	Overdue     *bool
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	UpdatedFrom *time.Time
+	UpdatedTo   *time.Time
+	Sort        []SortKey
+	Fields      []string
+	Query       querylang.Node
+	SearchID    *int
 }
 
 type GetAllValue struct {
 	Offset Offset
 	Page   int
+	Limit  int
+	Cursor *Cursor
 }
 
 type SearchValue struct {
 	Offset Offset
 	Page   int
+	Limit  int
 	Search string
 }
 
@@ -44,11 +246,16 @@ func (q *SearchQuery) ToValue() (search *SearchValue, err error) {
 		q.Page = "1"
 	}
 	page, err := strconv.Atoi(q.Page)
-	offset := Offset((page - 1) * config.Other.Limit)
+	if err != nil {
+		return &SearchValue{}, err
+	}
+	limit, _, err := parsePage(q.Limit, "", "", "")
+	offset := Offset((page - 1) * limit)
 	search = &SearchValue{
 		Offset: offset,
 		Search: q.Search,
 		Page:   page,
+		Limit:  limit,
 	}
 	return
 }
@@ -58,8 +265,65 @@ func (g *GetAllQuery) ToValue() (getAll *GetAllValue, err error) {
 		g.Page = "1"
 	}
 	page, err := strconv.Atoi(g.Page)
-	offset := Offset((page - 1) * config.Other.Limit)
-	getAll = &GetAllValue{offset, page}
+	if err != nil {
+		return &GetAllValue{}, err
+	}
+	limit, _, err := parsePage(g.Limit, "", "", "")
+	offset := Offset((page - 1) * limit)
+	getAll = &GetAllValue{Offset: offset, Page: page, Limit: limit}
+	return
+}
+
+func (q *UsersQuery) ToValue() (value *GetAllValue, err error) {
+	if q.Page == "" || q.Page == "0" {
+		q.Page = "1"
+	}
+	page, err := strconv.Atoi(q.Page)
+	if err != nil {
+		return &GetAllValue{}, err
+	}
+	limit, cursor, err := parsePage(q.Limit, q.Cursor, q.Paginate, "")
+	offset := Offset((page - 1) * limit)
+	value = &GetAllValue{Offset: offset, Page: page, Limit: limit, Cursor: cursor}
+	return
+}
+
+// ToValue parses the filters of q, the date ranges are inclusive of both days.
+func (q *TodoListsQuery) ToValue() (value *TodoListsValue, err error) {
+	if q.Page == "" || q.Page == "0" {
+		q.Page = "1"
+	}
+	page, err := strconv.Atoi(q.Page)
+	if err != nil {
+		return &TodoListsValue{}, err
+	}
+	limit, cursor, err := parsePage(q.Limit, q.Cursor, q.Paginate, q.Sort)
+	if err != nil {
+		return &TodoListsValue{}, err
+	}
+	offset := Offset((page - 1) * limit)
+	value = &TodoListsValue{Offset: offset, Page: page, Limit: limit, Cursor: cursor, Status: q.Status}
+	parsers := []func() error{
+		func() (err error) { value.Completed, err = parseBool(q.Completed); return },
+		func() (err error) { value.Overdue, err = parseBool(q.Overdue); return },
+		func() (err error) { value.PriorityMin, err = parseInt(q.PriorityMin); return },
+		func() (err error) { value.PriorityMax, err = parseInt(q.PriorityMax); return },
+		func() (err error) { value.DueFrom, err = parseDay(q.DueFrom, false); return },
+		func() (err error) { value.DueTo, err = parseDay(q.DueTo, true); return },
+		func() (err error) { value.CreatedFrom, err = parseDay(q.CreatedFrom, false); return },
+		func() (err error) { value.CreatedTo, err = parseDay(q.CreatedTo, true); return },
+		func() (err error) { value.UpdatedFrom, err = parseDay(q.UpdatedFrom, false); return },
+		func() (err error) { value.UpdatedTo, err = parseDay(q.UpdatedTo, true); return },
+		func() (err error) { value.Sort, err = ParseSort(q.Sort, TodoListSortColumns); return },
+		func() (err error) { value.Fields, err = ParseFields(q.Fields, TodoListFields); return },
+		func() (err error) { value.Query, err = querylang.Parse(q.Q, TodoListQueryFields); return },
+		func() (err error) { value.SearchID, err = parseInt(q.SearchID); return },
+	}
+	for _, parse := range parsers {
+		if err = parse(); err != nil {
+			return
+		}
+	}
 	return
 }
 
@@ -83,3 +347,177 @@ func (q *TodoListByIDsQuery) ToValue() (value *TodoListByIDsValue, err error) {
 	value = &TodoListByIDsValue{IDs: IDs}
 	return
 }
+
+func (q *CommentByIDQuery) ToValue() (value *CommentByIDValue, err error) {
+	id, err := strconv.Atoi(q.ID)
+	if err != nil {
+		value = &CommentByIDValue{}
+		return
+	}
+	commentID, err := strconv.Atoi(q.CommentID)
+	value = &CommentByIDValue{ID: id, CommentID: commentID}
+	return
+}
+
+func (q *NotificationByIDsQuery) ToValue() (IDs []int, err error) {
+	for _, idstr := range q.IDs {
+		id, errParse := strconv.Atoi(idstr)
+		if errParse != nil {
+			err = errParse
+			return
+		}
+		IDs = append(IDs, id)
+	}
+	return
+}
+
+func (q *AttachmentByIDQuery) ToValue() (value *AttachmentByIDValue, err error) {
+	id, err := strconv.Atoi(q.ID)
+	if err != nil {
+		value = &AttachmentByIDValue{}
+		return
+	}
+	attachmentID, err := strconv.Atoi(q.AttachmentID)
+	value = &AttachmentByIDValue{ID: id, AttachmentID: attachmentID}
+	return
+}
+
+func (q *BoardColumnByIDQuery) ToValue() (ID int, err error) {
+	return strconv.Atoi(q.ColumnID)
+}
+
+func (q *TemplateByIDQuery) ToValue() (ID int, err error) {
+	return strconv.Atoi(q.TemplateID)
+}
+
+func (q *TimeEntryByIDQuery) ToValue() (ID int, err error) {
+	return strconv.Atoi(q.EntryID)
+}
+
+func (q *TimeReportQuery) ToValue() (value *TimeReportValue, err error) {
+	value = &TimeReportValue{Period: q.Period}
+	if value.Period == "" {
+		value.Period = "day"
+	}
+	from, err := parseDay(q.From, false)
+	if err != nil {
+		return
+	}
+	to, err := parseDay(q.To, true)
+	if err != nil {
+		return
+	}
+	if !from.Before(*to) {
+		return nil, fmt.Errorf("from %v must not come after to %v", q.From, q.To)
+	}
+	value.From, value.To = *from, *to
+	value.ProjectID, err = parseInt(q.ProjectID)
+	return
+}
+
+func (q *StatisticsQuery) ToValue() (value *StatisticsValue, err error) {
+	value = &StatisticsValue{Period: q.Period}
+	if value.Period == "" {
+		value.Period = "day"
+	}
+	from, err := parseDay(q.From, false)
+	if err != nil {
+		return
+	}
+	to, err := parseDay(q.To, true)
+	if err != nil {
+		return
+	}
+	if !from.Before(*to) {
+		return nil, fmt.Errorf("from %v must not come after to %v", q.From, q.To)
+	}
+	value.From, value.To = *from, *to
+	return
+}
+
+func (q *TodoListExportQuery) ToValue() (value *TodoListExportValue, err error) {
+	value = &TodoListExportValue{Format: q.Format}
+	if value.Format == "" {
+		value.Format = taskio.FormatJSON
+	}
+	if value.Fields, err = ParseFields(q.Fields, TodoListFields); err != nil {
+		return
+	}
+	if len(value.Fields) == 0 {
+		value.Fields = TodoListFields
+	}
+	value.Query, err = querylang.Parse(q.Q, TodoListQueryFields)
+	return
+}
+
+func (q *TodoListImportQuery) ToValue() (value *TodoListImportValue, err error) {
+	value = &TodoListImportValue{Format: q.Format}
+	if value.Mapping, err = taskio.ParseMapping(q.Mapping, TodoListImportFields); err != nil {
+		return
+	}
+	dryRun, err := parseBool(q.DryRun)
+	value.DryRun = dryRun != nil && *dryRun
+	return
+}
+
+func (q *ViewByIDQuery) ToValue() (ID int, err error) {
+	return strconv.Atoi(q.ViewID)
+}
+
+func (q *ViewShareQuery) ToValue() (ID int, userID uuid.UUID, err error) {
+	if ID, err = strconv.Atoi(q.ViewID); err != nil {
+		return
+	}
+	userID, err = uuid.Parse(q.UserID)
+	return
+}
+
+// ToTodoListsQuery builds the listing query of a view, a grouped view sorts on the group first so every group
+// is contiguous across pages, in the direction the view sort gives it if any.
+func (q *ViewTodoListsQuery) ToTodoListsQuery(query string, sort string, groupBy string) TodoListsQuery {
+	if groupBy != "" {
+		keys := []string{groupBy}
+		for _, key := range strings.Split(sort, ",") {
+			key = strings.TrimSpace(key)
+			if strings.TrimPrefix(key, "-") == groupBy {
+				keys[0] = key
+			} else if key != "" {
+				keys = append(keys, key)
+			}
+		}
+		sort = strings.Join(keys, ",")
+	}
+	return TodoListsQuery{Page: q.Page, Limit: q.Limit, Cursor: q.Cursor, Paginate: q.Paginate, Sort: sort, Fields: q.Fields, Q: query}
+}
+
+func (q *SavedSearchByIDQuery) ToValue() (ID int, err error) {
+	return strconv.Atoi(q.SearchID)
+}
+
+func (q *ProjectByIDQuery) ToValue() (ID int, err error) {
+	return strconv.Atoi(q.ProjectID)
+}
+
+func (q *ShareByIDQuery) ToValue() (ID int, err error) {
+	return strconv.Atoi(q.ShareID)
+}
+
+func (q *ShareRespondQuery) ToValue() (ID int, status string, err error) {
+	ID, err = strconv.Atoi(q.ShareID)
+	status = "declined"
+	if q.Action == "accept" {
+		status = "accepted"
+	}
+	return
+}
+
+func (q *DependencyQuery) ToValue() (value *DependencyValue, err error) {
+	id, err := strconv.Atoi(q.ID)
+	if err != nil {
+		value = &DependencyValue{}
+		return
+	}
+	blockerID, err := strconv.Atoi(q.BlockerID)
+	value = &DependencyValue{ID: id, BlockerID: blockerID}
+	return
+}
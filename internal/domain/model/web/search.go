@@ -0,0 +1,18 @@
+package web
+
+import (
+	"strings"
+	"unicode"
+)
+
+// PrefixTsQuery turns free text into a tsquery where every word matches as a prefix, e.g. "meet note" becomes
+// "meet:* & note:*". Anything that is not a letter or a digit separates words so the result is always valid.
+func PrefixTsQuery(search string) string {
+	words := strings.FieldsFunc(search, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, word := range words {
+		words[i] = strings.ToLower(word) + ":*"
+	}
+	return strings.Join(words, " & ")
+}
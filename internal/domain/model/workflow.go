@@ -0,0 +1,137 @@
+package model
+
+import (
+	"database/sql/driver"
+	"time"
+)
+
+type TaskStatus string
+
+func (ts *TaskStatus) Scan(value interface{}) error {
+	if value == nil {
+		*ts = ""
+		return nil
+	}
+	switch v := value.(type) {
+	case []byte:
+		*ts = TaskStatus(v)
+	case string:
+		*ts = TaskStatus(v)
+	}
+	return nil
+}
+
+func (ts TaskStatus) Value() (driver.Value, error) {
+	return string(ts), nil
+}
+
+const (
+	StatusTodo       TaskStatus = "todo"
+	StatusInProgress TaskStatus = "in_progress"
+	StatusBlocked    TaskStatus = "blocked"
+	StatusDone       TaskStatus = "done"
+	StatusCancelled  TaskStatus = "cancelled"
+)
+
+// Workflow holds the statuses a task can take and which moves between them are allowed.
+type Workflow struct {
+	Initial     TaskStatus
+	Start       TaskStatus
+	Done        TaskStatus
+	Statuses    []TaskStatus
+	Transitions map[TaskStatus][]TaskStatus
+}
+
+func DefaultWorkflow() *Workflow {
+	return &Workflow{
+		Initial:  StatusTodo,
+		Start:    StatusInProgress,
+		Done:     StatusDone,
+		Statuses: []TaskStatus{StatusTodo, StatusInProgress, StatusBlocked, StatusDone, StatusCancelled},
+		Transitions: map[TaskStatus][]TaskStatus{
+			StatusTodo:       {StatusInProgress, StatusBlocked, StatusDone, StatusCancelled},
+			StatusInProgress: {StatusTodo, StatusBlocked, StatusDone, StatusCancelled},
+			StatusBlocked:    {StatusTodo, StatusInProgress, StatusCancelled},
+			StatusDone:       {StatusTodo, StatusInProgress},
+			StatusCancelled:  {StatusTodo},
+		},
+	}
+}
+
+// NewWorkflow builds a workflow from configuration, falling back to DefaultWorkflow when nothing is configured.
+func NewWorkflow(initial, start, done string, statuses []string, transitions map[string][]string) *Workflow {
+	if len(statuses) == 0 {
+		return DefaultWorkflow()
+	}
+	workflow := &Workflow{
+		Initial:     TaskStatus(initial),
+		Start:       TaskStatus(start),
+		Done:        TaskStatus(done),
+		Transitions: map[TaskStatus][]TaskStatus{},
+	}
+	for _, status := range statuses {
+		workflow.Statuses = append(workflow.Statuses, TaskStatus(status))
+	}
+	for from, targets := range transitions {
+		for _, to := range targets {
+			workflow.Transitions[TaskStatus(from)] = append(workflow.Transitions[TaskStatus(from)], TaskStatus(to))
+		}
+	}
+	if workflow.Initial == "" {
+		workflow.Initial = workflow.Statuses[0]
+	}
+	return workflow
+}
+
+func (w *Workflow) IsValid(status TaskStatus) bool {
+	for _, s := range w.Statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *Workflow) CanTransition(from, to TaskStatus) bool {
+	if from == to {
+		return true
+	}
+	for _, s := range w.Transitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Resolve picks the target status of a write, deriving it from the legacy completed flag when no status is requested.
+func (w *Workflow) Resolve(current, requested TaskStatus, completed bool) TaskStatus {
+	switch {
+	case requested != "":
+		return requested
+	case completed:
+		return w.Done
+	case current == w.Done:
+		return w.Initial
+	case current != "":
+		return current
+	default:
+		return w.Initial
+	}
+}
+
+// Apply moves the todolist into status and keeps completed, started_at and completed_at consistent with it.
+func (w *Workflow) Apply(todolist *TodoList, status TaskStatus, now time.Time) {
+	todolist.Status = status
+	todolist.Completed = status == w.Done
+	if status == w.Start && todolist.StartedAt == nil {
+		todolist.StartedAt = &now
+	}
+	if status == w.Done {
+		if todolist.CompletedAt == nil {
+			todolist.CompletedAt = &now
+		}
+	} else {
+		todolist.CompletedAt = nil
+	}
+}
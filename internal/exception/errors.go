@@ -9,6 +9,10 @@ var (
 	ErrorNotFound       = errors.New("NOT FOUND")
 	ErrorUnauthorized   = errors.New("UNAUTHORIZED")
 	ErrorForbidden      = errors.New("FORBIDDEN")
+	// ErrorPreconditionFailed is an If-Match that no longer matches the version of the resource
+	ErrorPreconditionFailed = errors.New("PRECONDITION FAILED")
+	// ErrorPreconditionRequired is a write without If-Match while config.Other.RequireIfMatch is set
+	ErrorPreconditionRequired = errors.New("PRECONDITION REQUIRED")
 )
 
 type Error struct {
@@ -3,12 +3,15 @@ package handler
 import (
 	"errors"
 	"go_gin/internal/exception"
+	"go_gin/pkg/querylang"
 	"net/http"
 )
 
 type ResponseErrors struct {
 	Status  int    `json:"status"`
 	Message string `json:"message"`
+	// Position is the 1-based character of a search query the error is about
+	Position int `json:"position,omitempty"`
 }
 
 func NewResponseErrors(err error) *ResponseErrors {
@@ -17,6 +20,10 @@ func NewResponseErrors(err error) *ResponseErrors {
 
 	if errors.As(err, &typeErrors) {
 		responseErrors.Message = typeErrors.MessageError().Error()
+		syntaxError := &querylang.SyntaxError{}
+		if errors.As(typeErrors.MessageError(), &syntaxError) {
+			responseErrors.Position = syntaxError.Pos
+		}
 		typeErr := typeErrors.TypeError()
 		switch typeErr {
 		case exception.ErrorNotFound:
@@ -31,6 +38,10 @@ func NewResponseErrors(err error) *ResponseErrors {
 			responseErrors.Status = http.StatusForbidden
 		case exception.ErrorUnauthorized:
 			responseErrors.Status = http.StatusUnauthorized
+		case exception.ErrorPreconditionFailed:
+			responseErrors.Status = http.StatusPreconditionFailed
+		case exception.ErrorPreconditionRequired:
+			responseErrors.Status = http.StatusPreconditionRequired
 		}
 	}
 	return &responseErrors // Return a pointer to ResponseErrors
@@ -9,6 +9,7 @@ import (
 	"go_gin/internal/domain/model/web"
 	"go_gin/internal/exception"
 	"go_gin/internal/handler"
+	"go_gin/pkg/bcrypts"
 	"go_gin/pkg/helper"
 	"gorm.io/gorm"
 	"net/http"
@@ -109,6 +110,27 @@ func (m *Middleware) Authentication(c *gin.Context) {
 	c.Next()
 }
 
+// DAVAuthentication logs CalDAV clients in with HTTP Basic credentials, the email and password of the user, since
+// they cannot hold an access token.
+func (m *Middleware) DAVAuthentication(c *gin.Context) {
+	email, password, ok := c.Request.BasicAuth()
+	if ok {
+		user, errNotFound := m.Repository.GetUserByEmail(context.Background(), m.DB, email)
+		if errNotFound == nil && bcrypts.CheckPasswordHash(password, user.Password) {
+			c.Set("user_id", user.ID)
+			c.Set("email", user.Email)
+			c.Set("username", user.Username)
+			c.Next()
+			return
+		}
+	}
+	c.Header("WWW-Authenticate", `Basic realm="todolist", charset="UTF-8"`)
+	err := exception.NewError(errors.New("UNAUTHORIZATION"), exception.ErrorUnauthorized)
+	responseErrors := handler.NewResponseErrors(err)
+	c.JSON(responseErrors.Status, responseErrors)
+	c.Abort()
+}
+
 func (m *Middleware) AuthorizationRoleAdmin(c *gin.Context) {
 	idInterface, exist := c.Get("user_id")
 	tx := m.DB.Begin()
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"go_gin/internal/domain/model"
+	"go_gin/pkg/helper"
+	"gorm.io/gorm"
+)
+
+type ActivityRepository struct {
+}
+
+func NewActivityRepository() *ActivityRepository {
+	return &ActivityRepository{}
+}
+
+func (r *ActivityRepository) CreateActivities(ctx context.Context, DB *gorm.DB, activities model.TaskActivities) error {
+	if len(activities) == 0 {
+		return nil
+	}
+	err := DB.WithContext(ctx).Model(&model.TaskActivity{}).Create(&activities).Error
+	return err
+}
+
+func (r *ActivityRepository) GetActivitiesByTaskID(ctx context.Context, DB *gorm.DB, taskID int) model.TaskActivities {
+	var activities model.TaskActivities
+	err := DB.WithContext(ctx).Model(&model.TaskActivity{}).Where("task_id = ?", taskID).Order("created_at ASC").Find(&activities).Error
+	helper.Panic(err)
+	return activities
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"go_gin/internal/domain/model"
+	"go_gin/pkg/helper"
+	"gorm.io/gorm"
+)
+
+type AssignmentRepository struct {
+}
+
+func NewAssignmentRepository() *AssignmentRepository {
+	return &AssignmentRepository{}
+}
+
+func (r *AssignmentRepository) CreateAssignment(ctx context.Context, DB *gorm.DB, assignment model.Assignment) error {
+	err := DB.WithContext(ctx).Model(&model.Assignment{}).Create(&assignment).Error
+	return err
+}
+
+func (r *AssignmentRepository) GetAssignmentsByTaskID(ctx context.Context, DB *gorm.DB, taskID int) model.Assignments {
+	var assignments model.Assignments
+	err := DB.WithContext(ctx).Model(&model.Assignment{}).Where("task_id = ?", taskID).Order("created_at ASC").Find(&assignments).Error
+	helper.Panic(err)
+	return assignments
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"go_gin/internal/domain/model"
+	"go_gin/pkg/helper"
+	"gorm.io/gorm"
+)
+
+type AttachmentRepository struct {
+}
+
+func NewAttachmentRepository() *AttachmentRepository {
+	return &AttachmentRepository{}
+}
+
+func (r *AttachmentRepository) GetAttachmentsByTaskIDs(ctx context.Context, DB *gorm.DB, taskIDs []int) model.Attachments {
+	var attachments model.Attachments
+	err := DB.WithContext(ctx).Model(&model.Attachment{}).Where("task_id IN ?", taskIDs).Order("created_at ASC").Find(&attachments).Error
+	helper.Panic(err)
+	return attachments
+}
+
+func (r *AttachmentRepository) GetAttachmentByID(ctx context.Context, DB *gorm.DB, ID int, taskID int) (model.Attachment, error) {
+	var attachment model.Attachment
+	err := DB.WithContext(ctx).Model(&model.Attachment{}).Where("task_id = ?", taskID).Where("attachment_id = ?", ID).Take(&attachment).Error
+	if err != nil {
+		return model.Attachment{}, err
+	}
+	return attachment, nil
+}
+
+func (r *AttachmentRepository) CreateAttachment(ctx context.Context, DB *gorm.DB, attachment model.Attachment) (model.Attachment, error) {
+	err := DB.WithContext(ctx).Model(&model.Attachment{}).Create(&attachment).Error
+	return attachment, err
+}
+
+func (r *AttachmentRepository) DeleteAttachmentByID(ctx context.Context, DB *gorm.DB, ID int) {
+	err := DB.WithContext(ctx).Where("attachment_id = ?", ID).Delete(&model.Attachment{}).Error
+	helper.Panic(err)
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"go_gin/internal/domain/model"
+	"go_gin/pkg/helper"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BoardRepository struct {
+}
+
+func NewBoardRepository() *BoardRepository {
+	return &BoardRepository{}
+}
+
+func (b *BoardRepository) GetColumns(ctx context.Context, DB *gorm.DB, userID uuid.UUID) model.BoardColumns {
+	var columns model.BoardColumns
+	err := DB.WithContext(ctx).Model(&model.BoardColumn{}).Where("user_id = ?", userID).Order("position ASC").Order("column_id ASC").Find(&columns).Error
+	helper.Panic(err)
+	return columns
+}
+
+func (b *BoardRepository) GetColumnByID(ctx context.Context, DB *gorm.DB, ID int, userID uuid.UUID) (model.BoardColumn, error) {
+	var column model.BoardColumn
+	err := DB.WithContext(ctx).Model(&model.BoardColumn{}).Where("user_id = ?", userID).Where("column_id = ?", ID).Take(&column).Error
+	if err != nil {
+		return model.BoardColumn{}, err
+	}
+	return column, nil
+}
+
+// LockColumnByID is GetColumnByID with the column locked for the rest of the transaction, so that the cards counted
+// against its WIP limit cannot change before the move is made.
+func (b *BoardRepository) LockColumnByID(ctx context.Context, DB *gorm.DB, ID int, userID uuid.UUID) (model.BoardColumn, error) {
+	var column model.BoardColumn
+	err := DB.WithContext(ctx).Model(&model.BoardColumn{}).Clauses(clause.Locking{Strength: "UPDATE"}).Where("user_id = ?", userID).Where("column_id = ?", ID).Take(&column).Error
+	if err != nil {
+		return model.BoardColumn{}, err
+	}
+	return column, nil
+}
+
+func (b *BoardRepository) CreateColumns(ctx context.Context, DB *gorm.DB, columns model.BoardColumns) (model.BoardColumns, error) {
+	err := DB.WithContext(ctx).Model(&model.BoardColumn{}).Create(&columns).Error
+	return columns, err
+}
+
+func (b *BoardRepository) UpdateColumnByID(ctx context.Context, DB *gorm.DB, column model.BoardColumn, ID int, userID uuid.UUID) error {
+	return DB.WithContext(ctx).Model(&model.BoardColumn{}).Where("user_id = ?", userID).Where("column_id = ?", ID).Select("name", "status", "wip_limit").Updates(&column).Error
+}
+
+func (b *BoardRepository) DeleteColumnByID(ctx context.Context, DB *gorm.DB, ID int, userID uuid.UUID) {
+	err := DB.WithContext(ctx).Model(&model.BoardColumn{}).Where("user_id = ?", userID).Where("column_id = ?", ID).Delete(&model.BoardColumn{}).Error
+	helper.Panic(err)
+}
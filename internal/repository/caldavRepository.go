@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"go_gin/internal/domain/model"
+	"go_gin/pkg/helper"
+	"gorm.io/gorm"
+)
+
+type CalDAVRepository struct {
+}
+
+func NewCalDAVRepository() *CalDAVRepository {
+	return &CalDAVRepository{}
+}
+
+func (c *CalDAVRepository) GetCalDAVResources(ctx context.Context, DB *gorm.DB, userID uuid.UUID) model.CalDAVResources {
+	var resources model.CalDAVResources
+	err := DB.WithContext(ctx).Model(&model.CalDAVResource{}).Where("user_id = ?", userID).Find(&resources).Error
+	helper.Panic(err)
+	return resources
+}
+
+func (c *CalDAVRepository) GetCalDAVResourceByName(ctx context.Context, DB *gorm.DB, userID uuid.UUID, name string) (model.CalDAVResource, error) {
+	var resource model.CalDAVResource
+	err := DB.WithContext(ctx).Model(&model.CalDAVResource{}).Where("user_id = ?", userID).Where("name = ?", name).Take(&resource).Error
+	if err != nil {
+		return model.CalDAVResource{}, err
+	}
+	return resource, nil
+}
+
+func (c *CalDAVRepository) GetCalDAVResourceByTaskID(ctx context.Context, DB *gorm.DB, taskID int) (model.CalDAVResource, error) {
+	var resource model.CalDAVResource
+	err := DB.WithContext(ctx).Model(&model.CalDAVResource{}).Where("task_id = ?", taskID).Take(&resource).Error
+	if err != nil {
+		return model.CalDAVResource{}, err
+	}
+	return resource, nil
+}
+
+// LockCalDAVName holds the name of a calendar object of userID until the end of the transaction, so that two
+// writes to a name that does not exist yet cannot both create it. Dialects without advisory locks are not locked.
+func (c *CalDAVRepository) LockCalDAVName(ctx context.Context, DB *gorm.DB, userID uuid.UUID, name string) {
+	if DB.Dialector.Name() != "postgres" {
+		return
+	}
+	err := DB.WithContext(ctx).Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "caldav/"+userID.String()+"/"+name).Error
+	helper.Panic(err)
+}
+
+func (c *CalDAVRepository) CreateCalDAVResource(ctx context.Context, DB *gorm.DB, resource model.CalDAVResource) error {
+	return DB.WithContext(ctx).Model(&model.CalDAVResource{}).Create(&resource).Error
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"go_gin/internal/domain/model"
+	"go_gin/pkg/helper"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CalendarRepository struct {
+}
+
+func NewCalendarRepository() *CalendarRepository {
+	return &CalendarRepository{}
+}
+
+// SaveCalendarToken stores the token of a user, replacing the one it had so that its old feed URL stops working.
+func (c *CalendarRepository) SaveCalendarToken(ctx context.Context, DB *gorm.DB, token model.CalendarToken) {
+	err := DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"token_hash", "created_at"}),
+	}).Create(&token).Error
+	helper.Panic(err)
+}
+
+func (c *CalendarRepository) GetCalendarTokenByHash(ctx context.Context, DB *gorm.DB, hash string) (model.CalendarToken, error) {
+	var token model.CalendarToken
+	err := DB.WithContext(ctx).Model(&model.CalendarToken{}).Where("token_hash = ?", hash).Take(&token).Error
+	if err != nil {
+		return model.CalendarToken{}, err
+	}
+	return token, nil
+}
+
+func (c *CalendarRepository) DeleteCalendarToken(ctx context.Context, DB *gorm.DB, userID uuid.UUID) int64 {
+	result := DB.WithContext(ctx).Where("user_id = ?", userID).Delete(&model.CalendarToken{})
+	helper.Panic(result.Error)
+	return result.RowsAffected
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"go_gin/internal/domain/model"
+	"go_gin/pkg/helper"
+	"gorm.io/gorm"
+)
+
+type CommentRepository struct {
+}
+
+func NewCommentRepository() *CommentRepository {
+	return &CommentRepository{}
+}
+
+func (r *CommentRepository) GetCommentsByTaskID(ctx context.Context, DB *gorm.DB, taskID int) model.Comments {
+	var comments model.Comments
+	err := DB.WithContext(ctx).Model(&model.Comment{}).Where("task_id = ?", taskID).Order("created_at ASC").Find(&comments).Error
+	helper.Panic(err)
+	return comments
+}
+
+func (r *CommentRepository) GetCommentByID(ctx context.Context, DB *gorm.DB, ID int, taskID int) (model.Comment, error) {
+	var comment model.Comment
+	err := DB.WithContext(ctx).Model(&model.Comment{}).Where("task_id = ?", taskID).Where("comment_id = ?", ID).Take(&comment).Error
+	if err != nil {
+		return model.Comment{}, err
+	}
+	return comment, nil
+}
+
+func (r *CommentRepository) CreateComment(ctx context.Context, DB *gorm.DB, comment model.Comment) (model.Comment, error) {
+	err := DB.WithContext(ctx).Model(&model.Comment{}).Create(&comment).Error
+	return comment, err
+}
+
+func (r *CommentRepository) UpdateCommentBody(ctx context.Context, DB *gorm.DB, ID int, body string) {
+	err := DB.WithContext(ctx).Model(&model.Comment{}).Where("comment_id = ?", ID).Updates(map[string]interface{}{"body": body, "edited": true}).Error
+	helper.Panic(err)
+}
+
+func (r *CommentRepository) DeleteCommentByID(ctx context.Context, DB *gorm.DB, ID int) {
+	err := DB.WithContext(ctx).Where("comment_id = ?", ID).Delete(&model.Comment{}).Error
+	helper.Panic(err)
+}
+
+func (r *CommentRepository) CreateCommentRevision(ctx context.Context, DB *gorm.DB, revision model.CommentRevision) error {
+	err := DB.WithContext(ctx).Model(&model.CommentRevision{}).Create(&revision).Error
+	return err
+}
+
+func (r *CommentRepository) GetCommentRevisions(ctx context.Context, DB *gorm.DB, ID int) model.CommentRevisions {
+	var revisions model.CommentRevisions
+	err := DB.WithContext(ctx).Model(&model.CommentRevision{}).Where("comment_id = ?", ID).Order("created_at ASC").Find(&revisions).Error
+	helper.Panic(err)
+	return revisions
+}
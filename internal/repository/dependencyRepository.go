@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"go_gin/internal/domain/model"
+	"go_gin/pkg/helper"
+	"gorm.io/gorm"
+)
+
+type DependencyRepository struct {
+}
+
+func NewDependencyRepository() *DependencyRepository {
+	return &DependencyRepository{}
+}
+
+func (d *DependencyRepository) CreateDependency(ctx context.Context, DB *gorm.DB, dependency model.TaskDependency) error {
+	err := DB.WithContext(ctx).Model(&model.TaskDependency{}).Create(&dependency).Error
+	return err
+}
+
+func (d *DependencyRepository) DeleteDependency(ctx context.Context, DB *gorm.DB, blockerID int, blockedID int) int64 {
+	result := DB.WithContext(ctx).Where("blocker_id = ?", blockerID).Where("blocked_id = ?", blockedID).Delete(&model.TaskDependency{})
+	helper.Panic(result.Error)
+	return result.RowsAffected
+}
+
+// DependencyPathExists reports whether to is reachable from from by following blocker to blocked links.
+// LockDependencies holds the dependency graph until the end of the transaction, so that two links checked against it
+// at once cannot close a cycle together. Links cross users through shares, so the whole graph is locked. Dialects
+// without advisory locks are not locked.
+func (d *DependencyRepository) LockDependencies(ctx context.Context, DB *gorm.DB) {
+	if DB.Dialector.Name() != "postgres" {
+		return
+	}
+	err := DB.WithContext(ctx).Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "task_dependencies").Error
+	helper.Panic(err)
+}
+
+func (d *DependencyRepository) DependencyPathExists(ctx context.Context, DB *gorm.DB, from int, to int) bool {
+	var count int64
+	err := DB.WithContext(ctx).Raw(`WITH RECURSIVE reach(task_id) AS (
+		SELECT blocked_id FROM task_dependencies WHERE blocker_id = ?
+		UNION
+		SELECT d.blocked_id FROM task_dependencies d JOIN reach r ON d.blocker_id = r.task_id
+	) SELECT COUNT(*) FROM reach WHERE task_id = ?`, from, to).Scan(&count).Error
+	helper.Panic(err)
+	return count > 0
+}
+
+func (d *DependencyRepository) GetBlockers(ctx context.Context, DB *gorm.DB, taskID int) model.TodoLists {
+	var todolists model.TodoLists
+	err := DB.WithContext(ctx).Model(&model.TodoList{}).Where("task_id IN (?)", DB.Model(&model.TaskDependency{}).Select("blocker_id").Where("blocked_id = ?", taskID)).Order("task_id ASC").Find(&todolists).Error
+	helper.Panic(err)
+	return todolists
+}
+
+func (d *DependencyRepository) GetBlocking(ctx context.Context, DB *gorm.DB, taskID int) model.TodoLists {
+	var todolists model.TodoLists
+	err := DB.WithContext(ctx).Model(&model.TodoList{}).Where("task_id IN (?)", DB.Model(&model.TaskDependency{}).Select("blocked_id").Where("blocker_id = ?", taskID)).Order("task_id ASC").Find(&todolists).Error
+	helper.Panic(err)
+	return todolists
+}
+
+func (d *DependencyRepository) CountOpenBlockers(ctx context.Context, DB *gorm.DB, taskID int) int64 {
+	var count int64
+	err := DB.WithContext(ctx).Model(&model.TodoList{}).Where("task_id IN (?)", DB.Model(&model.TaskDependency{}).Select("blocker_id").Where("blocked_id = ?", taskID)).Where("completed = ?", false).Count(&count).Error
+	helper.Panic(err)
+	return count
+}
+
+func (d *DependencyRepository) GetDependenciesByTaskIDs(ctx context.Context, DB *gorm.DB, taskIDs []int) model.TaskDependencies {
+	var dependencies model.TaskDependencies
+	if len(taskIDs) == 0 {
+		return dependencies
+	}
+	err := DB.WithContext(ctx).Model(&model.TaskDependency{}).Where("blocker_id IN ?", taskIDs).Where("blocked_id IN ?", taskIDs).Find(&dependencies).Error
+	helper.Panic(err)
+	return dependencies
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"go_gin/internal/domain/model/web"
+	"gorm.io/gorm"
+	"strings"
+)
+
+// ordering is the full order of a listing, the last expression must be unique so keyset pages never overlap.
+type ordering struct {
+	exprs   []string
+	columns []string
+	desc    []bool
+}
+
+// apply orders tx and, when values is set, keeps only the rows after them, backward walks the order in reverse.
+func (o ordering) apply(tx *gorm.DB, values []interface{}, backward bool) *gorm.DB {
+	if len(values) == len(o.exprs) {
+		var conditions []string
+		var args []interface{}
+		for i := range o.exprs {
+			var parts []string
+			for j := 0; j < i; j++ {
+				parts = append(parts, o.exprs[j]+" = ?")
+				args = append(args, values[j])
+			}
+			operator := " > ?"
+			if o.desc[i] != backward {
+				operator = " < ?"
+			}
+			parts = append(parts, o.exprs[i]+operator)
+			args = append(args, values[i])
+			conditions = append(conditions, "("+strings.Join(parts, " AND ")+")")
+		}
+		tx = tx.Where("("+strings.Join(conditions, " OR ")+")", args...)
+	}
+	for i, expr := range o.exprs {
+		if o.desc[i] != backward {
+			tx = tx.Order(expr + " DESC")
+		} else {
+			tx = tx.Order(expr + " ASC")
+		}
+	}
+	return tx
+}
+
+// keysetPage trims the extra row fetched to detect another page, puts rows back in display order
+// and returns the cursors of the pages around them.
+func keysetPage[T any](rows []T, limit int, cursor *web.Cursor, values func(row T) []string) ([]T, *web.Cursor, *web.Cursor) {
+	more := len(rows) > limit
+	if more {
+		rows = rows[:limit]
+	}
+	if cursor.Prev {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+	}
+	if len(rows) == 0 {
+		return rows, nil, nil
+	}
+	var next, prev *web.Cursor
+	if more || cursor.Prev {
+		next = &web.Cursor{Values: values(rows[len(rows)-1]), Sort: cursor.Sort}
+	}
+	if (more && cursor.Prev) || (!cursor.Prev && len(cursor.Values) > 0) {
+		prev = &web.Cursor{Values: values(rows[0]), Prev: true, Sort: cursor.Sort}
+	}
+	return rows, next, prev
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"go_gin/internal/domain/model"
+	"go_gin/internal/domain/model/web"
+	"go_gin/pkg/helper"
+	"gorm.io/gorm"
+	"time"
+)
+
+type NotificationRepository struct {
+}
+
+func NewNotificationRepository() *NotificationRepository {
+	return &NotificationRepository{}
+}
+
+func (r *NotificationRepository) CreateNotifications(ctx context.Context, DB *gorm.DB, notifications model.Notifications) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	err := DB.WithContext(ctx).Model(&model.Notification{}).Create(&notifications).Error
+	return err
+}
+
+func (r *NotificationRepository) GetNotifications(ctx context.Context, DB *gorm.DB, query web.GetAllValue, userID uuid.UUID) model.Notifications {
+	var notifications model.Notifications
+	err := DB.WithContext(ctx).Model(&model.Notification{}).Where("user_id = ?", userID).Order("created_at DESC").Offset(int(query.Offset)).Limit(query.Limit).Find(&notifications).Error
+	helper.Panic(err)
+	return notifications
+}
+
+func (r *NotificationRepository) CountNotifications(ctx context.Context, DB *gorm.DB, userID uuid.UUID) int64 {
+	var count int64
+	err := DB.WithContext(ctx).Model(&model.Notification{}).Where("user_id = ?", userID).Count(&count).Error
+	helper.Panic(err)
+	return count
+}
+
+func (r *NotificationRepository) MarkNotificationsRead(ctx context.Context, DB *gorm.DB, IDs []int, userID uuid.UUID) {
+	err := DB.WithContext(ctx).Model(&model.Notification{}).Where("user_id = ?", userID).Where("notification_id IN ?", IDs).Where("read_at IS NULL").Update("read_at", time.Now()).Error
+	helper.Panic(err)
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"go_gin/internal/domain/model"
+	"go_gin/pkg/helper"
+	"gorm.io/gorm"
+)
+
+type ProjectRepository struct {
+}
+
+func NewProjectRepository() *ProjectRepository {
+	return &ProjectRepository{}
+}
+
+func (p *ProjectRepository) GetProjects(ctx context.Context, DB *gorm.DB, userID uuid.UUID) model.Projects {
+	var projects model.Projects
+	err := DB.WithContext(ctx).Model(&model.Project{}).Where("user_id = ?", userID).Order("name ASC").Find(&projects).Error
+	helper.Panic(err)
+	return projects
+}
+
+func (p *ProjectRepository) GetProjectByID(ctx context.Context, DB *gorm.DB, ID int, userID uuid.UUID) (model.Project, error) {
+	var project model.Project
+	err := DB.WithContext(ctx).Model(&model.Project{}).Where("user_id = ?", userID).Where("project_id = ?", ID).Take(&project).Error
+	if err != nil {
+		return model.Project{}, err
+	}
+	return project, nil
+}
+
+func (p *ProjectRepository) CreateProject(ctx context.Context, DB *gorm.DB, project model.Project) (model.Project, error) {
+	err := DB.WithContext(ctx).Model(&model.Project{}).Create(&project).Error
+	return project, err
+}
+
+func (p *ProjectRepository) UpdateProjectByID(ctx context.Context, DB *gorm.DB, project model.Project, ID int, userID uuid.UUID) {
+	err := DB.WithContext(ctx).Model(&model.Project{}).Where("user_id = ?", userID).Where("project_id = ?", ID).Select("name", "description").Updates(&project).Error
+	helper.Panic(err)
+}
+
+func (p *ProjectRepository) DeleteProjectByID(ctx context.Context, DB *gorm.DB, ID int, userID uuid.UUID) {
+	err := DB.WithContext(ctx).Where("user_id = ?", userID).Where("project_id = ?", ID).Delete(&model.Project{}).Error
+	helper.Panic(err)
+}
+
+func (p *ProjectRepository) ProjectExistByID(ctx context.Context, DB *gorm.DB, ID int, userID uuid.UUID) bool {
+	var count int64
+	err := DB.WithContext(ctx).Model(&model.Project{}).Where("user_id = ?", userID).Where("project_id = ?", ID).Count(&count).Error
+	helper.Panic(err)
+	return count == 1
+}
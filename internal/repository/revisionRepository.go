@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"go_gin/internal/domain/model"
+	"go_gin/pkg/helper"
+	"gorm.io/gorm"
+)
+
+type RevisionRepository struct {
+}
+
+func NewRevisionRepository() *RevisionRepository {
+	return &RevisionRepository{}
+}
+
+func (r *RevisionRepository) GetRevisionsByTaskID(ctx context.Context, DB *gorm.DB, taskID int) model.TodoListRevisions {
+	var revisions model.TodoListRevisions
+	err := DB.WithContext(ctx).Model(&model.TodoListRevision{}).Where("task_id = ?", taskID).Order("number ASC").Find(&revisions).Error
+	helper.Panic(err)
+	return revisions
+}
+
+func (r *RevisionRepository) GetRevision(ctx context.Context, DB *gorm.DB, taskID int, number int) (model.TodoListRevision, error) {
+	var revision model.TodoListRevision
+	err := DB.WithContext(ctx).Model(&model.TodoListRevision{}).Where("task_id = ?", taskID).Where("number = ?", number).Take(&revision).Error
+	if err != nil {
+		return model.TodoListRevision{}, err
+	}
+	return revision, nil
+}
+
+// GetLastRevisionNumber returns the number of the latest revision of taskID, 0 when it has none yet.
+func (r *RevisionRepository) GetLastRevisionNumber(ctx context.Context, DB *gorm.DB, taskID int) int {
+	var number int
+	err := DB.WithContext(ctx).Model(&model.TodoListRevision{}).Where("task_id = ?", taskID).Select("COALESCE(MAX(number), 0)").Scan(&number).Error
+	helper.Panic(err)
+	return number
+}
+
+func (r *RevisionRepository) CreateRevisions(ctx context.Context, DB *gorm.DB, revisions model.TodoListRevisions) error {
+	if len(revisions) == 0 {
+		return nil
+	}
+	err := DB.WithContext(ctx).Model(&model.TodoListRevision{}).Create(&revisions).Error
+	return err
+}
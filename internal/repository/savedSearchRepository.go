@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"go_gin/internal/domain/model"
+	"go_gin/pkg/helper"
+	"gorm.io/gorm"
+)
+
+type SavedSearchRepository struct {
+}
+
+func NewSavedSearchRepository() *SavedSearchRepository {
+	return &SavedSearchRepository{}
+}
+
+func (s *SavedSearchRepository) GetSavedSearches(ctx context.Context, DB *gorm.DB, userID uuid.UUID) model.SavedSearches {
+	var searches model.SavedSearches
+	err := DB.WithContext(ctx).Model(&model.SavedSearch{}).Where("user_id = ?", userID).Order("name ASC").Find(&searches).Error
+	helper.Panic(err)
+	return searches
+}
+
+func (s *SavedSearchRepository) GetSavedSearchByID(ctx context.Context, DB *gorm.DB, ID int, userID uuid.UUID) (model.SavedSearch, error) {
+	var search model.SavedSearch
+	err := DB.WithContext(ctx).Model(&model.SavedSearch{}).Where("user_id = ?", userID).Where("search_id = ?", ID).Take(&search).Error
+	if err != nil {
+		return model.SavedSearch{}, err
+	}
+	return search, nil
+}
+
+func (s *SavedSearchRepository) CreateSavedSearch(ctx context.Context, DB *gorm.DB, search model.SavedSearch) (model.SavedSearch, error) {
+	err := DB.WithContext(ctx).Model(&model.SavedSearch{}).Create(&search).Error
+	return search, err
+}
+
+func (s *SavedSearchRepository) UpdateSavedSearchByID(ctx context.Context, DB *gorm.DB, search model.SavedSearch, ID int, userID uuid.UUID) error {
+	return DB.WithContext(ctx).Model(&model.SavedSearch{}).Where("user_id = ?", userID).Where("search_id = ?", ID).Select("name", "query").Updates(&search).Error
+}
+
+func (s *SavedSearchRepository) DeleteSavedSearchByID(ctx context.Context, DB *gorm.DB, ID int, userID uuid.UUID) {
+	err := DB.WithContext(ctx).Where("user_id = ?", userID).Where("search_id = ?", ID).Delete(&model.SavedSearch{}).Error
+	helper.Panic(err)
+}
+
+func (s *SavedSearchRepository) SavedSearchExistByID(ctx context.Context, DB *gorm.DB, ID int, userID uuid.UUID) bool {
+	var count int64
+	err := DB.WithContext(ctx).Model(&model.SavedSearch{}).Where("user_id = ?", userID).Where("search_id = ?", ID).Count(&count).Error
+	helper.Panic(err)
+	return count == 1
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"go_gin/internal/domain/model"
+	"go_gin/pkg/helper"
+	"gorm.io/gorm"
+	"time"
+)
+
+type ShareRepository struct {
+}
+
+func NewShareRepository() *ShareRepository {
+	return &ShareRepository{}
+}
+
+func (s *ShareRepository) CreateShare(ctx context.Context, DB *gorm.DB, share model.Share) (model.Share, error) {
+	err := DB.WithContext(ctx).Model(&model.Share{}).Create(&share).Error
+	return share, err
+}
+
+func (s *ShareRepository) GetShareByID(ctx context.Context, DB *gorm.DB, ID int) (model.Share, error) {
+	var share model.Share
+	err := DB.WithContext(ctx).Model(&model.Share{}).Where("share_id = ?", ID).Take(&share).Error
+	if err != nil {
+		return model.Share{}, err
+	}
+	return share, nil
+}
+
+func (s *ShareRepository) GetSharesReceived(ctx context.Context, DB *gorm.DB, userID uuid.UUID, status string) model.Shares {
+	var shares model.Shares
+	tx := DB.WithContext(ctx).Model(&model.Share{}).Where("user_id = ?", userID)
+	if status != "" {
+		tx = tx.Where("status = ?", status)
+	}
+	err := tx.Order("created_at DESC").Find(&shares).Error
+	helper.Panic(err)
+	return shares
+}
+
+func (s *ShareRepository) GetSharesSent(ctx context.Context, DB *gorm.DB, ownerID uuid.UUID) model.Shares {
+	var shares model.Shares
+	err := DB.WithContext(ctx).Model(&model.Share{}).Where("owner_id = ?", ownerID).Order("created_at DESC").Find(&shares).Error
+	helper.Panic(err)
+	return shares
+}
+
+func (s *ShareRepository) UpdateShareStatus(ctx context.Context, DB *gorm.DB, ID int, status string) {
+	err := DB.WithContext(ctx).Model(&model.Share{}).Where("share_id = ?", ID).Updates(map[string]interface{}{"status": status, "responded_at": time.Now()}).Error
+	helper.Panic(err)
+}
+
+func (s *ShareRepository) DeleteShareByID(ctx context.Context, DB *gorm.DB, ID int) {
+	err := DB.WithContext(ctx).Where("share_id = ?", ID).Delete(&model.Share{}).Error
+	helper.Panic(err)
+}
+
+// FindGrant returns the strongest accepted permission userID holds on the task directly or through its project.
+func (s *ShareRepository) FindGrant(ctx context.Context, DB *gorm.DB, taskID int, projectID *int, userID uuid.UUID) (model.SharePermission, bool) {
+	var shares model.Shares
+	tx := DB.WithContext(ctx).Model(&model.Share{}).Where("user_id = ?", userID).Where("status = ?", model.ShareStatusAccepted)
+	if projectID != nil {
+		tx = tx.Where("task_id = ? OR project_id = ?", taskID, *projectID)
+	} else {
+		tx = tx.Where("task_id = ?", taskID)
+	}
+	err := tx.Find(&shares).Error
+	helper.Panic(err)
+	var permission model.SharePermission
+	for _, share := range shares {
+		if share.Permission.Allows(model.PermissionEditor) {
+			return share.Permission, true
+		}
+		permission = share.Permission
+	}
+	return permission, len(shares) > 0
+}
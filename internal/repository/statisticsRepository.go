@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"go_gin/internal/domain/model"
+	"go_gin/internal/domain/model/web"
+	"go_gin/pkg/helper"
+	"gorm.io/gorm"
+	"time"
+)
+
+// StatisticsRepository aggregates the todolists of a user, or of every user when userID is nil. Trashed todolists
+// are left out and days are counted in UTC.
+type StatisticsRepository struct {
+}
+
+func NewStatisticsRepository() *StatisticsRepository {
+	return &StatisticsRepository{}
+}
+
+func (s *StatisticsRepository) todolists(ctx context.Context, DB *gorm.DB, userID *uuid.UUID) *gorm.DB {
+	tx := DB.WithContext(ctx).Model(&model.TodoList{})
+	if userID != nil {
+		tx = tx.Where("user_id = ?", *userID)
+	}
+	return tx
+}
+
+func (s *StatisticsRepository) GetStatisticsTotals(ctx context.Context, DB *gorm.DB, query web.StatisticsValue, userID *uuid.UUID, now time.Time) model.StatisticsTotals {
+	var totals model.StatisticsTotals
+	created := "created_at >= ? AND created_at < ?"
+	completed := "completed AND completed_at >= ? AND completed_at < ?"
+	overdue, overdueArgs := overdueCondition(now)
+	args := []interface{}{query.From, query.To, query.From, query.To, query.From, query.To, query.From, query.To, query.From, query.To}
+	err := s.todolists(ctx, DB, userID).Select(
+		"COUNT(DISTINCT user_id) FILTER (WHERE "+created+") AS users, "+
+			"COUNT(*) FILTER (WHERE "+created+") AS created, "+
+			"COUNT(*) FILTER (WHERE completed AND "+created+") AS created_completed, "+
+			"COUNT(*) FILTER (WHERE "+completed+") AS completed, "+
+			"AVG(EXTRACT(EPOCH FROM completed_at - created_at)) FILTER (WHERE "+completed+") AS lead_seconds, "+
+			"COUNT(*) FILTER (WHERE "+overdue+") AS overdue",
+		append(args, overdueArgs...)...,
+	).Scan(&totals).Error
+	helper.Panic(err)
+	return totals
+}
+
+// GetStatisticsCounts counts the todolists by the day or week of column, created_at or completed_at, in the range of
+// query.
+func (s *StatisticsRepository) GetStatisticsCounts(ctx context.Context, DB *gorm.DB, query web.StatisticsValue, userID *uuid.UUID, column string) model.StatisticsCounts {
+	var counts model.StatisticsCounts
+	tx := s.todolists(ctx, DB, userID)
+	if column == "completed_at" {
+		tx = tx.Where("completed = ?", true)
+	}
+	err := tx.Select("TO_CHAR(DATE_TRUNC(?, "+column+"), 'YYYY-MM-DD') AS period, COUNT(*) AS count", query.Period).
+		Where(column+" >= ?", query.From).
+		Where(column+" < ?", query.To).
+		Group("period").Order("period ASC").Scan(&counts).Error
+	helper.Panic(err)
+	return counts
+}
+
+// GetPriorityCounts counts the todolists created in the range of query by priority.
+func (s *StatisticsRepository) GetPriorityCounts(ctx context.Context, DB *gorm.DB, query web.StatisticsValue, userID *uuid.UUID) model.PriorityCounts {
+	var counts model.PriorityCounts
+	err := s.todolists(ctx, DB, userID).Select("priority, COUNT(*) AS count").
+		Where("created_at >= ?", query.From).
+		Where("created_at < ?", query.To).
+		Group("priority").Order("priority ASC").Scan(&counts).Error
+	helper.Panic(err)
+	return counts
+}
+
+// GetCompletionDays returns the days in the range of query a todolist was completed on, as ascending 2006-01-02 days.
+func (s *StatisticsRepository) GetCompletionDays(ctx context.Context, DB *gorm.DB, query web.StatisticsValue, userID *uuid.UUID) []string {
+	var days []string
+	err := s.todolists(ctx, DB, userID).Distinct("TO_CHAR(completed_at, 'YYYY-MM-DD') AS day").
+		Where("completed = ?", true).
+		Where("completed_at >= ?", query.From).
+		Where("completed_at < ?", query.To).
+		Order("day ASC").Scan(&days).Error
+	helper.Panic(err)
+	return days
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"go_gin/internal/domain/model"
+	"go_gin/pkg/helper"
+	"gorm.io/gorm"
+)
+
+type TagRepository struct {
+}
+
+func NewTagRepository() *TagRepository {
+	return &TagRepository{}
+}
+
+func (t *TagRepository) GetTagsByTaskID(ctx context.Context, DB *gorm.DB, taskID int) model.TodoListTags {
+	var tags model.TodoListTags
+	err := DB.WithContext(ctx).Model(&model.TodoListTag{}).Where("task_id = ?", taskID).Order("tag ASC").Find(&tags).Error
+	helper.Panic(err)
+	return tags
+}
+
+// ReplaceTags sets the tags of a todolist to exactly tags.
+func (t *TagRepository) ReplaceTags(ctx context.Context, DB *gorm.DB, taskID int, tags []string) {
+	err := DB.WithContext(ctx).Where("task_id = ?", taskID).Delete(&model.TodoListTag{}).Error
+	helper.Panic(err)
+	if len(tags) == 0 {
+		return
+	}
+	rows := make(model.TodoListTags, 0, len(tags))
+	for _, tag := range tags {
+		rows = append(rows, model.TodoListTag{TaskID: taskID, Tag: tag})
+	}
+	err = DB.WithContext(ctx).Create(&rows).Error
+	helper.Panic(err)
+}
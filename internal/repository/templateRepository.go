@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"go_gin/internal/domain/model"
+	"go_gin/pkg/helper"
+	"gorm.io/gorm"
+)
+
+type TemplateRepository struct {
+}
+
+func NewTemplateRepository() *TemplateRepository {
+	return &TemplateRepository{}
+}
+
+func (t *TemplateRepository) withItems(DB *gorm.DB) *gorm.DB {
+	return DB.Model(&model.Template{}).Preload("Items", func(tx *gorm.DB) *gorm.DB {
+		return tx.Order("sort_order ASC")
+	})
+}
+
+func (t *TemplateRepository) GetTemplates(ctx context.Context, DB *gorm.DB, userID uuid.UUID) model.Templates {
+	var templates model.Templates
+	err := t.withItems(DB.WithContext(ctx)).Where("user_id = ?", userID).Order("name ASC").Find(&templates).Error
+	helper.Panic(err)
+	return templates
+}
+
+func (t *TemplateRepository) GetTemplateByID(ctx context.Context, DB *gorm.DB, ID int, userID uuid.UUID) (model.Template, error) {
+	var template model.Template
+	err := t.withItems(DB.WithContext(ctx)).Where("user_id = ?", userID).Where("template_id = ?", ID).Take(&template).Error
+	if err != nil {
+		return model.Template{}, err
+	}
+	return template, nil
+}
+
+// CreateTemplate creates the template together with its items.
+func (t *TemplateRepository) CreateTemplate(ctx context.Context, DB *gorm.DB, template model.Template) (model.Template, error) {
+	err := DB.WithContext(ctx).Model(&model.Template{}).Create(&template).Error
+	return template, err
+}
+
+// UpdateTemplateByID renames the template and replaces all of its items.
+func (t *TemplateRepository) UpdateTemplateByID(ctx context.Context, DB *gorm.DB, template model.Template, ID int, userID uuid.UUID) error {
+	err := DB.WithContext(ctx).Model(&model.Template{}).Where("user_id = ?", userID).Where("template_id = ?", ID).Select("name", "description").Updates(&template).Error
+	if err != nil {
+		return err
+	}
+	err = DB.WithContext(ctx).Where("template_id = ?", ID).Delete(&model.TemplateItem{}).Error
+	if err != nil {
+		return err
+	}
+	items := template.Items
+	for i := range items {
+		items[i].TemplateID = ID
+	}
+	return DB.WithContext(ctx).Model(&model.TemplateItem{}).Create(&items).Error
+}
+
+func (t *TemplateRepository) DeleteTemplateByID(ctx context.Context, DB *gorm.DB, ID int, userID uuid.UUID) {
+	err := DB.WithContext(ctx).Model(&model.Template{}).Where("user_id = ?", userID).Where("template_id = ?", ID).Delete(&model.Template{}).Error
+	helper.Panic(err)
+}
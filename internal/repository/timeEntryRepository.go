@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"go_gin/internal/domain/model"
+	"go_gin/internal/domain/model/web"
+	"go_gin/pkg/helper"
+	"gorm.io/gorm"
+	"time"
+)
+
+type TimeEntryRepository struct {
+}
+
+func NewTimeEntryRepository() *TimeEntryRepository {
+	return &TimeEntryRepository{}
+}
+
+func (t *TimeEntryRepository) GetRunningTimeEntry(ctx context.Context, DB *gorm.DB, userID uuid.UUID) (model.TimeEntry, error) {
+	var entry model.TimeEntry
+	err := DB.WithContext(ctx).Model(&model.TimeEntry{}).Where("user_id = ?", userID).Where("ended_at IS NULL").Take(&entry).Error
+	if err != nil {
+		return model.TimeEntry{}, err
+	}
+	return entry, nil
+}
+
+func (t *TimeEntryRepository) GetTimeEntryByID(ctx context.Context, DB *gorm.DB, ID int, userID uuid.UUID) (model.TimeEntry, error) {
+	var entry model.TimeEntry
+	err := DB.WithContext(ctx).Model(&model.TimeEntry{}).Where("user_id = ?", userID).Where("entry_id = ?", ID).Take(&entry).Error
+	if err != nil {
+		return model.TimeEntry{}, err
+	}
+	return entry, nil
+}
+
+func (t *TimeEntryRepository) GetTimeEntriesByTaskID(ctx context.Context, DB *gorm.DB, taskID int) model.TimeEntries {
+	var entries model.TimeEntries
+	err := DB.WithContext(ctx).Model(&model.TimeEntry{}).Where("task_id = ?", taskID).Order("started_at ASC").Order("entry_id ASC").Find(&entries).Error
+	helper.Panic(err)
+	return entries
+}
+
+// GetTimeEntriesForReport returns the finished entries started in the range of query with the project of their
+// todolist, on the todolists of query.ProjectID when set and else the entries of userID. Time on todolists in the
+// trash is left out like the todolists themselves.
+func (t *TimeEntryRepository) GetTimeEntriesForReport(ctx context.Context, DB *gorm.DB, query web.TimeReportValue, userID uuid.UUID) model.TimeEntries {
+	var entries model.TimeEntries
+	tx := DB.WithContext(ctx).Model(&model.TimeEntry{}).
+		Select("time_entries.*, todolist.project_id").
+		Joins("JOIN todolist ON todolist.task_id = time_entries.task_id").
+		Where("todolist.deleted_at IS NULL").
+		Where("time_entries.ended_at IS NOT NULL").
+		Where("time_entries.started_at >= ?", query.From).
+		Where("time_entries.started_at < ?", query.To)
+	if query.ProjectID != nil {
+		tx = tx.Where("todolist.project_id = ?", *query.ProjectID)
+	} else {
+		tx = tx.Where("time_entries.user_id = ?", userID)
+	}
+	err := tx.Order("time_entries.started_at ASC").Find(&entries).Error
+	helper.Panic(err)
+	return entries
+}
+
+func (t *TimeEntryRepository) CreateTimeEntry(ctx context.Context, DB *gorm.DB, entry model.TimeEntry) (model.TimeEntry, error) {
+	err := DB.WithContext(ctx).Model(&model.TimeEntry{}).Create(&entry).Error
+	return entry, err
+}
+
+func (t *TimeEntryRepository) StopTimeEntry(ctx context.Context, DB *gorm.DB, ID int, endedAt time.Time) {
+	err := DB.WithContext(ctx).Model(&model.TimeEntry{}).Where("entry_id = ?", ID).Update("ended_at", endedAt).Error
+	helper.Panic(err)
+}
+
+func (t *TimeEntryRepository) DeleteTimeEntryByID(ctx context.Context, DB *gorm.DB, ID int) {
+	err := DB.WithContext(ctx).Model(&model.TimeEntry{}).Where("entry_id = ?", ID).Delete(&model.TimeEntry{}).Error
+	helper.Panic(err)
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"fmt"
+	"go_gin/pkg/querylang"
+	"strings"
+	"time"
+)
+
+// todoListQueryColumns maps the fields of the query language to their column, tag and overdue are handled apart.
+var todoListQueryColumns = map[string]string{
+	"priority":  "priority",
+	"project":   "project_id",
+	"due":       "due_date",
+	"created":   "created_at",
+	"updated":   "updated_at",
+	"status":    "status",
+	"completed": "completed",
+}
+
+var sqlOperators = map[querylang.Op]string{
+	querylang.OpEq: "=",
+	querylang.OpNe: "<>",
+	querylang.OpLt: "<",
+	querylang.OpLe: "<=",
+	querylang.OpGt: ">",
+	querylang.OpGe: ">=",
+}
+
+// likeEscaper escapes the wildcards of a LIKE pattern with "!", named as the ESCAPE of the LIKE since dialects do not
+// agree on a default escape character nor on how a backslash is quoted.
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// todoListCondition translates a parsed query into a SQL condition on todolist, values are always bound as arguments.
+func todoListCondition(node querylang.Node) (string, []interface{}) {
+	switch n := node.(type) {
+	case *querylang.And:
+		return joinConditions(n.Nodes, " AND ")
+	case *querylang.Or:
+		return joinConditions(n.Nodes, " OR ")
+	case *querylang.Not:
+		condition, args := todoListCondition(n.Node)
+		return "NOT " + condition, args
+	case *querylang.Text:
+		key := "%" + likeEscaper.Replace(strings.ToLower(n.Value)) + "%"
+		return `(LOWER(task_name) LIKE ? ESCAPE '!' OR LOWER(COALESCE(description, '')) LIKE ? ESCAPE '!')`, []interface{}{key, key}
+	case *querylang.Compare:
+		return compareCondition(n)
+	}
+	panic(fmt.Errorf("query node %T is not supported", node))
+}
+
+func joinConditions(nodes []querylang.Node, separator string) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	for _, node := range nodes {
+		condition, nodeArgs := todoListCondition(node)
+		conditions = append(conditions, condition)
+		args = append(args, nodeArgs...)
+	}
+	return "(" + strings.Join(conditions, separator) + ")", args
+}
+
+func compareCondition(n *querylang.Compare) (string, []interface{}) {
+	switch n.Field {
+	case "tag":
+		condition := "EXISTS (SELECT 1 FROM todolist_tags WHERE todolist_tags.task_id = todolist.task_id AND todolist_tags.tag = ?)"
+		if n.Op == querylang.OpNe {
+			condition = "NOT " + condition
+		}
+		return condition, []interface{}{strings.ToLower(n.Value.(string))}
+	case "overdue":
+		condition, args := overdueCondition(time.Now())
+		if (n.Op == querylang.OpEq) != n.Value.(bool) {
+			condition = "NOT " + condition
+		}
+		return condition, args
+	case "due", "created", "updated":
+		return dayCondition(todoListQueryColumns[n.Field], n.Op, n.Value.(time.Time))
+	}
+	condition := fmt.Sprintf("%s %s ?", todoListQueryColumns[n.Field], sqlOperators[n.Op])
+	return condition, []interface{}{n.Value}
+}
+
+// overdueCondition matches the open todolists past due at now. A timed due date is past from its moment, a whole day
+// once it is over in UTC.
+func overdueCondition(now time.Time) (string, []interface{}) {
+	now = now.UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	condition := "(completed = ? AND due_date IS NOT NULL AND due_date < CASE WHEN due_timed THEN ?::timestamptz ELSE ?::timestamptz END)"
+	return condition, []interface{}{false, now, today}
+}
+
+// dayCondition compares a timestamp column with a whole day, created:2026-11-01 matches any time on that day.
+func dayCondition(column string, op querylang.Op, day time.Time) (string, []interface{}) {
+	next := day.AddDate(0, 0, 1)
+	switch op {
+	case querylang.OpEq:
+		return fmt.Sprintf("(%s >= ? AND %s < ?)", column, column), []interface{}{day, next}
+	case querylang.OpNe:
+		return fmt.Sprintf("(%s < ? OR %s >= ?)", column, column), []interface{}{day, next}
+	case querylang.OpLe:
+		return column + " < ?", []interface{}{next}
+	case querylang.OpGt:
+		return column + " >= ?", []interface{}{next}
+	}
+	return fmt.Sprintf("%s %s ?", column, sqlOperators[op]), []interface{}{day}
+}
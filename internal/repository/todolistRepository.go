@@ -7,9 +7,13 @@ import (
 	"go_gin/internal/config"
 	"go_gin/internal/domain/model"
 	"go_gin/internal/domain/model/web"
+	"go_gin/pkg/fractional"
 	"go_gin/pkg/helper"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strconv"
 	"strings"
+	"time"
 )
 
 type TodolistRepository struct {
@@ -20,30 +24,193 @@ func NewTodolistRepository() *TodolistRepository {
 }
 
 // perkecil argumen
-func (t *TodolistRepository) scanTodoList(rows *sql.Rows) (model.TodoList, error) {
+func (t *TodolistRepository) scanTodoList(DB *gorm.DB, rows *sql.Rows) (model.TodoList, error) {
 	var todolist model.TodoList
-	err := rows.Scan(
-		&todolist.TaskID,
-		&todolist.UserID,
-		&todolist.TaskName,
-		&todolist.Description,
-		&todolist.DueDate,
-		&todolist.Priority,
-		&todolist.Completed,
-		&todolist.CreatedAt,
-		&todolist.UpdatedAt,
-	)
+	err := DB.ScanRows(rows, &todolist)
 	return todolist, err
 }
-func (t *TodolistRepository) GetTodoListsSearch(ctx context.Context, DB *gorm.DB, query web.SearchValue, userId uuid.UUID) model.TodoLists {
-	valueSearch := []string{"%", query.Search, "%"}
-	key := strings.Join(valueSearch, "")
+
+// searchTodoLists matches query.Search against the todolists of userId, an empty search lists them all. Postgres goes
+// through the search_vector index with language, a search without any word matches nothing there. Other dialects
+// fall back to a case insensitive LIKE.
+func (t *TodolistRepository) searchTodoLists(DB *gorm.DB, query web.SearchValue, userId uuid.UUID, language string) *gorm.DB {
+	tx := DB.Model(&model.TodoList{}).Where("user_id = ?", userId)
+	search := strings.TrimSpace(query.Search)
+	if search == "" {
+		return tx
+	}
+	if DB.Dialector.Name() != "postgres" {
+		key := "%" + likeEscaper.Replace(strings.ToLower(search)) + "%"
+		return tx.Where(`(LOWER(task_name) LIKE ? ESCAPE '!' OR LOWER(description) LIKE ? ESCAPE '!')`, key, key)
+	}
+	tsquery := web.PrefixTsQuery(search)
+	if tsquery == "" {
+		return tx.Where("FALSE")
+	}
+	return tx.Where("search_vector @@ to_tsquery(?::regconfig, ?)", language, tsquery)
+}
+
+func (t *TodolistRepository) CountTodoListsSearch(ctx context.Context, DB *gorm.DB, query web.SearchValue, userId uuid.UUID, language string) int64 {
+	var count int64
+	err := t.searchTodoLists(DB.WithContext(ctx), query, userId, language).Count(&count).Error
+	helper.Panic(err)
+	return count
+}
+
+// GetTodoListsSearch returns the best matches first, with the matched words of the name and description highlighted.
+func (t *TodolistRepository) GetTodoListsSearch(ctx context.Context, DB *gorm.DB, query web.SearchValue, userId uuid.UUID, language string) model.TodoListSearchHits {
+	var hits model.TodoListSearchHits
+	tx := t.searchTodoLists(DB.WithContext(ctx), query, userId, language)
+	tsquery := web.PrefixTsQuery(query.Search)
+	if DB.Dialector.Name() != "postgres" || tsquery == "" {
+		tx = tx.Select("todolist.*, 0 AS rank, task_name AS task_name_highlight, COALESCE(description, '') AS description_highlight").Order("task_id ASC")
+	} else {
+		tx = tx.Select("todolist.*, ts_rank(search_vector, to_tsquery(?::regconfig, ?)) AS rank, "+
+			"ts_headline(?::regconfig, task_name, to_tsquery(?::regconfig, ?), 'StartSel=<mark>, StopSel=</mark>, HighlightAll=true') AS task_name_highlight, "+
+			"ts_headline(?::regconfig, COALESCE(description, ''), to_tsquery(?::regconfig, ?), 'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20, MinWords=5') AS description_highlight",
+			language, tsquery, language, language, tsquery, language, language, tsquery).
+			Order("rank DESC").Order("task_id ASC")
+	}
+	err := tx.Offset(int(query.Offset)).Limit(query.Limit).Find(&hits).Error
+	helper.Panic(err)
+	return hits
+}
+
+// filterTodoLists applies the listing filters of query to the todolists of userId, the "to" bounds are exclusive.
+func (t *TodolistRepository) filterTodoLists(DB *gorm.DB, query web.TodoListsValue, userId uuid.UUID) *gorm.DB {
+	tx := DB.Model(&model.TodoList{}).Where("user_id = ?", userId)
+	if len(query.Status) > 0 {
+		tx = tx.Where("status IN ?", query.Status)
+	}
+	if query.Completed != nil {
+		tx = tx.Where("completed = ?", *query.Completed)
+	}
+	if query.PriorityMin != nil {
+		tx = tx.Where("priority >= ?", *query.PriorityMin)
+	}
+	if query.PriorityMax != nil {
+		tx = tx.Where("priority <= ?", *query.PriorityMax)
+	}
+	ranges := []struct {
+		column   string
+		from, to *time.Time
+	}{
+		{"due_date", query.DueFrom, query.DueTo},
+		{"created_at", query.CreatedFrom, query.CreatedTo},
+		{"updated_at", query.UpdatedFrom, query.UpdatedTo},
+	}
+	for _, r := range ranges {
+		if r.from != nil {
+			tx = tx.Where(r.column+" >= ?", *r.from)
+		}
+		if r.to != nil {
+			tx = tx.Where(r.column+" < ?", *r.to)
+		}
+	}
+	if query.Overdue != nil {
+		condition, args := overdueCondition(time.Now())
+		if !*query.Overdue {
+			condition = "NOT " + condition
+		}
+		tx = tx.Where(condition, args...)
+	}
+	if query.Query != nil {
+		condition, args := todoListCondition(query.Query)
+		tx = tx.Where(condition, args...)
+	}
+	return tx
+}
+
+// todoListOrdering orders by the requested keys, due dates without a value always come last. Ties are broken by the
+// manual position then task_id, unless task_id was asked for explicitly.
+func todoListOrdering(keys []web.SortKey) ordering {
+	var order ordering
+	idDesc, byID, byPosition := false, false, false
+	for _, key := range keys {
+		if key.Column == "task_id" {
+			idDesc, byID = key.Desc, true
+			continue
+		}
+		byPosition = byPosition || key.Column == "position"
+		expr := key.Column
+		if key.Column == "due_date" {
+			expr = "COALESCE(due_date, TIMESTAMPTZ '9999-12-31 00:00:00+00')"
+			if key.Desc {
+				expr = "COALESCE(due_date, TIMESTAMPTZ '0001-01-01 00:00:00+00')"
+			}
+		}
+		order.exprs = append(order.exprs, expr)
+		order.columns = append(order.columns, key.Column)
+		order.desc = append(order.desc, key.Desc)
+	}
+	if !byID && !byPosition {
+		order.exprs = append(order.exprs, "position")
+		order.columns = append(order.columns, "position")
+		order.desc = append(order.desc, false)
+	}
+	order.exprs = append(order.exprs, "task_id")
+	order.columns = append(order.columns, "task_id")
+	order.desc = append(order.desc, idDesc)
+	return order
+}
+
+// todoListKey is the cursor value of column for todolist, a missing due date takes the sentinel it is sorted by.
+func todoListKey(todolist model.TodoList, column string, desc bool) string {
+	switch column {
+	case "task_name":
+		return todolist.TaskName
+	case "position":
+		return todolist.Position
+	case "due_date":
+		if todolist.DueDate == nil {
+			if desc {
+				return "0001-01-01T00:00:00Z"
+			}
+			return "9999-12-31T00:00:00Z"
+		}
+		return todolist.DueDate.Format(time.RFC3339Nano)
+	case "priority":
+		return strconv.Itoa(todolist.Priority)
+	case "status":
+		return string(todolist.Status)
+	case "completed":
+		return strconv.FormatBool(todolist.Completed)
+	case "created_at":
+		return todolist.CreatedAt.Format(time.RFC3339Nano)
+	case "updated_at":
+		return todolist.UpdatedAt.Format(time.RFC3339Nano)
+	}
+	return strconv.Itoa(todolist.TaskID)
+}
+
+// parseTodoListKey turns a cursor value back into the type of column.
+func parseTodoListKey(column string, value string) (interface{}, error) {
+	switch column {
+	case "task_name", "status", "position":
+		return value, nil
+	case "completed":
+		return strconv.ParseBool(value)
+	case "due_date", "created_at", "updated_at":
+		return time.Parse(time.RFC3339Nano, value)
+	}
+	return strconv.Atoi(value)
+}
+
+func (t *TodolistRepository) CountTodoLists(ctx context.Context, DB *gorm.DB, query web.TodoListsValue, userId uuid.UUID) int64 {
+	var count int64
+	err := t.filterTodoLists(DB.WithContext(ctx), query, userId).Count(&count).Error
+	helper.Panic(err)
+	return count
+}
+
+func (t *TodolistRepository) GetTodoLists(ctx context.Context, DB *gorm.DB, query web.TodoListsValue, userId uuid.UUID) model.TodoLists {
 	var todolists model.TodoLists
-	rows, err := DB.WithContext(ctx).Model(&model.TodoList{}).Where("user_id = ?", userId).Where("task_name LIKE ?", key).Or("description LIKE ?", key).Offset(int(query.Offset)).Limit(config.Other.Limit).Rows()
+	tx := todoListOrdering(query.Sort).apply(t.filterTodoLists(DB.WithContext(ctx), query, userId), nil, false)
+	rows, err := tx.Offset(int(query.Offset)).Limit(query.Limit).Rows()
 	helper.Panic(err)
 	defer rows.Close()
 	for rows.Next() {
-		todolist, err := t.scanTodoList(rows)
+		todolist, err := t.scanTodoList(DB, rows)
 		if err != nil {
 			helper.Panic(err)
 			return nil
@@ -53,37 +220,215 @@ func (t *TodolistRepository) GetTodoListsSearch(ctx context.Context, DB *gorm.DB
 	return todolists
 }
 
-func (t *TodolistRepository) GetTodoLists(ctx context.Context, DB *gorm.DB, query web.GetAllValue, userId uuid.UUID) model.TodoLists {
+// CountTodoListsByGroup counts the todolists matching query per value of column, in the order of column.
+func (t *TodolistRepository) CountTodoListsByGroup(ctx context.Context, DB *gorm.DB, query web.TodoListsValue, userId uuid.UUID, column string) model.TodoListGroups {
+	var groups model.TodoListGroups
+	err := t.filterTodoLists(DB.WithContext(ctx), query, userId).
+		Select("CAST(" + column + " AS TEXT) AS key, COUNT(*) AS count").Group(column).Order(column).Find(&groups).Error
+	helper.Panic(err)
+	return groups
+}
+
+// GetTodoListIDs returns the ids of every todolist of userId matching the filters of query, in task_id order.
+func (t *TodolistRepository) GetTodoListIDs(ctx context.Context, DB *gorm.DB, query web.TodoListsValue, userId uuid.UUID) []int {
+	var IDs []int
+	err := t.filterTodoLists(DB.WithContext(ctx), query, userId).Order("task_id").Pluck("task_id", &IDs).Error
+	helper.Panic(err)
+	return IDs
+}
+
+// GetTodoListsAfterID returns up to limit todolists of userId matching query with a task_id above afterID, in task_id
+// order, so that an export can walk them batch by batch.
+func (t *TodolistRepository) GetTodoListsAfterID(ctx context.Context, DB *gorm.DB, query web.TodoListsValue, userId uuid.UUID, afterID int, limit int) model.TodoLists {
 	var todolists model.TodoLists
-	rows, err := DB.WithContext(ctx).Model(&model.TodoList{}).Where("user_id = ?", userId).Offset(int(query.Offset)).Limit(config.Other.Limit).Rows()
+	err := t.filterTodoLists(DB.WithContext(ctx), query, userId).Where("task_id > ?", afterID).Order("task_id").Limit(limit).Find(&todolists).Error
 	helper.Panic(err)
-	defer rows.Close()
-	for rows.Next() {
-		todolist, err := t.scanTodoList(rows)
-		if err != nil {
-			helper.Panic(err)
-			return nil
-		}
-		todolists = append(todolists, todolist)
+	return todolists
+}
+
+// GetDueTodoLists returns the todolists of userId that have a due date, the earliest first.
+func (t *TodolistRepository) GetDueTodoLists(ctx context.Context, DB *gorm.DB, userId uuid.UUID) model.TodoLists {
+	var todolists model.TodoLists
+	err := DB.WithContext(ctx).Model(&model.TodoList{}).Where("user_id = ?", userId).Where("due_date IS NOT NULL").Order("due_date ASC").Order("task_id ASC").Find(&todolists).Error
+	helper.Panic(err)
+	return todolists
+}
+
+// GetTodoListsChangedSince returns the todolists of userId written by a transaction snapshot does not see, all of them
+// for an empty snapshot.
+func (t *TodolistRepository) GetTodoListsChangedSince(ctx context.Context, DB *gorm.DB, userId uuid.UUID, snapshot string) model.TodoLists {
+	var todolists model.TodoLists
+	tx := DB.WithContext(ctx).Model(&model.TodoList{}).Where("user_id = ?", userId)
+	if snapshot != "" {
+		tx = tx.Where("NOT pg_visible_in_snapshot(change_xid, ?::pg_snapshot)", snapshot)
 	}
+	err := tx.Order("task_id ASC").Find(&todolists).Error
+	helper.Panic(err)
 	return todolists
 }
 
+// GetChangeSnapshot returns the snapshot of the transaction of DB, the point GetTodoListsChangedSince later reads
+// changes from. In a repeatable read transaction it is the snapshot every read of the transaction sees.
+func (t *TodolistRepository) GetChangeSnapshot(ctx context.Context, DB *gorm.DB) string {
+	var snapshot string
+	err := DB.WithContext(ctx).Raw("SELECT pg_current_snapshot()::TEXT").Scan(&snapshot).Error
+	helper.Panic(err)
+	return snapshot
+}
+
+// GetTodoListsAfter returns the keyset page that follows query.Cursor together with the cursors around it.
+func (t *TodolistRepository) GetTodoListsAfter(ctx context.Context, DB *gorm.DB, query web.TodoListsValue, userId uuid.UUID) (model.TodoLists, *web.Cursor, *web.Cursor, error) {
+	order := todoListOrdering(query.Sort)
+	var values []interface{}
+	if len(query.Cursor.Values) > 0 {
+		if len(query.Cursor.Values) != len(order.columns) {
+			return nil, nil, nil, web.ErrInvalidCursor
+		}
+		for i, column := range order.columns {
+			value, err := parseTodoListKey(column, query.Cursor.Values[i])
+			if err != nil {
+				return nil, nil, nil, web.ErrInvalidCursor
+			}
+			values = append(values, value)
+		}
+	}
+	var todolists model.TodoLists
+	tx := order.apply(t.filterTodoLists(DB.WithContext(ctx), query, userId), values, query.Cursor.Prev)
+	err := tx.Limit(query.Limit + 1).Find(&todolists).Error
+	helper.Panic(err)
+	todolists, next, prev := keysetPage(todolists, query.Limit, query.Cursor, func(todolist model.TodoList) []string {
+		keys := make([]string, len(order.columns))
+		for i, column := range order.columns {
+			keys[i] = todoListKey(todolist, column, order.desc[i])
+		}
+		return keys
+	})
+	return todolists, next, prev, nil
+}
+
 func (t *TodolistRepository) GetTodoListByID(ctx context.Context, DB *gorm.DB, ID int, userId uuid.UUID) (model.TodoList, error) {
 	var todolist model.TodoList
-	err := DB.WithContext(ctx).Model(&model.TodoList{}).Where("user_id = ?", userId).Where("id = ?", ID).Take(&todolist).Error
+	err := DB.WithContext(ctx).Model(&model.TodoList{}).Where("user_id = ?", userId).Where("task_id = ?", ID).Take(&todolist).Error
 	if err != nil {
 		return model.TodoList{}, err
 	}
 	return todolist, nil
 }
 
-func (t *TodolistRepository) CreateTodoList(ctx context.Context, DB *gorm.DB, todolist model.TodoList) error {
+// GetTodoListByTaskID loads a todolist whoever owns it, callers must check access themselves.
+func (t *TodolistRepository) GetTodoListByTaskID(ctx context.Context, DB *gorm.DB, ID int) (model.TodoList, error) {
+	var todolist model.TodoList
+	err := DB.WithContext(ctx).Model(&model.TodoList{}).Where("task_id = ?", ID).Take(&todolist).Error
+	if err != nil {
+		return model.TodoList{}, err
+	}
+	return todolist, nil
+}
+
+func (t *TodolistRepository) sharedWith(DB *gorm.DB, userId uuid.UUID) *gorm.DB {
+	accepted := DB.Model(&model.Share{}).Where("user_id = ?", userId).Where("status = ?", model.ShareStatusAccepted)
+	return DB.Model(&model.TodoList{}).
+		Where("task_id IN (?) OR project_id IN (?)", accepted.Session(&gorm.Session{}).Select("task_id").Where("task_id IS NOT NULL"), accepted.Session(&gorm.Session{}).Select("project_id").Where("project_id IS NOT NULL"))
+}
+
+func (t *TodolistRepository) GetSharedTodoLists(ctx context.Context, DB *gorm.DB, query web.GetAllValue, userId uuid.UUID) model.TodoLists {
+	var todolists model.TodoLists
+	err := t.sharedWith(DB.WithContext(ctx), userId).Order("task_id ASC").Offset(int(query.Offset)).Limit(query.Limit).Find(&todolists).Error
+	helper.Panic(err)
+	return todolists
+}
+
+func (t *TodolistRepository) CountSharedTodoLists(ctx context.Context, DB *gorm.DB, userId uuid.UUID) int64 {
+	var count int64
+	err := t.sharedWith(DB.WithContext(ctx), userId).Count(&count).Error
+	helper.Panic(err)
+	return count
+}
+
+func (t *TodolistRepository) GetAssignedTodoLists(ctx context.Context, DB *gorm.DB, query web.GetAllValue, userId uuid.UUID) model.TodoLists {
+	var todolists model.TodoLists
+	err := DB.WithContext(ctx).Model(&model.TodoList{}).Where("assignee_id = ?", userId).Order("task_id ASC").Offset(int(query.Offset)).Limit(query.Limit).Find(&todolists).Error
+	helper.Panic(err)
+	return todolists
+}
+
+func (t *TodolistRepository) CountAssignedTodoLists(ctx context.Context, DB *gorm.DB, userId uuid.UUID) int64 {
+	var count int64
+	err := DB.WithContext(ctx).Model(&model.TodoList{}).Where("assignee_id = ?", userId).Count(&count).Error
+	helper.Panic(err)
+	return count
+}
+
+func (t *TodolistRepository) UpdateTodoListAssignee(ctx context.Context, DB *gorm.DB, ID int, assigneeID *uuid.UUID) {
+	err := DB.WithContext(ctx).Model(&model.TodoList{}).Where("task_id = ?", ID).Update("assignee_id", assigneeID).Error
+	helper.Panic(err)
+}
+
+func (t *TodolistRepository) GetTodoListsByProjectID(ctx context.Context, DB *gorm.DB, projectID int) model.TodoLists {
+	var todolists model.TodoLists
+	err := DB.WithContext(ctx).Model(&model.TodoList{}).Where("project_id = ?", projectID).Order("task_id ASC").Find(&todolists).Error
+	helper.Panic(err)
+	return todolists
+}
+
+// appendPositions returns n keys following the last todolist of userId, the list is rebalanced first when they
+// would grow past fractional.RebalanceLength.
+func (t *TodolistRepository) appendPositions(ctx context.Context, DB *gorm.DB, userId uuid.UUID, n int) ([]string, error) {
+	var positions []string
+	err := DB.WithContext(ctx).Model(&model.TodoList{}).Where("user_id = ?", userId).Order("position DESC").Order("task_id DESC").Limit(1).Pluck("position", &positions).Error
+	if err != nil {
+		return nil, err
+	}
+	last := ""
+	if len(positions) > 0 {
+		last = positions[0]
+	}
+	keys := make([]string, n)
+	for rebalanced := false; ; rebalanced = true {
+		previous := last
+		for i := range keys {
+			if keys[i], err = fractional.Between(previous, ""); err != nil {
+				return nil, err
+			}
+			previous = keys[i]
+		}
+		if rebalanced || n == 0 || len(keys[n-1]) <= fractional.RebalanceLength {
+			return keys, nil
+		}
+		if last, err = t.rebalance(ctx, DB, userId); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (t *TodolistRepository) CreateTodoList(ctx context.Context, DB *gorm.DB, todolist model.TodoList) (model.TodoList, error) {
+	if todolist.Position == "" {
+		positions, err := t.appendPositions(ctx, DB, todolist.UserID, 1)
+		if err != nil {
+			return todolist, err
+		}
+		todolist.Position = positions[0]
+	}
 	err := DB.WithContext(ctx).Model(&model.TodoList{}).Create(&todolist).Error
-	return err
+	return todolist, err
 }
 
 func (t *TodolistRepository) CreateTodoLists(ctx context.Context, DB *gorm.DB, todolists model.TodoLists) error {
+	pending := make(map[uuid.UUID][]int)
+	for i, todolist := range todolists {
+		if todolist.Position == "" {
+			pending[todolist.UserID] = append(pending[todolist.UserID], i)
+		}
+	}
+	for userId, indexes := range pending {
+		positions, err := t.appendPositions(ctx, DB, userId, len(indexes))
+		if err != nil {
+			return err
+		}
+		for i, index := range indexes {
+			todolists[index].Position = positions[i]
+		}
+	}
 	var err error
 	if len(todolists) > config.Other.LimitInsert {
 		err = DB.WithContext(ctx).CreateInBatches(&todolists, config.Other.LimitInsert).Error
@@ -94,30 +439,196 @@ func (t *TodolistRepository) CreateTodoLists(ctx context.Context, DB *gorm.DB, t
 }
 
 func (t *TodolistRepository) UpdateTodoListByID(ctx context.Context, DB *gorm.DB, todolist model.TodoList, ID int, userId uuid.UUID) {
-	err := DB.WithContext(ctx).Model(&model.TodoList{}).Where("user_id = ?", userId).Where("id = ?", ID).Updates(&todolist).Error
+	err := DB.WithContext(ctx).Model(&model.TodoList{}).Where("user_id = ?", userId).Where("task_id = ?", ID).Select("project_id", "task_name", "description", "due_date", "due_timed", "due_timezone", "priority", "recurrence", "completed", "status", "started_at", "completed_at").Updates(&todolist).Error
+	helper.Panic(err)
+}
+
+// GetAdjacentPosition returns the position of the todolist right after todolist, or right before it when after is
+// false, in the list of its owner leaving out excludeID. It is empty at either end of the list.
+func (t *TodolistRepository) GetAdjacentPosition(ctx context.Context, DB *gorm.DB, todolist model.TodoList, excludeID int, after bool) string {
+	var positions []string
+	tx := DB.WithContext(ctx).Model(&model.TodoList{}).Where("user_id = ?", todolist.UserID).Where("task_id <> ?", excludeID)
+	if after {
+		tx = tx.Where("position > ? OR (position = ? AND task_id > ?)", todolist.Position, todolist.Position, todolist.TaskID).Order("position ASC").Order("task_id ASC")
+	} else {
+		tx = tx.Where("position < ? OR (position = ? AND task_id < ?)", todolist.Position, todolist.Position, todolist.TaskID).Order("position DESC").Order("task_id DESC")
+	}
+	err := tx.Limit(1).Pluck("position", &positions).Error
+	helper.Panic(err)
+	if len(positions) == 0 {
+		return ""
+	}
+	return positions[0]
+}
+
+func (t *TodolistRepository) UpdateTodoListPosition(ctx context.Context, DB *gorm.DB, ID int, position string) {
+	err := DB.WithContext(ctx).Model(&model.TodoList{}).Where("task_id = ?", ID).Update("position", position).Error
+	helper.Panic(err)
+}
+
+// GetBoardTodoLists returns every todolist of userId in board order.
+func (t *TodolistRepository) GetBoardTodoLists(ctx context.Context, DB *gorm.DB, userId uuid.UUID) model.TodoLists {
+	var todolists model.TodoLists
+	err := DB.WithContext(ctx).Model(&model.TodoList{}).Where("user_id = ?", userId).Order("position ASC").Order("task_id ASC").Find(&todolists).Error
+	helper.Panic(err)
+	return todolists
+}
+
+func (t *TodolistRepository) UpdateTodoListEstimate(ctx context.Context, DB *gorm.DB, ID int, estimate *int) {
+	err := DB.WithContext(ctx).Model(&model.TodoList{}).Where("task_id = ?", ID).Update("estimate_minutes", estimate).Error
+	helper.Panic(err)
+}
+
+func (t *TodolistRepository) UpdateTodoListColumn(ctx context.Context, DB *gorm.DB, ID int, columnID *int) {
+	err := DB.WithContext(ctx).Model(&model.TodoList{}).Where("task_id = ?", ID).Update("column_id", columnID).Error
+	helper.Panic(err)
+}
+
+// rebalance gives the todolists of userId evenly spread short keys in their current order and returns the last one.
+func (t *TodolistRepository) rebalance(ctx context.Context, DB *gorm.DB, userId uuid.UUID) (string, error) {
+	var IDs []int
+	err := DB.WithContext(ctx).Model(&model.TodoList{}).Where("user_id = ?", userId).Order("position ASC").Order("task_id ASC").Pluck("task_id", &IDs).Error
+	if err != nil {
+		return "", err
+	}
+	positions := fractional.Spread(len(IDs))
+	for i, ID := range IDs {
+		if err = DB.WithContext(ctx).Model(&model.TodoList{}).Where("task_id = ?", ID).Update("position", positions[i]).Error; err != nil {
+			return "", err
+		}
+	}
+	if len(positions) == 0 {
+		return "", nil
+	}
+	return positions[len(positions)-1], nil
+}
+
+// RebalanceTodoLists rewrites the positions of the todolists of userId once keys got long or collided, keeping their order.
+func (t *TodolistRepository) RebalanceTodoLists(ctx context.Context, DB *gorm.DB, userId uuid.UUID) {
+	_, err := t.rebalance(ctx, DB, userId)
+	helper.Panic(err)
+}
+
+func (t *TodolistRepository) UpdateTodoListStatus(ctx context.Context, DB *gorm.DB, todolist model.TodoList, ID int, userId uuid.UUID) {
+	err := DB.WithContext(ctx).Model(&model.TodoList{}).Where("user_id = ?", userId).Where("task_id = ?", ID).Select("completed", "status", "started_at", "completed_at").Updates(&todolist).Error
+	helper.Panic(err)
+}
+
+func (t *TodolistRepository) CountTodoListsByStatus(ctx context.Context, DB *gorm.DB, userId uuid.UUID) map[model.TaskStatus]int64 {
+	var groups []struct {
+		Status model.TaskStatus
+		Count  int64
+	}
+	err := DB.WithContext(ctx).Model(&model.TodoList{}).Select("status, COUNT(*) AS count").Where("user_id = ?", userId).Group("status").Scan(&groups).Error
+	helper.Panic(err)
+	counts := make(map[model.TaskStatus]int64, len(groups))
+	for _, group := range groups {
+		counts[group.Status] = group.Count
+	}
+	return counts
+}
+
+// DeleteTodoListByID moves a todolist to the trash, deletedAt is shared by the whole batch so it can be undone at once.
+func (t *TodolistRepository) DeleteTodoListByID(ctx context.Context, DB *gorm.DB, ID int, userId uuid.UUID, deletedAt time.Time) {
+	err := DB.WithContext(ctx).Model(&model.TodoList{}).Where("user_id = ?", userId).Where("task_id = ?", ID).Update("deleted_at", deletedAt).Error
+	helper.Panic(err)
+}
+
+func (t *TodolistRepository) DeleteTodoListsByIDs(ctx context.Context, DB *gorm.DB, IDs []int, userId uuid.UUID, deletedAt time.Time) {
+	err := DB.WithContext(ctx).Model(&model.TodoList{}).Where("user_id = ?", userId).Where("task_id IN ?", IDs).Update("deleted_at", deletedAt).Error
+	helper.Panic(err)
+}
+
+func (t *TodolistRepository) trash(DB *gorm.DB, userId uuid.UUID) *gorm.DB {
+	return DB.Unscoped().Model(&model.TodoList{}).Where("user_id = ?", userId).Where("deleted_at IS NOT NULL")
+}
+
+// GetTodoListIDsTrashedSince returns the ids of the todolists of userId in the trash written by a transaction snapshot
+// does not see.
+func (t *TodolistRepository) GetTodoListIDsTrashedSince(ctx context.Context, DB *gorm.DB, userId uuid.UUID, snapshot string) []int {
+	var IDs []int
+	err := t.trash(DB.WithContext(ctx), userId).Where("NOT pg_visible_in_snapshot(change_xid, ?::pg_snapshot)", snapshot).Order("task_id ASC").Pluck("task_id", &IDs).Error
+	helper.Panic(err)
+	return IDs
+}
+
+func (t *TodolistRepository) GetTrashedTodoLists(ctx context.Context, DB *gorm.DB, query web.GetAllValue, userId uuid.UUID) model.TodoLists {
+	var todolists model.TodoLists
+	err := t.trash(DB.WithContext(ctx), userId).Order("deleted_at DESC").Order("task_id ASC").Offset(int(query.Offset)).Limit(query.Limit).Find(&todolists).Error
+	helper.Panic(err)
+	return todolists
+}
+
+func (t *TodolistRepository) CountTrashedTodoLists(ctx context.Context, DB *gorm.DB, userId uuid.UUID) int64 {
+	var count int64
+	err := t.trash(DB.WithContext(ctx), userId).Count(&count).Error
+	helper.Panic(err)
+	return count
+}
+
+func (t *TodolistRepository) TrashedTodoListsExistByIDs(ctx context.Context, DB *gorm.DB, IDs []int, userId uuid.UUID) bool {
+	var count int64
+	err := t.trash(DB.WithContext(ctx), userId).Where("task_id IN ?", IDs).Count(&count).Error
 	helper.Panic(err)
+	return count == int64(len(IDs))
 }
 
-func (t *TodolistRepository) DeleteTodoListByID(ctx context.Context, DB *gorm.DB, ID int, userId uuid.UUID) {
-	err := DB.WithContext(ctx).Model(&model.TodoList{}).Where("user_id = ?", userId).Where("id = ?", ID).Delete(&model.TodoList{}).Error
+func (t *TodolistRepository) RestoreTodoListsByIDs(ctx context.Context, DB *gorm.DB, IDs []int, userId uuid.UUID) {
+	err := t.trash(DB.WithContext(ctx), userId).Where("task_id IN ?", IDs).Update("deleted_at", nil).Error
 	helper.Panic(err)
 }
 
-func (t *TodolistRepository) DeleteTodoListsByIDs(ctx context.Context, DB *gorm.DB, IDs []int, userId uuid.UUID) {
-	err := DB.WithContext(ctx).Model(&model.TodoList{}).Where("user_id = ?", userId).Where("id IN ?", IDs).Delete(&model.TodoList{}).Error
+// RestoreTodoListsDeletedAt restores the batch of todolists userId deleted at deletedAt and returns how many came back.
+func (t *TodolistRepository) RestoreTodoListsDeletedAt(ctx context.Context, DB *gorm.DB, userId uuid.UUID, deletedAt time.Time) int64 {
+	result := t.trash(DB.WithContext(ctx), userId).Where("deleted_at = ?", deletedAt).Update("deleted_at", nil)
+	helper.Panic(result.Error)
+	return result.RowsAffected
+}
+
+// GetTrashedTodoListIDs returns the todolists in the trash of userId, or of everyone when nil, deleted before
+// deletedBefore when set.
+func (t *TodolistRepository) GetTrashedTodoListIDs(ctx context.Context, DB *gorm.DB, userId *uuid.UUID, deletedBefore *time.Time) []int {
+	var IDs []int
+	tx := DB.WithContext(ctx).Unscoped().Model(&model.TodoList{}).Where("deleted_at IS NOT NULL")
+	if userId != nil {
+		tx = tx.Where("user_id = ?", *userId)
+	}
+	if deletedBefore != nil {
+		tx = tx.Where("deleted_at < ?", *deletedBefore)
+	}
+	err := tx.Order("task_id ASC").Pluck("task_id", &IDs).Error
 	helper.Panic(err)
+	return IDs
+}
+
+// PurgeTodoListsByIDs deletes trashed todolists for good, their comments, attachments and the like go with them.
+func (t *TodolistRepository) PurgeTodoListsByIDs(ctx context.Context, DB *gorm.DB, IDs []int) {
+	err := DB.WithContext(ctx).Unscoped().Where("task_id IN ?", IDs).Where("deleted_at IS NOT NULL").Delete(&model.TodoList{}).Error
+	helper.Panic(err)
+}
+
+// LockTodoListVersion locks the todolist for the rest of the transaction and returns its version, 0 when it does
+// not exist.
+func (t *TodolistRepository) LockTodoListVersion(ctx context.Context, DB *gorm.DB, ID int) int {
+	var versions []int
+	err := DB.WithContext(ctx).Model(&model.TodoList{}).Clauses(clause.Locking{Strength: "UPDATE"}).Where("task_id = ?", ID).Pluck("version", &versions).Error
+	helper.Panic(err)
+	if len(versions) == 0 {
+		return 0
+	}
+	return versions[0]
 }
 
 func (t *TodolistRepository) TodoListExistByID(ctx context.Context, DB *gorm.DB, ID int, userId uuid.UUID) bool {
 	var count int64
-	err := DB.WithContext(ctx).Where("user_id = ?", userId).Where("id = ?", ID).Count(&count).Error
+	err := DB.WithContext(ctx).Model(&model.TodoList{}).Where("user_id = ?", userId).Where("task_id = ?", ID).Count(&count).Error
 	helper.Panic(err)
 	return count == 1
 }
 
 func (t *TodolistRepository) TodoListsExistByIDs(ctx context.Context, DB *gorm.DB, IDs []int, userId uuid.UUID) bool {
 	var count int64
-	err := DB.WithContext(ctx).Where("user_id = ?", userId).Where("id IN ?", IDs).Count(&count).Error
+	err := DB.WithContext(ctx).Model(&model.TodoList{}).Where("user_id = ?", userId).Where("task_id IN ?", IDs).Count(&count).Error
 	helper.Panic(err)
 	return count == int64(len(IDs))
 }
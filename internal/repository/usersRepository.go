@@ -8,7 +8,9 @@ import (
 	"go_gin/internal/domain/model/web"
 	"go_gin/pkg/helper"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"strings"
+	"time"
 )
 
 type UsersRepository struct {
@@ -18,7 +20,7 @@ func (u *UsersRepository) GetUsersBySearch(ctx context.Context, DB *gorm.DB, que
 	var users model.Users
 	valueSearch := []string{"%", query.Search, "%"}
 	key := strings.Join(valueSearch, "")
-	rows, err := DB.WithContext(ctx).Unscoped().Model(&model.User{}).Where("username LIKE ?", key).Where("email LIKE ?", key).Offset(int(query.Offset)).Limit(config.Other.Limit).Rows()
+	rows, err := DB.WithContext(ctx).Unscoped().Model(&model.User{}).Where("username LIKE ?", key).Where("email LIKE ?", key).Offset(int(query.Offset)).Limit(query.Limit).Rows()
 	helper.Panic(err)
 	defer rows.Close()
 	for rows.Next() {
@@ -64,9 +66,19 @@ func (u *UsersRepository) GetUserByEmail(ctx context.Context, DB *gorm.DB, Email
 	return user, nil
 }
 
+func (u *UsersRepository) GetUsersByUsernames(ctx context.Context, DB *gorm.DB, usernames []string) model.Users {
+	var users model.Users
+	if len(usernames) == 0 {
+		return users
+	}
+	err := DB.WithContext(ctx).Model(&model.User{}).Where("username IN ?", usernames).Find(&users).Error
+	helper.Panic(err)
+	return users
+}
+
 func (u *UsersRepository) GetUsers(ctx context.Context, DB *gorm.DB, query web.GetAllValue) model.Users {
 	var users model.Users
-	rows, err := DB.WithContext(ctx).Unscoped().Model(&model.User{}).Offset(int(query.Offset)).Limit(config.Other.Limit).Rows()
+	rows, err := DB.WithContext(ctx).Unscoped().Model(&model.User{}).Order("created_at, id").Offset(int(query.Offset)).Limit(query.Limit).Rows()
 	helper.Panic(err)
 	defer rows.Close()
 	for rows.Next() {
@@ -80,6 +92,30 @@ func (u *UsersRepository) GetUsers(ctx context.Context, DB *gorm.DB, query web.G
 	return users
 }
 
+// GetUsersAfter returns the keyset page of users, oldest first, that follows query.Cursor.
+func (u *UsersRepository) GetUsersAfter(ctx context.Context, DB *gorm.DB, query web.GetAllValue) (model.Users, *web.Cursor, *web.Cursor, error) {
+	order := ordering{exprs: []string{"created_at", "id"}, columns: []string{"created_at", "id"}, desc: []bool{false, false}}
+	var values []interface{}
+	if len(query.Cursor.Values) > 0 {
+		if len(query.Cursor.Values) != 2 {
+			return nil, nil, nil, web.ErrInvalidCursor
+		}
+		createdAt, errTime := time.Parse(time.RFC3339Nano, query.Cursor.Values[0])
+		ID, errID := uuid.Parse(query.Cursor.Values[1])
+		if errTime != nil || errID != nil {
+			return nil, nil, nil, web.ErrInvalidCursor
+		}
+		values = []interface{}{createdAt, ID}
+	}
+	var users model.Users
+	err := order.apply(DB.WithContext(ctx).Unscoped().Model(&model.User{}), values, query.Cursor.Prev).Limit(query.Limit + 1).Find(&users).Error
+	helper.Panic(err)
+	users, next, prev := keysetPage(users, query.Limit, query.Cursor, func(user model.User) []string {
+		return []string{user.CreatedAt.Format(time.RFC3339Nano), user.ID.String()}
+	})
+	return users, next, prev, nil
+}
+
 func (u *UsersRepository) CreateUser(ctx context.Context, DB *gorm.DB, user model.User) error {
 	err := DB.WithContext(ctx).Create(&user).Error
 	if err != nil {
@@ -107,6 +143,18 @@ func (u *UsersRepository) UpdateUserID(ctx context.Context, DB *gorm.DB, user mo
 	helper.Panic(err)
 }
 
+// SearchLanguageExists reports whether language is a text search configuration installed in the database,
+// dialects without full-text search accept any language since it is not used.
+func (u *UsersRepository) SearchLanguageExists(ctx context.Context, DB *gorm.DB, language string) bool {
+	if DB.Dialector.Name() != "postgres" {
+		return true
+	}
+	var count int64
+	err := DB.WithContext(ctx).Table("pg_ts_config").Where("cfgname = ?", language).Count(&count).Error
+	helper.Panic(err)
+	return count > 0
+}
+
 func (u *UsersRepository) DeleteUserByID(ctx context.Context, DB *gorm.DB, ID uuid.UUID) {
 	err := DB.WithContext(ctx).Where("id = ?", ID).Delete(&model.User{}).Error
 	helper.Panic(err)
@@ -129,3 +177,14 @@ func (u *UsersRepository) UsersExistByIDs(ctx context.Context, DB *gorm.DB, IDs
 	helper.Panic(err)
 	return count == int64(len(IDs))
 }
+
+// LockUserVersion locks the user for the rest of the transaction and returns its version, 0 when it does not exist.
+func (u *UsersRepository) LockUserVersion(ctx context.Context, DB *gorm.DB, ID uuid.UUID) int {
+	var versions []int
+	err := DB.WithContext(ctx).Model(&model.User{}).Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", ID).Pluck("version", &versions).Error
+	helper.Panic(err)
+	if len(versions) == 0 {
+		return 0
+	}
+	return versions[0]
+}
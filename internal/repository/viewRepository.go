@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"go_gin/internal/domain/model"
+	"go_gin/pkg/helper"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ViewRepository struct {
+}
+
+func NewViewRepository() *ViewRepository {
+	return &ViewRepository{}
+}
+
+// GetViews returns the views of userID followed by the views shared with them.
+func (v *ViewRepository) GetViews(ctx context.Context, DB *gorm.DB, userID uuid.UUID) model.Views {
+	var views model.Views
+	err := DB.WithContext(ctx).Model(&model.View{}).
+		Where("user_id = ? OR view_id IN (SELECT view_id FROM view_shares WHERE user_id = ?)", userID, userID).
+		Clauses(clause.OrderBy{Expression: clause.Expr{SQL: "user_id = ? DESC, name ASC", Vars: []interface{}{userID}, WithoutParentheses: true}}).
+		Find(&views).Error
+	helper.Panic(err)
+	return views
+}
+
+func (v *ViewRepository) GetViewByID(ctx context.Context, DB *gorm.DB, ID int) (model.View, error) {
+	var view model.View
+	err := DB.WithContext(ctx).Model(&model.View{}).Where("view_id = ?", ID).Take(&view).Error
+	if err != nil {
+		return model.View{}, err
+	}
+	return view, nil
+}
+
+func (v *ViewRepository) CreateView(ctx context.Context, DB *gorm.DB, view model.View) (model.View, error) {
+	err := DB.WithContext(ctx).Model(&model.View{}).Create(&view).Error
+	return view, err
+}
+
+func (v *ViewRepository) UpdateViewByID(ctx context.Context, DB *gorm.DB, view model.View, ID int) error {
+	return DB.WithContext(ctx).Model(&model.View{}).Where("view_id = ?", ID).Select("name", "query", "sort", "group_by").Updates(&view).Error
+}
+
+func (v *ViewRepository) DeleteViewByID(ctx context.Context, DB *gorm.DB, ID int) {
+	err := DB.WithContext(ctx).Where("view_id = ?", ID).Delete(&model.View{}).Error
+	helper.Panic(err)
+}
+
+func (v *ViewRepository) GetViewShares(ctx context.Context, DB *gorm.DB, ID int) model.ViewShares {
+	var shares model.ViewShares
+	err := DB.WithContext(ctx).Model(&model.ViewShare{}).Where("view_id = ?", ID).Order("created_at ASC").Find(&shares).Error
+	helper.Panic(err)
+	return shares
+}
+
+func (v *ViewRepository) CreateViewShare(ctx context.Context, DB *gorm.DB, share model.ViewShare) error {
+	return DB.WithContext(ctx).Model(&model.ViewShare{}).Create(&share).Error
+}
+
+func (v *ViewRepository) DeleteViewShare(ctx context.Context, DB *gorm.DB, ID int, userID uuid.UUID) int64 {
+	result := DB.WithContext(ctx).Where("view_id = ?", ID).Where("user_id = ?", userID).Delete(&model.ViewShare{})
+	helper.Panic(result.Error)
+	return result.RowsAffected
+}
+
+func (v *ViewRepository) ViewSharedWith(ctx context.Context, DB *gorm.DB, ID int, userID uuid.UUID) bool {
+	var count int64
+	err := DB.WithContext(ctx).Model(&model.ViewShare{}).Where("view_id = ?", ID).Where("user_id = ?", userID).Count(&count).Error
+	helper.Panic(err)
+	return count == 1
+}
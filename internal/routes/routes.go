@@ -10,9 +10,25 @@ import (
 )
 
 type Routes struct {
-	Controller model.UsersController
-	Middleware *middleware.Middleware
-	TodoList   *controller.TodoListController
+	Controller   model.UsersController
+	Middleware   *middleware.Middleware
+	TodoList     *controller.TodoListController
+	Comment      *controller.CommentController
+	Notification *controller.NotificationController
+	Attachment   *controller.AttachmentController
+	Project      *controller.ProjectController
+	Share        *controller.ShareController
+	Dependency   *controller.DependencyController
+	Tag          *controller.TagController
+	SavedSearch  *controller.SavedSearchController
+	View         *controller.ViewController
+	Board        *controller.BoardController
+	Template     *controller.TemplateController
+	Time         *controller.TimeController
+	Calendar     *controller.CalendarController
+	CalDAV       *controller.CalDAVController
+	QuickAdd     *controller.QuickAddController
+	Statistics   *controller.StatisticsController
 }
 
 func (r *Routes) Run() *gin.Engine {
@@ -32,6 +48,7 @@ func (r *Routes) Run() *gin.Engine {
 	admin.PATCH("/users", r.Middleware.Authentication, r.Middleware.AuthorizationRoleAdmin, r.Controller.RestoreUsersByIDs)
 	admin.DELETE("/user/:id", r.Middleware.Authentication, r.Middleware.AuthorizationRoleAdmin, r.Controller.DeleteUserByID)
 	admin.DELETE("/users", r.Middleware.Authentication, r.Middleware.AuthorizationRoleAdmin, r.Controller.DeleteUsersByIDs)
+	admin.GET("/stats", r.Middleware.Authentication, r.Middleware.AuthorizationRoleAdmin, r.Statistics.GetAllStatistics)
 
 	moderator.GET("/users", r.Middleware.Authentication, r.Middleware.AuthorizationRoleModerator, r.Middleware.IsLogin, r.Controller.GetAll)
 	moderator.POST("/registers", r.Middleware.Authentication, r.Middleware.AuthorizationRoleModerator, r.Middleware.IsLogin, r.Controller.CreateUsers)
@@ -44,9 +61,129 @@ func (r *Routes) Run() *gin.Engine {
 	api.GET("/user/:id/todolist", r.Middleware.IsLogin, r.TodoList.GetTodoListByID)
 	api.POST("/user/:id/todolist", r.Middleware.IsLogin, r.TodoList.CreateTodoList)
 	api.POST("/user/:id/todolists", r.Middleware.IsLogin, r.TodoList.CreatesTodoLists)
+	api.POST("/user/:id/todolists/import", r.Middleware.IsLogin, r.TodoList.ImportTodoLists)
+	api.GET("/user/:id/todolists/export", r.Middleware.IsLogin, r.TodoList.ExportTodoLists)
+	api.GET("/user/:id/todolists/status", r.Middleware.IsLogin, r.TodoList.GetTodoListGroupByStatus)
 	api.PUT("/user/:id/todolist", r.Middleware.IsLogin, r.TodoList.UpdateTodoList)
+	api.PATCH("/user/:id/todolist/status", r.Middleware.IsLogin, r.TodoList.UpdateTodoListStatus)
+	api.PATCH("/user/:id/todolists", r.Middleware.IsLogin, r.TodoList.UpdateTodoLists)
 	api.DELETE("/user/:id/todolist", r.Middleware.IsLogin, r.TodoList.DeleteTodoList)
 	api.DELETE("/user/:id/todolists", r.Middleware.IsLogin, r.TodoList.DeleteTodoLists)
+	api.GET("/user/:id/todolists/shared", r.Middleware.IsLogin, r.TodoList.GetSharedTodoLists)
+	api.GET("/user/:id/todolists/assigned", r.Middleware.IsLogin, r.TodoList.GetAssignedTodoLists)
+	api.PATCH("/user/:id/todolist/assignee", r.Middleware.IsLogin, r.TodoList.AssignTodoList)
+	api.GET("/user/:id/todolist/assignments", r.Middleware.IsLogin, r.TodoList.GetAssignments)
+	api.PATCH("/user/:id/todolist/position", r.Middleware.IsLogin, r.TodoList.MoveTodoList)
+	api.GET("/user/:id/todolist/revisions", r.Middleware.IsLogin, r.TodoList.GetRevisions)
+	api.POST("/user/:id/todolist/revert", r.Middleware.IsLogin, r.TodoList.RevertTodoList)
+
+	//trash
+	api.GET("/user/:id/trash", r.Middleware.IsLogin, r.TodoList.GetTrashedTodoLists)
+	api.DELETE("/user/:id/trash", r.Middleware.IsLogin, r.TodoList.EmptyTrash)
+	api.PATCH("/user/:id/todolist/restore", r.Middleware.IsLogin, r.TodoList.RestoreTodoList)
+	api.PATCH("/user/:id/todolists/restore", r.Middleware.IsLogin, r.TodoList.RestoreTodoLists)
+	api.POST("/user/:id/todolists/undo", r.Middleware.IsLogin, r.TodoList.UndoDeleteTodoLists)
+	//tag & saved search
+	api.GET("/user/:id/todolist/tags", r.Middleware.IsLogin, r.Tag.GetTags)
+	api.PUT("/user/:id/todolist/tags", r.Middleware.IsLogin, r.Tag.UpdateTags)
+	api.GET("/user/:id/searches", r.Middleware.IsLogin, r.SavedSearch.GetSavedSearches)
+	api.POST("/user/:id/search", r.Middleware.IsLogin, r.SavedSearch.CreateSavedSearch)
+	api.PUT("/user/:id/search", r.Middleware.IsLogin, r.SavedSearch.UpdateSavedSearch)
+	api.DELETE("/user/:id/search", r.Middleware.IsLogin, r.SavedSearch.DeleteSavedSearch)
+	//view
+	api.GET("/user/:id/views", r.Middleware.IsLogin, r.View.GetViews)
+	api.POST("/user/:id/view", r.Middleware.IsLogin, r.View.CreateView)
+	api.PUT("/user/:id/view", r.Middleware.IsLogin, r.View.UpdateView)
+	api.DELETE("/user/:id/view", r.Middleware.IsLogin, r.View.DeleteView)
+	api.GET("/user/:id/view/shares", r.Middleware.IsLogin, r.View.GetViewShares)
+	api.POST("/user/:id/view/shares", r.Middleware.IsLogin, r.View.ShareView)
+	api.DELETE("/user/:id/view/share", r.Middleware.IsLogin, r.View.UnshareView)
+	api.GET("/user/:id/view/todolists", r.Middleware.IsLogin, r.View.GetViewTodoLists)
+	//board
+	api.GET("/user/:id/board", r.Middleware.IsLogin, r.Board.GetBoard)
+	api.POST("/user/:id/board/column", r.Middleware.IsLogin, r.Board.CreateColumn)
+	api.PUT("/user/:id/board/column", r.Middleware.IsLogin, r.Board.UpdateColumn)
+	api.DELETE("/user/:id/board/column", r.Middleware.IsLogin, r.Board.DeleteColumn)
+	api.PATCH("/user/:id/board/card", r.Middleware.IsLogin, r.Board.MoveCard)
+	//template
+	api.GET("/user/:id/templates", r.Middleware.IsLogin, r.Template.GetTemplates)
+	api.GET("/user/:id/template", r.Middleware.IsLogin, r.Template.GetTemplateByID)
+	api.POST("/user/:id/template", r.Middleware.IsLogin, r.Template.CreateTemplate)
+	api.PUT("/user/:id/template", r.Middleware.IsLogin, r.Template.UpdateTemplate)
+	api.DELETE("/user/:id/template", r.Middleware.IsLogin, r.Template.DeleteTemplate)
+	api.POST("/user/:id/template/instantiate", r.Middleware.IsLogin, r.Template.InstantiateTemplate)
+	//time
+	api.POST("/user/:id/todolist/timer/start", r.Middleware.IsLogin, r.Time.StartTimer)
+	api.POST("/user/:id/timer/stop", r.Middleware.IsLogin, r.Time.StopTimer)
+	api.GET("/user/:id/timer", r.Middleware.IsLogin, r.Time.GetRunningTimer)
+	api.GET("/user/:id/todolist/time", r.Middleware.IsLogin, r.Time.GetTimeSummary)
+	api.POST("/user/:id/todolist/time", r.Middleware.IsLogin, r.Time.CreateTimeEntry)
+	api.DELETE("/user/:id/time/entry", r.Middleware.IsLogin, r.Time.DeleteTimeEntry)
+	api.PUT("/user/:id/todolist/estimate", r.Middleware.IsLogin, r.Time.UpdateEstimate)
+	api.GET("/user/:id/time/report", r.Middleware.IsLogin, r.Time.GetTimeReport)
+
+	//quick add
+	api.POST("/user/:id/todolist/quick", r.Middleware.IsLogin, r.QuickAdd.QuickAddTodoList)
+
+	//statistics
+	api.GET("/user/:id/stats", r.Middleware.IsLogin, r.Statistics.GetStatistics)
+
+	//calendar
+	api.POST("/user/:id/calendar/token", r.Middleware.IsLogin, r.Calendar.CreateCalendarToken)
+	api.DELETE("/user/:id/calendar/token", r.Middleware.IsLogin, r.Calendar.DeleteCalendarToken)
+	api.GET("/calendar/:token", r.Calendar.GetCalendarFeed)
+
+	//caldav
+	router.GET("/.well-known/caldav", r.CalDAV.WellKnown)
+	router.Handle("PROPFIND", "/.well-known/caldav", r.CalDAV.WellKnown)
+	router.OPTIONS("/dav/*path", r.CalDAV.Options)
+	dav := router.Group("/dav", r.Middleware.DAVAuthentication)
+	dav.Handle("PROPFIND", "/", r.CalDAV.PropfindRoot)
+	dav.Handle("PROPFIND", "/:id/", r.CalDAV.PropfindHome)
+	dav.Handle("PROPFIND", "/:id/todolist/", r.CalDAV.PropfindCollection)
+	dav.Handle("REPORT", "/:id/todolist/", r.CalDAV.Report)
+	dav.Handle("PROPFIND", "/:id/todolist/:name", r.CalDAV.PropfindObject)
+	dav.GET("/:id/todolist/:name", r.CalDAV.GetObject)
+	dav.HEAD("/:id/todolist/:name", r.CalDAV.GetObject)
+	dav.PUT("/:id/todolist/:name", r.CalDAV.PutObject)
+	dav.DELETE("/:id/todolist/:name", r.CalDAV.DeleteObject)
+
+	//project
+	api.GET("/user/:id/projects", r.Middleware.IsLogin, r.Project.GetProjects)
+	api.POST("/user/:id/project", r.Middleware.IsLogin, r.Project.CreateProject)
+	api.PUT("/user/:id/project", r.Middleware.IsLogin, r.Project.UpdateProject)
+	api.DELETE("/user/:id/project", r.Middleware.IsLogin, r.Project.DeleteProject)
+
+	//dependency
+	api.GET("/user/:id/todolist/dependencies", r.Middleware.IsLogin, r.Dependency.GetDependencies)
+	api.POST("/user/:id/todolist/dependency", r.Middleware.IsLogin, r.Dependency.CreateDependency)
+	api.DELETE("/user/:id/todolist/dependency", r.Middleware.IsLogin, r.Dependency.DeleteDependency)
+	api.GET("/user/:id/project/plan", r.Middleware.IsLogin, r.Dependency.GetProjectPlan)
+
+	//share
+	api.GET("/user/:id/shares", r.Middleware.IsLogin, r.Share.GetSharesReceived)
+	api.GET("/user/:id/shares/sent", r.Middleware.IsLogin, r.Share.GetSharesSent)
+	api.POST("/user/:id/shares", r.Middleware.IsLogin, r.Share.CreateShare)
+	api.PATCH("/user/:id/share", r.Middleware.IsLogin, r.Share.RespondShare)
+	api.DELETE("/user/:id/share", r.Middleware.IsLogin, r.Share.DeleteShare)
+
+	//comment & activity
+	api.GET("/user/:id/todolist/comments", r.Middleware.IsLogin, r.Comment.GetComments)
+	api.POST("/user/:id/todolist/comment", r.Middleware.IsLogin, r.Comment.CreateComment)
+	api.PUT("/user/:id/todolist/comment", r.Middleware.IsLogin, r.Comment.UpdateComment)
+	api.DELETE("/user/:id/todolist/comment", r.Middleware.IsLogin, r.Comment.DeleteComment)
+	api.GET("/user/:id/todolist/comment/history", r.Middleware.IsLogin, r.Comment.GetCommentHistory)
+	api.GET("/user/:id/todolist/activity", r.Middleware.IsLogin, r.Comment.GetActivities)
+
+	//attachment
+	api.GET("/user/:id/todolist/attachments", r.Middleware.IsLogin, r.Attachment.GetAttachments)
+	api.POST("/user/:id/todolist/attachment", r.Middleware.IsLogin, r.Attachment.CreateAttachment)
+	api.GET("/user/:id/todolist/attachment", r.Middleware.IsLogin, r.Attachment.DownloadAttachment)
+	api.DELETE("/user/:id/todolist/attachment", r.Middleware.IsLogin, r.Attachment.DeleteAttachment)
+
+	//notification
+	api.GET("/user/:id/notifications", r.Middleware.IsLogin, r.Notification.GetNotifications)
+	api.PATCH("/user/:id/notifications", r.Middleware.IsLogin, r.Notification.ReadNotifications)
 
 	//users
 	api.GET("/auth", r.Middleware.Authentication, r.Middleware.AuthorizationAllRole)
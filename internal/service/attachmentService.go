@@ -0,0 +1,266 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"go_gin/internal/config"
+	"go_gin/internal/domain/model"
+	"go_gin/internal/domain/model/web"
+	"go_gin/internal/exception"
+	"go_gin/pkg/storage"
+	"gorm.io/gorm"
+	"io"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+)
+
+type AttachmentService struct {
+	DB         *gorm.DB
+	Validator  *validator.Validate
+	Repository model.AttachmentRepository
+	Access     *TodoListAccess
+	Storage    storage.Storage
+}
+
+func NewAttachmentService(DB *gorm.DB, validator *validator.Validate, repository model.AttachmentRepository, access *TodoListAccess, storage storage.Storage) *AttachmentService {
+	return &AttachmentService{DB: DB, Validator: validator, Repository: repository, Access: access, Storage: storage}
+}
+
+// removeBlobs deletes the stored files of attachments whose rows are already gone, logging the ones that fail.
+func removeBlobs(ctx context.Context, store storage.Storage, attachments model.Attachments) {
+	for _, attachment := range attachments {
+		if err := store.Delete(ctx, attachment.StorageKey); err != nil {
+			log.Printf("attachment %v: clean up blob %s failed: %s", attachment.AttachmentID, attachment.StorageKey, err.Error())
+		}
+	}
+}
+
+func isAllowedType(mediaType string) bool {
+	if len(config.Storage.AllowedTypes) == 0 {
+		return true
+	}
+	for _, allowed := range config.Storage.AllowedTypes {
+		if allowed == mediaType {
+			return true
+		}
+	}
+	return false
+}
+
+// sniffContentType detects the media type of file from its content instead of trusting the client header.
+func sniffContentType(file multipart.File) (string, error) {
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	mediaType, _, err := mime.ParseMediaType(http.DetectContentType(head[:n]))
+	return mediaType, err
+}
+
+func (a *AttachmentService) parseTodoListID(ctx context.Context, tx *gorm.DB, params web.Params, permission model.SharePermission) (value *web.TodoListByIDValue, errService error) {
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	queryParams, ok := params.Query.(web.TodoListByIDQuery)
+	if !ok {
+		errService = exception.NewError(fmt.Errorf("error parsing get by id query params"), exception.ErrorInternalServer)
+		return
+	}
+	validationQueryParams := a.Validator.Struct(queryParams)
+	if validationQueryParams != nil {
+		errService = exception.NewError(validationQueryParams, exception.ErrorBadRequest)
+		return
+	}
+	value, errParsing := queryParams.ToValue()
+	if errParsing != nil {
+		errService = exception.NewError(errParsing, exception.ErrorInternalServer)
+		return
+	}
+	if _, errAccess := a.Access.Authorize(ctx, tx, value.ID, userID, permission); errAccess != nil {
+		errService = errAccess
+		return
+	}
+	return
+}
+
+func (a *AttachmentService) findAttachment(ctx context.Context, tx *gorm.DB, params web.Params, permission model.SharePermission) (attachment model.Attachment, errService error) {
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	queryParams, ok := params.Query.(web.AttachmentByIDQuery)
+	if !ok {
+		errService = exception.NewError(fmt.Errorf("error parsing attachment query params"), exception.ErrorInternalServer)
+		return
+	}
+	validationQueryParams := a.Validator.Struct(queryParams)
+	if validationQueryParams != nil {
+		errService = exception.NewError(validationQueryParams, exception.ErrorBadRequest)
+		return
+	}
+	value, errParsing := queryParams.ToValue()
+	if errParsing != nil {
+		errService = exception.NewError(errParsing, exception.ErrorInternalServer)
+		return
+	}
+	if _, errAccess := a.Access.Authorize(ctx, tx, value.ID, userID, permission); errAccess != nil {
+		errService = errAccess
+		return
+	}
+	attachment, errNotFound := a.Repository.GetAttachmentByID(ctx, tx, value.AttachmentID, value.ID)
+	if errNotFound != nil {
+		errService = exception.NewError(fmt.Errorf("attachment with id %v not found", value.AttachmentID), exception.ErrorNotFound)
+		return
+	}
+	return
+}
+
+func (a *AttachmentService) FindAttachments(ctx context.Context, params web.Params) (responses model.AttachmentResponses, errService error) {
+	tx := a.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	value, errTodoList := a.parseTodoListID(ctx, tx, params, model.PermissionViewer)
+	if errTodoList != nil {
+		tx.Rollback()
+		errService = errTodoList
+		return
+	}
+	responses = a.Repository.GetAttachmentsByTaskIDs(ctx, tx, []int{value.ID}).ToAttachmentResponses()
+	tx.Commit()
+	return
+}
+
+func (a *AttachmentService) CreateAttachment(ctx context.Context, file *multipart.FileHeader, params web.Params) (response model.AttachmentResponse, errService error) {
+	tx := a.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	value, errTodoList := a.parseTodoListID(ctx, tx, params, model.PermissionEditor)
+	if errTodoList != nil {
+		tx.Rollback()
+		errService = errTodoList
+		return
+	}
+	if file == nil {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("file is required"), exception.ErrorBadRequest)
+		return
+	}
+	if config.Storage.MaxSize > 0 && file.Size > config.Storage.MaxSize {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("file is larger than %v bytes", config.Storage.MaxSize), exception.ErrorBadRequest)
+		return
+	}
+	src, errOpen := file.Open()
+	if errOpen != nil {
+		tx.Rollback()
+		errService = exception.NewError(errOpen, exception.ErrorBadRequest)
+		return
+	}
+	defer src.Close()
+	contentType, errSniff := sniffContentType(src)
+	if errSniff != nil {
+		tx.Rollback()
+		errService = exception.NewError(errSniff, exception.ErrorBadRequest)
+		return
+	}
+	if !isAllowedType(contentType) {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("file type %s is not allowed", contentType), exception.ErrorBadRequest)
+		return
+	}
+	userID, _ := params.UserID.ToUUID()
+	hash := sha256.New()
+	key := fmt.Sprintf("todolist/%d/%s", value.ID, uuid.New())
+	errPut := a.Storage.Put(ctx, key, io.TeeReader(src, hash), file.Size, contentType)
+	if errPut != nil {
+		tx.Rollback()
+		errService = exception.NewError(errPut, exception.ErrorInternalServer)
+		return
+	}
+	attachment, errCreate := a.Repository.CreateAttachment(ctx, tx, model.Attachment{
+		TaskID:      value.ID,
+		UserID:      userID,
+		FileName:    filepath.Base(filepath.Clean("/" + file.Filename)),
+		ContentType: contentType,
+		Size:        file.Size,
+		Checksum:    hex.EncodeToString(hash.Sum(nil)),
+		StorageKey:  key,
+	})
+	if errCreate != nil {
+		tx.Rollback()
+		removeBlobs(ctx, a.Storage, model.Attachments{{StorageKey: key}})
+		errService = exception.NewError(errCreate, exception.ErrorInternalServer)
+		return
+	}
+	tx.Commit()
+	response = *attachment.ToAttachmentResponse()
+	return
+}
+
+func (a *AttachmentService) OpenAttachment(ctx context.Context, params web.Params) (attachment model.Attachment, reader io.ReadSeekCloser, errService error) {
+	tx := a.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	attachment, errService = a.findAttachment(ctx, tx, params, model.PermissionViewer)
+	if errService != nil {
+		tx.Rollback()
+		return
+	}
+	tx.Commit()
+	reader, errOpen := a.Storage.Open(ctx, attachment.StorageKey, attachment.Size)
+	if errOpen != nil {
+		errService = exception.NewError(errOpen, exception.ErrorInternalServer)
+		return
+	}
+	return
+}
+
+func (a *AttachmentService) DeleteAttachment(ctx context.Context, params web.Params) (errService error) {
+	tx := a.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	attachment, errAttachment := a.findAttachment(ctx, tx, params, model.PermissionEditor)
+	if errAttachment != nil {
+		tx.Rollback()
+		errService = errAttachment
+		return
+	}
+	a.Repository.DeleteAttachmentByID(ctx, tx, attachment.AttachmentID)
+	tx.Commit()
+	removeBlobs(ctx, a.Storage, model.Attachments{attachment})
+	return
+}
@@ -0,0 +1,332 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"go_gin/internal/domain/model"
+	"go_gin/internal/domain/model/web"
+	"go_gin/internal/exception"
+	"go_gin/pkg/fractional"
+	"gorm.io/gorm"
+)
+
+// BoardService serves the kanban board, moving cards goes through TodoList for the workflow, blockers and ordering.
+type BoardService struct {
+	DB         *gorm.DB
+	Validator  *validator.Validate
+	Repository model.BoardRepository
+	TodoList   *TodoListService
+}
+
+func NewBoardService(DB *gorm.DB, validator *validator.Validate, repository model.BoardRepository, todolist *TodoListService) *BoardService {
+	return &BoardService{DB: DB, Validator: validator, Repository: repository, TodoList: todolist}
+}
+
+// ensureColumns returns the columns of userID, a board used for the first time gets one column per workflow status.
+func (b *BoardService) ensureColumns(ctx context.Context, tx *gorm.DB, userID uuid.UUID) (model.BoardColumns, error) {
+	columns := b.Repository.GetColumns(ctx, tx, userID)
+	if len(columns) > 0 {
+		return columns, nil
+	}
+	statuses := b.TodoList.Workflow.Statuses
+	positions := fractional.Spread(len(statuses))
+	for i := range statuses {
+		status := statuses[i]
+		columns = append(columns, model.BoardColumn{UserID: userID, Name: string(status), Status: &status, Position: positions[i]})
+	}
+	return b.Repository.CreateColumns(ctx, tx, columns)
+}
+
+func (b *BoardService) validateColumn(request model.BoardColumnRequest) error {
+	badRequest := b.Validator.Struct(request)
+	if badRequest != nil {
+		return exception.NewError(badRequest, exception.ErrorBadRequest)
+	}
+	if request.Status != nil && !b.TodoList.Workflow.IsValid(*request.Status) {
+		return exception.NewError(fmt.Errorf("status %v is not a valid status", *request.Status), exception.ErrorBadRequest)
+	}
+	return nil
+}
+
+func (b *BoardService) parseColumnID(ctx context.Context, tx *gorm.DB, params web.Params) (column model.BoardColumn, errService error) {
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	queryParams, ok := params.Query.(web.BoardColumnByIDQuery)
+	if !ok {
+		errService = exception.NewError(fmt.Errorf("error parsing board column query params"), exception.ErrorInternalServer)
+		return
+	}
+	validationQueryParams := b.Validator.Struct(queryParams)
+	if validationQueryParams != nil {
+		errService = exception.NewError(validationQueryParams, exception.ErrorBadRequest)
+		return
+	}
+	ID, errParsing := queryParams.ToValue()
+	if errParsing != nil {
+		errService = exception.NewError(errParsing, exception.ErrorInternalServer)
+		return
+	}
+	column, errNotFound := b.Repository.GetColumnByID(ctx, tx, ID, userID)
+	if errNotFound != nil {
+		errService = exception.NewError(fmt.Errorf("board column with id %v not found", ID), exception.ErrorNotFound)
+	}
+	return
+}
+
+func (b *BoardService) FindBoard(ctx context.Context, params web.Params) (response model.BoardResponse, errService error) {
+	tx := b.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	columns, errColumns := b.ensureColumns(ctx, tx, userID)
+	if errColumns != nil {
+		tx.Rollback()
+		errService = exception.NewError(errColumns, exception.ErrorInternalServer)
+		return
+	}
+	cards, unplaced := model.PlaceCards(columns, b.TodoList.Repository.GetBoardTodoLists(ctx, tx, userID))
+	tx.Commit()
+	for _, column := range columns {
+		response.Columns = append(response.Columns, *column.ToBoardColumnResponse(cards[column.ColumnID]))
+	}
+	response.Unplaced = unplaced.ToTodoListResponses()
+	if response.Unplaced == nil {
+		response.Unplaced = model.TodoListResponses{}
+	}
+	return
+}
+
+// CreateColumn adds a column at the right end of the board.
+func (b *BoardService) CreateColumn(ctx context.Context, request model.BoardColumnRequest, params web.Params) (response model.BoardColumnResponse, errService error) {
+	tx := b.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	if errService = b.validateColumn(request); errService != nil {
+		tx.Rollback()
+		return
+	}
+	columns, errColumns := b.ensureColumns(ctx, tx, userID)
+	if errColumns != nil {
+		tx.Rollback()
+		errService = exception.NewError(errColumns, exception.ErrorInternalServer)
+		return
+	}
+	column := request.ToBoardColumn(userID)
+	position, errKey := fractional.Between(columns[len(columns)-1].Position, "")
+	if errKey != nil {
+		tx.Rollback()
+		errService = exception.NewError(errKey, exception.ErrorInternalServer)
+		return
+	}
+	column.Position = position
+	created, errConflict := b.Repository.CreateColumns(ctx, tx, model.BoardColumns{*column})
+	if errConflict != nil {
+		tx.Rollback()
+		errService = exception.NewError(errConflict, exception.ErrorConflict)
+		return
+	}
+	tx.Commit()
+	response = *created[0].ToBoardColumnResponse(nil)
+	return
+}
+
+func (b *BoardService) UpdateColumn(ctx context.Context, request model.BoardColumnRequest, params web.Params) (errService error) {
+	tx := b.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	if errService = b.validateColumn(request); errService != nil {
+		tx.Rollback()
+		return
+	}
+	column, errColumn := b.parseColumnID(ctx, tx, params)
+	if errColumn != nil {
+		tx.Rollback()
+		errService = errColumn
+		return
+	}
+	if errConflict := b.Repository.UpdateColumnByID(ctx, tx, *request.ToBoardColumn(column.UserID), column.ColumnID, column.UserID); errConflict != nil {
+		tx.Rollback()
+		errService = exception.NewError(errConflict, exception.ErrorConflict)
+		return
+	}
+	tx.Commit()
+	return
+}
+
+// DeleteColumn removes a column, its cards go back to the column of their status.
+func (b *BoardService) DeleteColumn(ctx context.Context, params web.Params) (errService error) {
+	tx := b.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	column, errColumn := b.parseColumnID(ctx, tx, params)
+	if errColumn != nil {
+		tx.Rollback()
+		errService = errColumn
+		return
+	}
+	b.Repository.DeleteColumnByID(ctx, tx, column.ColumnID, column.UserID)
+	tx.Commit()
+	return
+}
+
+// MoveCard moves a todolist into a column at an index of its cards in one transaction: the status follows the
+// column when it is backed by one, the WIP limit of the column is enforced and only the card is repositioned. An
+// If-Match is checked against the version of the todolist.
+func (b *BoardService) MoveCard(ctx context.Context, request model.BoardCardMoveRequest, params web.Params) (response model.TodoListResponse, errService error) {
+	tx := b.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	badRequest := b.Validator.Struct(request)
+	if badRequest != nil {
+		tx.Rollback()
+		errService = exception.NewError(badRequest, exception.ErrorBadRequest)
+		return
+	}
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	queryParams, ok := params.Query.(web.TodoListByIDQuery)
+	if !ok {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("error parsing get by id query params"), exception.ErrorInternalServer)
+		return
+	}
+	validationQueryParams := b.Validator.Struct(queryParams)
+	if validationQueryParams != nil {
+		tx.Rollback()
+		errService = exception.NewError(validationQueryParams, exception.ErrorBadRequest)
+		return
+	}
+	value, errParsing := queryParams.ToValue()
+	if errParsing != nil {
+		tx.Rollback()
+		errService = exception.NewError(errParsing, exception.ErrorInternalServer)
+		return
+	}
+	// the lock comes first so that the todolist checked below is the one the move is made against
+	locked := b.TodoList.Repository.LockTodoListVersion(ctx, tx, value.ID)
+	todolist, errNotFound := b.TodoList.Repository.GetTodoListByID(ctx, tx, value.ID, userID)
+	if errNotFound != nil {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("todolist with id %v not found", value.ID), exception.ErrorNotFound)
+		return
+	}
+	if errMatch := checkIfMatch(params.IfMatch, locked, fmt.Sprintf("todolist with id %v", value.ID)); errMatch != nil {
+		tx.Rollback()
+		errService = errMatch
+		return
+	}
+	columns, errColumns := b.ensureColumns(ctx, tx, userID)
+	if errColumns != nil {
+		tx.Rollback()
+		errService = exception.NewError(errColumns, exception.ErrorInternalServer)
+		return
+	}
+	// the column stays locked until the commit so that concurrent moves into it are counted one after the other
+	column, errColumn := b.Repository.LockColumnByID(ctx, tx, request.ColumnID, userID)
+	if errColumn != nil {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("board column with id %v not found", request.ColumnID), exception.ErrorNotFound)
+		return
+	}
+	cards, _ := model.PlaceCards(columns, b.TodoList.Repository.GetBoardTodoLists(ctx, tx, userID))
+	var others model.TodoLists
+	for _, card := range cards[column.ColumnID] {
+		if card.TaskID != todolist.TaskID {
+			others = append(others, card)
+		}
+	}
+	entering := len(others) == len(cards[column.ColumnID])
+	if entering && column.WipLimit != nil && len(others) >= *column.WipLimit {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("board column %v is at its WIP limit of %v", column.Name, *column.WipLimit), exception.ErrorConflict)
+		return
+	}
+	if column.Status != nil && *column.Status != todolist.Status {
+		moved := todolist
+		if errStatus := b.TodoList.transition(&moved, todolist, *column.Status, false); errStatus != nil {
+			tx.Rollback()
+			errService = exception.NewError(errStatus, exception.ErrorBadRequest)
+			return
+		}
+		if errBlocked := b.TodoList.checkBlockers(ctx, tx, moved, todolist); errBlocked != nil {
+			tx.Rollback()
+			errService = exception.NewError(errBlocked, exception.ErrorConflict)
+			return
+		}
+		b.TodoList.Repository.UpdateTodoListStatus(ctx, tx, moved, todolist.TaskID, todolist.UserID)
+		if errRecord := b.TodoList.record(ctx, tx, todolist, moved, userID); errRecord != nil {
+			tx.Rollback()
+			errService = exception.NewError(errRecord, exception.ErrorInternalServer)
+			return
+		}
+	}
+	b.TodoList.Repository.UpdateTodoListColumn(ctx, tx, todolist.TaskID, &column.ColumnID)
+	if len(others) > 0 {
+		index := min(request.Index, len(others))
+		var errPlace error
+		if index == 0 {
+			errPlace = b.TodoList.placeNextTo(ctx, tx, todolist, others[0].TaskID, false)
+		} else {
+			errPlace = b.TodoList.placeNextTo(ctx, tx, todolist, others[index-1].TaskID, true)
+		}
+		if errPlace != nil {
+			tx.Rollback()
+			errService = errPlace
+			return
+		}
+	}
+	moved, errMoved := b.TodoList.Repository.GetTodoListByTaskID(ctx, tx, todolist.TaskID)
+	if errMoved != nil {
+		tx.Rollback()
+		errService = exception.NewError(errMoved, exception.ErrorInternalServer)
+		return
+	}
+	tx.Commit()
+	response = *moved.ToTodoListResponse()
+	return
+}
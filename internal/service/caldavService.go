@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"go_gin/internal/config"
+	"go_gin/internal/domain/model"
+	"go_gin/internal/domain/model/web"
+	"go_gin/internal/exception"
+	"go_gin/pkg/ical"
+	"go_gin/pkg/taskio"
+	"gorm.io/gorm"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CalDAVService serves the todolists of a user as a CalDAV collection of VTODO. Reads go through the repositories,
+// writes through the TodoListService so they are validated, recorded and versioned like any other change.
+type CalDAVService struct {
+	DB                 *gorm.DB
+	Validator          *validator.Validate
+	Repository         model.CalDAVRepository
+	TodoListRepository model.TodoListRepository
+	TodoLists          *TodoListService
+}
+
+func NewCalDAVService(DB *gorm.DB, validator *validator.Validate, repository model.CalDAVRepository, todolistRepository model.TodoListRepository, todolists *TodoListService) *CalDAVService {
+	return &CalDAVService{DB: DB, Validator: validator, Repository: repository, TodoListRepository: todolistRepository, TodoLists: todolists}
+}
+
+// object is todolist as the calendar object a client sees, under the name and UID of resource when a client made it.
+func (c *CalDAVService) object(todolist model.TodoList, resource *model.CalDAVResource, now time.Time) model.CalDAVObject {
+	todo := todolist.ToVTodo(config.Server.Host, now)
+	name := model.CalDAVName(todolist.TaskID)
+	if resource != nil {
+		name = resource.Name
+		todo.Set("UID", ical.EscapeText(resource.UID))
+	}
+	calendar := newVCalendar()
+	calendar.Components = append(calendar.Components, todo)
+	var data strings.Builder
+	ical.Encode(&data, calendar)
+	return model.CalDAVObject{Name: name, TaskID: todolist.TaskID, ETag: web.ETag(todolist.Version), Data: data.String()}
+}
+
+// resolve finds the todolist of userID served under name, with the resource naming it if a client did.
+func (c *CalDAVService) resolve(ctx context.Context, tx *gorm.DB, userID uuid.UUID, name string) (model.TodoList, *model.CalDAVResource, error) {
+	notFound := exception.NewError(fmt.Errorf("calendar object %s not found", name), exception.ErrorNotFound)
+	if resource, errNotFound := c.Repository.GetCalDAVResourceByName(ctx, tx, userID, name); errNotFound == nil {
+		todolist, errTodoList := c.TodoListRepository.GetTodoListByID(ctx, tx, resource.TaskID, userID)
+		if errTodoList != nil {
+			return model.TodoList{}, nil, notFound
+		}
+		return todolist, &resource, nil
+	}
+	taskID, ok := model.ParseCalDAVName(name)
+	if !ok {
+		return model.TodoList{}, nil, notFound
+	}
+	if _, errNamed := c.Repository.GetCalDAVResourceByTaskID(ctx, tx, taskID); errNamed == nil {
+		// a client named it, it is only served under that name
+		return model.TodoList{}, nil, notFound
+	}
+	todolist, errTodoList := c.TodoListRepository.GetTodoListByID(ctx, tx, taskID, userID)
+	if errTodoList != nil {
+		return model.TodoList{}, nil, notFound
+	}
+	return todolist, nil, nil
+}
+
+// FindCalDAVCollection returns every todolist of the user, or with a sync token the ones changed and removed since
+// it. The token holds the snapshot of the read, so a change committed after it is reported next time whenever it was
+// made. Todolists purged from the trash leave no trace, so tokens older than the trash retention are refused and the
+// client syncs from scratch.
+func (c *CalDAVService) FindCalDAVCollection(ctx context.Context, params web.Params, syncToken string) (collection model.CalDAVCollection, errService error) {
+	tx := c.DB.Begin(&sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	now := time.Now()
+	var snapshot string
+	if syncToken != "" {
+		var takenAt time.Time
+		var errToken error
+		takenAt, snapshot, errToken = model.ParseCalDAVSyncToken(syncToken)
+		if errToken == nil && config.Trash.RetentionDays > 0 && takenAt.Before(now.AddDate(0, 0, -config.Trash.RetentionDays)) {
+			errToken = fmt.Errorf("sync token %q has expired", syncToken)
+		}
+		if errToken != nil {
+			tx.Rollback()
+			errService = exception.NewError(errToken, exception.ErrorForbidden)
+			return
+		}
+	}
+	// the snapshot is read first, it is the one every read below sees
+	collection.SyncToken = model.CalDAVSyncToken(now, c.TodoListRepository.GetChangeSnapshot(ctx, tx))
+	named := c.Repository.GetCalDAVResources(ctx, tx, userID)
+	resources := make(map[int]*model.CalDAVResource, len(named))
+	for i := range named {
+		resources[named[i].TaskID] = &named[i]
+	}
+	todolists := c.TodoListRepository.GetTodoListsChangedSince(ctx, tx, userID, snapshot)
+	var trashed []int
+	if snapshot != "" {
+		trashed = c.TodoListRepository.GetTodoListIDsTrashedSince(ctx, tx, userID, snapshot)
+		if len(todolists) == 0 && len(trashed) == 0 {
+			// nothing changed, the client keeps its token
+			collection.SyncToken = syncToken
+		}
+	}
+	tx.Commit()
+	for _, todolist := range todolists {
+		collection.Objects = append(collection.Objects, c.object(todolist, resources[todolist.TaskID], now))
+	}
+	for _, taskID := range trashed {
+		name := model.CalDAVName(taskID)
+		if resource, ok := resources[taskID]; ok {
+			name = resource.Name
+		}
+		collection.Deleted = append(collection.Deleted, name)
+	}
+	return
+}
+
+func (c *CalDAVService) FindCalDAVObject(ctx context.Context, params web.Params, name string) (object model.CalDAVObject, errService error) {
+	tx := c.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	todolist, resource, errNotFound := c.resolve(ctx, tx, userID, name)
+	if errNotFound != nil {
+		tx.Rollback()
+		errService = errNotFound
+		return
+	}
+	tx.Commit()
+	object = c.object(todolist, resource, time.Now())
+	return
+}
+
+// PutCalDAVObject creates or replaces the todolist served under name from the VTODO of body. Priority and project
+// are kept when the VTODO does not set them, params.IfMatch and params.IfNoneMatch guard the write. A new todolist is
+// created together with the resource naming it.
+func (c *CalDAVService) PutCalDAVObject(ctx context.Context, params web.Params, name string, body io.Reader) (object model.CalDAVObject, created bool, errService error) {
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	records, errRead := taskio.Read(taskio.FormatICS, body, taskio.Mapping{"UID": {"uid"}}, web.TodoListImportFields)
+	if errRead != nil {
+		errService = exception.NewError(errRead, exception.ErrorBadRequest)
+		return
+	}
+	if len(records) != 1 {
+		errService = exception.NewError(fmt.Errorf("a calendar object must hold exactly one VTODO, it holds %v", len(records)), exception.ErrorForbidden)
+		return
+	}
+	fields := records[0].Fields
+	request, errRequest := model.NewTodoListImportRequest(fields)
+	if errRequest != nil {
+		errService = exception.NewError(errRequest, exception.ErrorBadRequest)
+		return
+	}
+	if request.Description == "" {
+		request.Description = request.TaskName
+	}
+	if request.DueDate == nil {
+		errService = exception.NewError(fmt.Errorf("a todolist needs a due date, the VTODO has no DUE"), exception.ErrorBadRequest)
+		return
+	}
+
+	tx := c.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	// the name is locked first so that a concurrent write to it sees the todolist created here
+	c.Repository.LockCalDAVName(ctx, tx, userID, name)
+	current, _, errNotFound := c.resolve(ctx, tx, userID, name)
+	if errNotFound == nil {
+		tx.Rollback()
+		if params.IfNoneMatch == "*" {
+			errService = exception.NewError(fmt.Errorf("calendar object %s already exists", name), exception.ErrorPreconditionFailed)
+			return
+		}
+		if fields["priority"] == "" {
+			request.Priority = current.Priority
+		}
+		request.ProjectID = current.ProjectID
+		update := web.Params{UserID: params.UserID, Query: web.TodoListByIDQuery{ID: strconv.Itoa(current.TaskID)}, IfMatch: params.IfMatch}
+		if _, errService = c.TodoLists.UpdateTodoList(ctx, request, update); errService != nil {
+			return
+		}
+	} else {
+		if params.IfMatch != "" {
+			tx.Rollback()
+			errService = exception.NewError(fmt.Errorf("calendar object %s does not exist", name), exception.ErrorPreconditionFailed)
+			return
+		}
+		if badRequest := c.Validator.Struct(request); badRequest != nil {
+			tx.Rollback()
+			errService = exception.NewError(badRequest, exception.ErrorBadRequest)
+			return
+		}
+		todolists, errCreate := c.TodoLists.createTodoLists(ctx, tx, model.TodoListRequests{request}, userID)
+		if errCreate != nil {
+			tx.Rollback()
+			errService = errCreate
+			return
+		}
+		taskID := todolists[0].TaskID
+		if name != model.CalDAVName(taskID) || fields["uid"] != "" {
+			resource := model.CalDAVResource{TaskID: taskID, UserID: userID, Name: name, UID: fields["uid"], CreatedAt: time.Now()}
+			if resource.UID == "" {
+				resource.UID = strings.TrimSuffix(name, ".ics")
+			}
+			if errConflict := c.Repository.CreateCalDAVResource(ctx, tx, resource); errConflict != nil {
+				tx.Rollback()
+				errService = exception.NewError(errConflict, exception.ErrorConflict)
+				return
+			}
+		}
+		tx.Commit()
+		created = true
+	}
+	object, errService = c.FindCalDAVObject(ctx, params, name)
+	return
+}
+
+// DeleteCalDAVObject moves the todolist served under name to the trash, params.IfMatch guards it.
+func (c *CalDAVService) DeleteCalDAVObject(ctx context.Context, params web.Params, name string) (errService error) {
+	object, errNotFound := c.FindCalDAVObject(ctx, params, name)
+	if errNotFound != nil {
+		return errNotFound
+	}
+	_, errService = c.TodoLists.DeleteTodoList(ctx, web.Params{UserID: params.UserID, Query: web.TodoListByIDQuery{ID: strconv.Itoa(object.TaskID)}, IfMatch: params.IfMatch})
+	return
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"go_gin/internal/config"
+	"go_gin/internal/domain/model"
+	"go_gin/internal/domain/model/web"
+	"go_gin/internal/exception"
+	"go_gin/pkg/helper"
+	"go_gin/pkg/ical"
+	"gorm.io/gorm"
+	"io"
+	"time"
+)
+
+// CalendarService publishes the todolists of a user with a due date as an iCalendar feed, behind a secret token so
+// calendar apps can subscribe to it without logging in.
+type CalendarService struct {
+	DB                 *gorm.DB
+	Validator          *validator.Validate
+	Repository         model.CalendarRepository
+	TodoListRepository model.TodoListRepository
+}
+
+func NewCalendarService(DB *gorm.DB, validator *validator.Validate, repository model.CalendarRepository, todolistRepository model.TodoListRepository) *CalendarService {
+	return &CalendarService{DB: DB, Validator: validator, Repository: repository, TodoListRepository: todolistRepository}
+}
+
+// CreateCalendarToken gives the user a new feed token, the previous one stops working. The token is only returned here.
+func (c *CalendarService) CreateCalendarToken(ctx context.Context, params web.Params) (response model.CalendarTokenResponse, errService error) {
+	tx := c.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	token, hash, errToken := helper.NewFeedToken()
+	if errToken != nil {
+		tx.Rollback()
+		errService = exception.NewError(errToken, exception.ErrorInternalServer)
+		return
+	}
+	calendarToken := model.CalendarToken{UserID: userID, TokenHash: hash, CreatedAt: time.Now()}
+	c.Repository.SaveCalendarToken(ctx, tx, calendarToken)
+	tx.Commit()
+	response = model.CalendarTokenResponse{
+		Token:     token,
+		URL:       fmt.Sprintf("/api/calendar/%s.ics", token),
+		CreatedAt: calendarToken.CreatedAt,
+	}
+	return
+}
+
+func (c *CalendarService) DeleteCalendarToken(ctx context.Context, params web.Params) (errService error) {
+	tx := c.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	if c.Repository.DeleteCalendarToken(ctx, tx, userID) == 0 {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("user %v has no calendar feed", userID), exception.ErrorNotFound)
+		return
+	}
+	tx.Commit()
+	return
+}
+
+// WriteCalendarFeed writes the feed of the user owning token to out, every todolist with a due date shows as a VTODO
+// and as an all-day VEVENT on its due date.
+func (c *CalendarService) WriteCalendarFeed(ctx context.Context, token string, out io.Writer) (errService error) {
+	tx := c.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	calendarToken, errNotFound := c.Repository.GetCalendarTokenByHash(ctx, tx, helper.HashFeedToken(token))
+	if errNotFound != nil {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("calendar feed not found"), exception.ErrorNotFound)
+		return
+	}
+	todolists := c.TodoListRepository.GetDueTodoLists(ctx, tx, calendarToken.UserID)
+	tx.Commit()
+	now := time.Now()
+	calendar := newVCalendar()
+	calendar.Add("METHOD", "PUBLISH")
+	calendar.AddText("X-WR-CALNAME", "Todolist")
+	for _, todolist := range todolists {
+		calendar.Components = append(calendar.Components, todolist.ToVTodo(config.Server.Host, now), todolist.ToVEvent(config.Server.Host, now))
+	}
+	if errEncode := ical.Encode(out, calendar); errEncode != nil {
+		errService = exception.NewError(errEncode, exception.ErrorInternalServer)
+	}
+	return
+}
+
+// newVCalendar is an empty VCALENDAR of this service.
+func newVCalendar() ical.Component {
+	calendar := ical.Component{Name: "VCALENDAR"}
+	calendar.Add("VERSION", "2.0")
+	calendar.Add("PRODID", "-//go_gin//Todolist//EN")
+	calendar.Add("CALSCALE", "GREGORIAN")
+	return calendar
+}
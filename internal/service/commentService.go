@@ -0,0 +1,367 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"go_gin/internal/domain/model"
+	"go_gin/internal/domain/model/web"
+	"go_gin/internal/exception"
+	"gorm.io/gorm"
+	"sort"
+)
+
+type CommentService struct {
+	DB                     *gorm.DB
+	Validator              *validator.Validate
+	Repository             model.CommentRepository
+	Access                 *TodoListAccess
+	ActivityRepository     model.ActivityRepository
+	NotificationRepository model.NotificationRepository
+	UsersRepository        model.UsersRepository
+}
+
+func NewCommentService(DB *gorm.DB, validator *validator.Validate, repository model.CommentRepository, access *TodoListAccess, activityRepository model.ActivityRepository, notificationRepository model.NotificationRepository, usersRepository model.UsersRepository) *CommentService {
+	return &CommentService{DB: DB, Validator: validator, Repository: repository, Access: access, ActivityRepository: activityRepository, NotificationRepository: notificationRepository, UsersRepository: usersRepository}
+}
+
+// notifyMentions creates a mention notification for every user in usernames that may view the todolist, except the
+// comment author. Usernames are not unique, the others sharing a name are not told about a todolist they cannot see.
+func (s *CommentService) notifyMentions(ctx context.Context, tx *gorm.DB, comment model.Comment, usernames []string) error {
+	var notifications model.Notifications
+	for _, user := range s.UsersRepository.GetUsersByUsernames(ctx, tx, usernames) {
+		if user.ID == comment.UserID {
+			continue
+		}
+		if _, errAccess := s.Access.Authorize(ctx, tx, comment.TaskID, user.ID, model.PermissionViewer); errAccess != nil {
+			continue
+		}
+		taskID := comment.TaskID
+		notifications = append(notifications, model.Notification{
+			UserID:  user.ID,
+			ActorID: comment.UserID,
+			TaskID:  &taskID,
+			Type:    model.NotificationMention,
+			Message: fmt.Sprintf("you were mentioned in a comment on todolist %v", comment.TaskID),
+		})
+	}
+	return s.NotificationRepository.CreateNotifications(ctx, tx, notifications)
+}
+
+func (s *CommentService) FindComments(ctx context.Context, params web.Params) (responses model.CommentResponses, errService error) {
+	tx := s.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	queryParams, ok := params.Query.(web.TodoListByIDQuery)
+	if !ok {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("error parsing get by id query params"), exception.ErrorInternalServer)
+		return
+	}
+	validationQueryParams := s.Validator.Struct(queryParams)
+	if validationQueryParams != nil {
+		tx.Rollback()
+		errService = exception.NewError(validationQueryParams, exception.ErrorBadRequest)
+		return
+	}
+	value, errParsing := queryParams.ToValue()
+	if errParsing != nil {
+		tx.Rollback()
+		errService = exception.NewError(errParsing, exception.ErrorInternalServer)
+		return
+	}
+	if _, errAccess := s.Access.Authorize(ctx, tx, value.ID, userID, model.PermissionViewer); errAccess != nil {
+		tx.Rollback()
+		errService = errAccess
+		return
+	}
+	responses = s.Repository.GetCommentsByTaskID(ctx, tx, value.ID).ToCommentResponses()
+	tx.Commit()
+	return
+}
+
+func (s *CommentService) CreateComment(ctx context.Context, request model.CommentRequest, params web.Params) (response model.CommentResponse, errService error) {
+	tx := s.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	badRequest := s.Validator.Struct(request)
+	if badRequest != nil {
+		tx.Rollback()
+		errService = exception.NewError(badRequest, exception.ErrorBadRequest)
+		return
+	}
+	queryParams, ok := params.Query.(web.TodoListByIDQuery)
+	if !ok {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("error parsing get by id query params"), exception.ErrorInternalServer)
+		return
+	}
+	validationQueryParams := s.Validator.Struct(queryParams)
+	if validationQueryParams != nil {
+		tx.Rollback()
+		errService = exception.NewError(validationQueryParams, exception.ErrorBadRequest)
+		return
+	}
+	value, errParsing := queryParams.ToValue()
+	if errParsing != nil {
+		tx.Rollback()
+		errService = exception.NewError(errParsing, exception.ErrorInternalServer)
+		return
+	}
+	if _, errAccess := s.Access.Authorize(ctx, tx, value.ID, userID, model.PermissionViewer); errAccess != nil {
+		tx.Rollback()
+		errService = errAccess
+		return
+	}
+	comment, errCreate := s.Repository.CreateComment(ctx, tx, *request.ToComment(value.ID, userID))
+	if errCreate != nil {
+		tx.Rollback()
+		errService = exception.NewError(errCreate, exception.ErrorInternalServer)
+		return
+	}
+	if errNotify := s.notifyMentions(ctx, tx, comment, comment.Mentions()); errNotify != nil {
+		tx.Rollback()
+		errService = exception.NewError(errNotify, exception.ErrorInternalServer)
+		return
+	}
+	tx.Commit()
+	response = *comment.ToCommentResponse()
+	return
+}
+
+// findOwnComment loads the comment addressed by params and makes sure the caller wrote it.
+func (s *CommentService) findOwnComment(ctx context.Context, tx *gorm.DB, params web.Params) (comment model.Comment, errService error) {
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	queryParams, ok := params.Query.(web.CommentByIDQuery)
+	if !ok {
+		errService = exception.NewError(fmt.Errorf("error parsing comment query params"), exception.ErrorInternalServer)
+		return
+	}
+	validationQueryParams := s.Validator.Struct(queryParams)
+	if validationQueryParams != nil {
+		errService = exception.NewError(validationQueryParams, exception.ErrorBadRequest)
+		return
+	}
+	value, errParsing := queryParams.ToValue()
+	if errParsing != nil {
+		errService = exception.NewError(errParsing, exception.ErrorInternalServer)
+		return
+	}
+	if _, errAccess := s.Access.Authorize(ctx, tx, value.ID, userID, model.PermissionViewer); errAccess != nil {
+		errService = errAccess
+		return
+	}
+	comment, errNotFound := s.Repository.GetCommentByID(ctx, tx, value.CommentID, value.ID)
+	if errNotFound != nil {
+		errService = exception.NewError(fmt.Errorf("comment with id %v not found", value.CommentID), exception.ErrorNotFound)
+		return
+	}
+	if comment.UserID != userID {
+		errService = exception.NewError(fmt.Errorf("comment with id %v is not yours", value.CommentID), exception.ErrorForbidden)
+		return
+	}
+	return
+}
+
+func (s *CommentService) UpdateComment(ctx context.Context, request model.CommentRequest, params web.Params) (errService error) {
+	tx := s.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	badRequest := s.Validator.Struct(request)
+	if badRequest != nil {
+		tx.Rollback()
+		errService = exception.NewError(badRequest, exception.ErrorBadRequest)
+		return
+	}
+	comment, errComment := s.findOwnComment(ctx, tx, params)
+	if errComment != nil {
+		tx.Rollback()
+		errService = errComment
+		return
+	}
+	if comment.Body == request.Body {
+		tx.Rollback()
+		return
+	}
+	errRevision := s.Repository.CreateCommentRevision(ctx, tx, model.CommentRevision{CommentID: comment.CommentID, Body: comment.Body})
+	if errRevision != nil {
+		tx.Rollback()
+		errService = exception.NewError(errRevision, exception.ErrorInternalServer)
+		return
+	}
+	s.Repository.UpdateCommentBody(ctx, tx, comment.CommentID, request.Body)
+	previous := comment.Mentions()
+	comment.Body = request.Body
+	var added []string
+	for _, username := range comment.Mentions() {
+		if !containsString(previous, username) {
+			added = append(added, username)
+		}
+	}
+	if errNotify := s.notifyMentions(ctx, tx, comment, added); errNotify != nil {
+		tx.Rollback()
+		errService = exception.NewError(errNotify, exception.ErrorInternalServer)
+		return
+	}
+	tx.Commit()
+	return
+}
+
+func (s *CommentService) DeleteComment(ctx context.Context, params web.Params) (errService error) {
+	tx := s.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	comment, errComment := s.findOwnComment(ctx, tx, params)
+	if errComment != nil {
+		tx.Rollback()
+		errService = errComment
+		return
+	}
+	s.Repository.DeleteCommentByID(ctx, tx, comment.CommentID)
+	tx.Commit()
+	return
+}
+
+func (s *CommentService) FindCommentHistory(ctx context.Context, params web.Params) (revisions model.CommentRevisions, errService error) {
+	tx := s.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	queryParams, ok := params.Query.(web.CommentByIDQuery)
+	if !ok {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("error parsing comment query params"), exception.ErrorInternalServer)
+		return
+	}
+	validationQueryParams := s.Validator.Struct(queryParams)
+	if validationQueryParams != nil {
+		tx.Rollback()
+		errService = exception.NewError(validationQueryParams, exception.ErrorBadRequest)
+		return
+	}
+	value, errParsing := queryParams.ToValue()
+	if errParsing != nil {
+		tx.Rollback()
+		errService = exception.NewError(errParsing, exception.ErrorInternalServer)
+		return
+	}
+	if _, errAccess := s.Access.Authorize(ctx, tx, value.ID, userID, model.PermissionViewer); errAccess != nil {
+		tx.Rollback()
+		errService = errAccess
+		return
+	}
+	if _, errNotFound := s.Repository.GetCommentByID(ctx, tx, value.CommentID, value.ID); errNotFound != nil {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("comment with id %v not found", value.CommentID), exception.ErrorNotFound)
+		return
+	}
+	revisions = s.Repository.GetCommentRevisions(ctx, tx, value.CommentID)
+	tx.Commit()
+	return
+}
+
+func (s *CommentService) FindActivities(ctx context.Context, params web.Params) (responses model.ActivityResponses, errService error) {
+	tx := s.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	queryParams, ok := params.Query.(web.TodoListByIDQuery)
+	if !ok {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("error parsing get by id query params"), exception.ErrorInternalServer)
+		return
+	}
+	validationQueryParams := s.Validator.Struct(queryParams)
+	if validationQueryParams != nil {
+		tx.Rollback()
+		errService = exception.NewError(validationQueryParams, exception.ErrorBadRequest)
+		return
+	}
+	value, errParsing := queryParams.ToValue()
+	if errParsing != nil {
+		tx.Rollback()
+		errService = exception.NewError(errParsing, exception.ErrorInternalServer)
+		return
+	}
+	if _, errAccess := s.Access.Authorize(ctx, tx, value.ID, userID, model.PermissionViewer); errAccess != nil {
+		tx.Rollback()
+		errService = errAccess
+		return
+	}
+	for _, comment := range s.Repository.GetCommentsByTaskID(ctx, tx, value.ID) {
+		responses = append(responses, *comment.ToActivityResponse())
+	}
+	for _, activity := range s.ActivityRepository.GetActivitiesByTaskID(ctx, tx, value.ID) {
+		responses = append(responses, *activity.ToActivityResponse())
+	}
+	tx.Commit()
+	sort.SliceStable(responses, func(i, j int) bool {
+		return responses[i].CreatedAt.Before(responses[j].CreatedAt)
+	})
+	return
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
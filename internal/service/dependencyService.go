@@ -0,0 +1,284 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"go_gin/internal/domain/model"
+	"go_gin/internal/domain/model/web"
+	"go_gin/internal/exception"
+	"go_gin/pkg/graph"
+	"gorm.io/gorm"
+	"time"
+)
+
+type DependencyService struct {
+	DB                 *gorm.DB
+	Validator          *validator.Validate
+	Repository         model.DependencyRepository
+	TodoListRepository model.TodoListRepository
+	Access             *TodoListAccess
+}
+
+func NewDependencyService(DB *gorm.DB, validator *validator.Validate, repository model.DependencyRepository, todolistRepository model.TodoListRepository, access *TodoListAccess) *DependencyService {
+	return &DependencyService{DB: DB, Validator: validator, Repository: repository, TodoListRepository: todolistRepository, Access: access}
+}
+
+// planWeights gives every todolist the days between the latest due date of its blockers, or its creation, and its own due date.
+func planWeights(todolists model.TodoLists, dependencies model.TaskDependencies) map[int]float64 {
+	byID := make(map[int]model.TodoList, len(todolists))
+	for _, todolist := range todolists {
+		byID[todolist.TaskID] = todolist
+	}
+	starts := make(map[int]time.Time, len(todolists))
+	for _, todolist := range todolists {
+		starts[todolist.TaskID] = todolist.CreatedAt
+	}
+	for _, dependency := range dependencies {
+		blocker := byID[dependency.BlockerID]
+		if blocker.DueDate != nil && blocker.DueDate.After(starts[dependency.BlockedID]) {
+			starts[dependency.BlockedID] = *blocker.DueDate
+		}
+	}
+	weights := make(map[int]float64, len(todolists))
+	for _, todolist := range todolists {
+		if todolist.DueDate == nil {
+			continue
+		}
+		if days := todolist.DueDate.Sub(starts[todolist.TaskID]).Hours() / 24; days > 0 {
+			weights[todolist.TaskID] = days
+		}
+	}
+	return weights
+}
+
+func (d *DependencyService) FindDependencies(ctx context.Context, params web.Params) (dependencies model.TodoListDependencies, errService error) {
+	tx := d.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	queryParams, ok := params.Query.(web.TodoListByIDQuery)
+	if !ok {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("error parsing get by id query params"), exception.ErrorInternalServer)
+		return
+	}
+	validationQueryParams := d.Validator.Struct(queryParams)
+	if validationQueryParams != nil {
+		tx.Rollback()
+		errService = exception.NewError(validationQueryParams, exception.ErrorBadRequest)
+		return
+	}
+	value, errParsing := queryParams.ToValue()
+	if errParsing != nil {
+		tx.Rollback()
+		errService = exception.NewError(errParsing, exception.ErrorInternalServer)
+		return
+	}
+	if _, errAccess := d.Access.Authorize(ctx, tx, value.ID, userID, model.PermissionViewer); errAccess != nil {
+		tx.Rollback()
+		errService = errAccess
+		return
+	}
+	dependencies.BlockedBy = d.Repository.GetBlockers(ctx, tx, value.ID).ToTodoListResponses()
+	dependencies.Blocks = d.Repository.GetBlocking(ctx, tx, value.ID).ToTodoListResponses()
+	tx.Commit()
+	return
+}
+
+func (d *DependencyService) CreateDependency(ctx context.Context, request model.DependencyRequest, params web.Params) (errService error) {
+	tx := d.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	badRequest := d.Validator.Struct(request)
+	if badRequest != nil {
+		tx.Rollback()
+		errService = exception.NewError(badRequest, exception.ErrorBadRequest)
+		return
+	}
+	queryParams, ok := params.Query.(web.TodoListByIDQuery)
+	if !ok {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("error parsing get by id query params"), exception.ErrorInternalServer)
+		return
+	}
+	validationQueryParams := d.Validator.Struct(queryParams)
+	if validationQueryParams != nil {
+		tx.Rollback()
+		errService = exception.NewError(validationQueryParams, exception.ErrorBadRequest)
+		return
+	}
+	value, errParsing := queryParams.ToValue()
+	if errParsing != nil {
+		tx.Rollback()
+		errService = exception.NewError(errParsing, exception.ErrorInternalServer)
+		return
+	}
+	if value.ID == request.BlockerID {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("todolist cannot block itself"), exception.ErrorBadRequest)
+		return
+	}
+	if _, errAccess := d.Access.Authorize(ctx, tx, value.ID, userID, model.PermissionEditor); errAccess != nil {
+		tx.Rollback()
+		errService = errAccess
+		return
+	}
+	if _, errAccess := d.Access.Authorize(ctx, tx, request.BlockerID, userID, model.PermissionViewer); errAccess != nil {
+		tx.Rollback()
+		errService = errAccess
+		return
+	}
+	// the lock comes first so that the graph checked below is the one the link is added to
+	d.Repository.LockDependencies(ctx, tx)
+	if d.Repository.DependencyPathExists(ctx, tx, value.ID, request.BlockerID) {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("todolist with id %v already depends on todolist with id %v, the link would create a cycle", request.BlockerID, value.ID), exception.ErrorConflict)
+		return
+	}
+	errConflict := d.Repository.CreateDependency(ctx, tx, model.TaskDependency{BlockerID: request.BlockerID, BlockedID: value.ID, CreatedBy: userID})
+	if errConflict != nil {
+		tx.Rollback()
+		errService = exception.NewError(errConflict, exception.ErrorConflict)
+		return
+	}
+	tx.Commit()
+	return
+}
+
+func (d *DependencyService) DeleteDependency(ctx context.Context, params web.Params) (errService error) {
+	tx := d.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	queryParams, ok := params.Query.(web.DependencyQuery)
+	if !ok {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("error parsing dependency query params"), exception.ErrorInternalServer)
+		return
+	}
+	validationQueryParams := d.Validator.Struct(queryParams)
+	if validationQueryParams != nil {
+		tx.Rollback()
+		errService = exception.NewError(validationQueryParams, exception.ErrorBadRequest)
+		return
+	}
+	value, errParsing := queryParams.ToValue()
+	if errParsing != nil {
+		tx.Rollback()
+		errService = exception.NewError(errParsing, exception.ErrorInternalServer)
+		return
+	}
+	if _, errAccess := d.Access.Authorize(ctx, tx, value.ID, userID, model.PermissionEditor); errAccess != nil {
+		tx.Rollback()
+		errService = errAccess
+		return
+	}
+	if d.Repository.DeleteDependency(ctx, tx, value.BlockerID, value.ID) == 0 {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("todolist with id %v is not blocked by todolist with id %v", value.ID, value.BlockerID), exception.ErrorNotFound)
+		return
+	}
+	tx.Commit()
+	return
+}
+
+func (d *DependencyService) FindProjectPlan(ctx context.Context, params web.Params) (plan model.ProjectPlan, errService error) {
+	tx := d.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	queryParams, ok := params.Query.(web.ProjectByIDQuery)
+	if !ok {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("error parsing project query params"), exception.ErrorInternalServer)
+		return
+	}
+	validationQueryParams := d.Validator.Struct(queryParams)
+	if validationQueryParams != nil {
+		tx.Rollback()
+		errService = exception.NewError(validationQueryParams, exception.ErrorBadRequest)
+		return
+	}
+	projectID, errParsing := queryParams.ToValue()
+	if errParsing != nil {
+		tx.Rollback()
+		errService = exception.NewError(errParsing, exception.ErrorInternalServer)
+		return
+	}
+	if errAccess := d.Access.AuthorizeProject(ctx, tx, projectID, userID, model.PermissionViewer); errAccess != nil {
+		tx.Rollback()
+		errService = errAccess
+		return
+	}
+	todolists := d.TodoListRepository.GetTodoListsByProjectID(ctx, tx, projectID)
+	byID := make(map[int]model.TodoList, len(todolists))
+	IDs := make([]int, 0, len(todolists))
+	for _, todolist := range todolists {
+		byID[todolist.TaskID] = todolist
+		IDs = append(IDs, todolist.TaskID)
+	}
+	dependencies := d.Repository.GetDependenciesByTaskIDs(ctx, tx, IDs)
+	tx.Commit()
+	edges := make([]graph.Edge, 0, len(dependencies))
+	for _, dependency := range dependencies {
+		edges = append(edges, graph.Edge{From: dependency.BlockerID, To: dependency.BlockedID})
+	}
+	order, errCycle := graph.TopologicalSort(IDs, edges)
+	if errCycle != nil {
+		errService = exception.NewError(errCycle, exception.ErrorConflict)
+		return
+	}
+	path, days := graph.CriticalPath(order, edges, planWeights(todolists, dependencies))
+	for _, ID := range order {
+		todolist := byID[ID]
+		plan.Order = append(plan.Order, *todolist.ToTodoListResponse())
+	}
+	for _, ID := range path {
+		todolist := byID[ID]
+		plan.CriticalPath = append(plan.CriticalPath, *todolist.ToTodoListResponse())
+	}
+	plan.CriticalDays = days
+	return
+}
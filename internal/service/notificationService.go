@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"go_gin/internal/domain/model"
+	"go_gin/internal/domain/model/web"
+	"go_gin/internal/exception"
+	"gorm.io/gorm"
+)
+
+type NotificationService struct {
+	DB         *gorm.DB
+	Validator  *validator.Validate
+	Repository model.NotificationRepository
+}
+
+func NewNotificationService(DB *gorm.DB, validator *validator.Validate, repository model.NotificationRepository) *NotificationService {
+	return &NotificationService{DB: DB, Validator: validator, Repository: repository}
+}
+
+func (n *NotificationService) FindNotifications(ctx context.Context, params web.Params) (notifications model.Notifications, pagination web.Pagination, errService error) {
+	tx := n.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	queryParams, ok := params.Query.(web.GetAllQuery)
+	if !ok {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("error parsing get all query params"), exception.ErrorInternalServer)
+		return
+	}
+	validationQueryParams := n.Validator.Struct(queryParams)
+	if validationQueryParams != nil {
+		tx.Rollback()
+		errService = exception.NewError(validationQueryParams, exception.ErrorBadRequest)
+		return
+	}
+	value, errParsing := queryParams.ToValue()
+	if errParsing != nil {
+		tx.Rollback()
+		errService = exception.NewError(errParsing, exception.ErrorBadRequest)
+		return
+	}
+	notifications = n.Repository.GetNotifications(ctx, tx, *value, userID)
+	totalData := n.Repository.CountNotifications(ctx, tx, userID)
+	tx.Commit()
+	pagination = web.NewPagination(value.Page, value.Limit, totalData)
+	return
+}
+
+func (n *NotificationService) ReadNotifications(ctx context.Context, params web.Params) (errService error) {
+	tx := n.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	queryParams, ok := params.Query.(web.NotificationByIDsQuery)
+	if !ok {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("error parsing notification query params"), exception.ErrorInternalServer)
+		return
+	}
+	validationQueryParams := n.Validator.Struct(queryParams)
+	if validationQueryParams != nil {
+		tx.Rollback()
+		errService = exception.NewError(validationQueryParams, exception.ErrorBadRequest)
+		return
+	}
+	IDs, errParsing := queryParams.ToValue()
+	if errParsing != nil {
+		tx.Rollback()
+		errService = exception.NewError(errParsing, exception.ErrorInternalServer)
+		return
+	}
+	n.Repository.MarkNotificationsRead(ctx, tx, IDs, userID)
+	tx.Commit()
+	return
+}
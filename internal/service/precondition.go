@@ -0,0 +1,23 @@
+package service
+
+import (
+	"fmt"
+	"go_gin/internal/config"
+	"go_gin/internal/domain/model/web"
+	"go_gin/internal/exception"
+)
+
+// checkIfMatch compares the If-Match header of a write with the current version of the resource it names. A
+// missing header lets the write through unless config.Other.RequireIfMatch is set.
+func checkIfMatch(ifMatch string, version int, resource string) error {
+	if ifMatch == "" {
+		if config.Other.RequireIfMatch {
+			return exception.NewError(fmt.Errorf("if-match header is required to change %s", resource), exception.ErrorPreconditionRequired)
+		}
+		return nil
+	}
+	if !web.MatchETag(ifMatch, version) {
+		return exception.NewError(fmt.Errorf("%s was changed, its current etag is %s", resource, web.ETag(version)), exception.ErrorPreconditionFailed)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"go_gin/internal/domain/model"
+	"go_gin/internal/domain/model/web"
+	"go_gin/internal/exception"
+	"gorm.io/gorm"
+)
+
+type ProjectService struct {
+	DB         *gorm.DB
+	Validator  *validator.Validate
+	Repository model.ProjectRepository
+}
+
+func NewProjectService(DB *gorm.DB, validator *validator.Validate, repository model.ProjectRepository) *ProjectService {
+	return &ProjectService{DB: DB, Validator: validator, Repository: repository}
+}
+
+// parseProjectID validates the project_id query and makes sure the project belongs to the caller.
+func (p *ProjectService) parseProjectID(ctx context.Context, tx *gorm.DB, params web.Params) (ID int, errService error) {
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	queryParams, ok := params.Query.(web.ProjectByIDQuery)
+	if !ok {
+		errService = exception.NewError(fmt.Errorf("error parsing project query params"), exception.ErrorInternalServer)
+		return
+	}
+	validationQueryParams := p.Validator.Struct(queryParams)
+	if validationQueryParams != nil {
+		errService = exception.NewError(validationQueryParams, exception.ErrorBadRequest)
+		return
+	}
+	ID, errParsing := queryParams.ToValue()
+	if errParsing != nil {
+		errService = exception.NewError(errParsing, exception.ErrorInternalServer)
+		return
+	}
+	if !p.Repository.ProjectExistByID(ctx, tx, ID, userID) {
+		errService = exception.NewError(fmt.Errorf("project with id %v not found", ID), exception.ErrorNotFound)
+		return
+	}
+	return
+}
+
+func (p *ProjectService) FindProjects(ctx context.Context, params web.Params) (responses model.ProjectResponses, errService error) {
+	tx := p.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	responses = p.Repository.GetProjects(ctx, tx, userID).ToProjectResponses()
+	tx.Commit()
+	return
+}
+
+func (p *ProjectService) CreateProject(ctx context.Context, request model.ProjectRequest, params web.Params) (response model.ProjectResponse, errService error) {
+	tx := p.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	badRequest := p.Validator.Struct(request)
+	if badRequest != nil {
+		tx.Rollback()
+		errService = exception.NewError(badRequest, exception.ErrorBadRequest)
+		return
+	}
+	project, errConflict := p.Repository.CreateProject(ctx, tx, *request.ToProject(userID))
+	if errConflict != nil {
+		tx.Rollback()
+		errService = exception.NewError(errConflict, exception.ErrorConflict)
+		return
+	}
+	tx.Commit()
+	response = *project.ToProjectResponse()
+	return
+}
+
+func (p *ProjectService) UpdateProject(ctx context.Context, request model.ProjectRequest, params web.Params) (errService error) {
+	tx := p.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	badRequest := p.Validator.Struct(request)
+	if badRequest != nil {
+		tx.Rollback()
+		errService = exception.NewError(badRequest, exception.ErrorBadRequest)
+		return
+	}
+	ID, errProject := p.parseProjectID(ctx, tx, params)
+	if errProject != nil {
+		tx.Rollback()
+		errService = errProject
+		return
+	}
+	userID, _ := params.UserID.ToUUID()
+	p.Repository.UpdateProjectByID(ctx, tx, *request.ToProject(userID), ID, userID)
+	tx.Commit()
+	return
+}
+
+func (p *ProjectService) DeleteProject(ctx context.Context, params web.Params) (errService error) {
+	tx := p.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	ID, errProject := p.parseProjectID(ctx, tx, params)
+	if errProject != nil {
+		tx.Rollback()
+		errService = errProject
+		return
+	}
+	userID, _ := params.UserID.ToUUID()
+	p.Repository.DeleteProjectByID(ctx, tx, ID, userID)
+	tx.Commit()
+	return
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"go_gin/internal/domain/model"
+	"go_gin/internal/domain/model/web"
+	"go_gin/internal/exception"
+	"go_gin/pkg/quickadd"
+	"gorm.io/gorm"
+	"time"
+)
+
+// QuickAddService creates todolists from a line of free text through TodoList, tagged in the same transaction.
+type QuickAddService struct {
+	DB        *gorm.DB
+	Validator *validator.Validate
+	TodoList  *TodoListService
+	Tags      model.TagRepository
+}
+
+func NewQuickAddService(DB *gorm.DB, validator *validator.Validate, todolist *TodoListService, tags model.TagRepository) *QuickAddService {
+	return &QuickAddService{DB: DB, Validator: validator, TodoList: todolist, Tags: tags}
+}
+
+// QuickAddTodoList reads request.Text into a todolist and creates it with its tags, unless it is a dry run.
+func (q *QuickAddService) QuickAddTodoList(ctx context.Context, request model.QuickAddRequest, params web.Params) (response model.QuickAddResponse, errService error) {
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	badRequest := q.Validator.Struct(request)
+	if badRequest != nil {
+		errService = exception.NewError(badRequest, exception.ErrorBadRequest)
+		return
+	}
+	location, errLocation := time.LoadLocation(request.Timezone)
+	if errLocation != nil {
+		errService = exception.NewError(fmt.Errorf("timezone %q is not an IANA timezone", request.Timezone), exception.ErrorBadRequest)
+		return
+	}
+	now := time.Now().In(location)
+	task := quickadd.Parse(request.Text, now)
+	tags := model.TagsRequest{Tags: task.Tags}
+	response = model.QuickAddResponse{DryRun: request.DryRun, Request: model.NewQuickAddTodoListRequest(task, now), Tags: tags.Normalize()}
+	if badRequest := q.Validator.Struct(response.Request); badRequest != nil {
+		if response.Request.TaskName == "" {
+			badRequest = fmt.Errorf("%q has no title left once its date, priority and tags are read", request.Text)
+		}
+		errService = exception.NewError(badRequest, exception.ErrorBadRequest)
+		return
+	}
+	if badRequest := q.Validator.Struct(tags); badRequest != nil {
+		errService = exception.NewError(badRequest, exception.ErrorBadRequest)
+		return
+	}
+	if request.DryRun {
+		return
+	}
+
+	tx := q.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	todolists, errCreate := q.TodoList.createTodoLists(ctx, tx, model.TodoListRequests{response.Request}, userID)
+	if errCreate != nil {
+		tx.Rollback()
+		errService = errCreate
+		return
+	}
+	response.TaskID = todolists[0].TaskID
+	if len(response.Tags) > 0 {
+		q.Tags.ReplaceTags(ctx, tx, response.TaskID, response.Tags)
+	}
+	tx.Commit()
+	return
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"go_gin/internal/domain/model"
+	"go_gin/internal/domain/model/web"
+	"go_gin/internal/exception"
+	"go_gin/pkg/querylang"
+	"gorm.io/gorm"
+)
+
+type SavedSearchService struct {
+	DB         *gorm.DB
+	Validator  *validator.Validate
+	Repository model.SavedSearchRepository
+}
+
+func NewSavedSearchService(DB *gorm.DB, validator *validator.Validate, repository model.SavedSearchRepository) *SavedSearchService {
+	return &SavedSearchService{DB: DB, Validator: validator, Repository: repository}
+}
+
+// validateSavedSearch checks the request and that its query parses, so a saved search can always be run.
+func (s *SavedSearchService) validateSavedSearch(request model.SavedSearchRequest) error {
+	badRequest := s.Validator.Struct(request)
+	if badRequest != nil {
+		return exception.NewError(badRequest, exception.ErrorBadRequest)
+	}
+	if _, errQuery := querylang.Parse(request.Query, web.TodoListQueryFields); errQuery != nil {
+		return exception.NewError(errQuery, exception.ErrorBadRequest)
+	}
+	return nil
+}
+
+func (s *SavedSearchService) parseSearchID(ctx context.Context, tx *gorm.DB, params web.Params) (ID int, errService error) {
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	queryParams, ok := params.Query.(web.SavedSearchByIDQuery)
+	if !ok {
+		errService = exception.NewError(fmt.Errorf("error parsing saved search query params"), exception.ErrorInternalServer)
+		return
+	}
+	validationQueryParams := s.Validator.Struct(queryParams)
+	if validationQueryParams != nil {
+		errService = exception.NewError(validationQueryParams, exception.ErrorBadRequest)
+		return
+	}
+	ID, errParsing := queryParams.ToValue()
+	if errParsing != nil {
+		errService = exception.NewError(errParsing, exception.ErrorInternalServer)
+		return
+	}
+	if !s.Repository.SavedSearchExistByID(ctx, tx, ID, userID) {
+		errService = exception.NewError(fmt.Errorf("saved search with id %v not found", ID), exception.ErrorNotFound)
+		return
+	}
+	return
+}
+
+func (s *SavedSearchService) FindSavedSearches(ctx context.Context, params web.Params) (responses model.SavedSearchResponses, errService error) {
+	tx := s.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	responses = s.Repository.GetSavedSearches(ctx, tx, userID).ToSavedSearchResponses()
+	tx.Commit()
+	return
+}
+
+func (s *SavedSearchService) CreateSavedSearch(ctx context.Context, request model.SavedSearchRequest, params web.Params) (response model.SavedSearchResponse, errService error) {
+	tx := s.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	if errService = s.validateSavedSearch(request); errService != nil {
+		tx.Rollback()
+		return
+	}
+	search, errConflict := s.Repository.CreateSavedSearch(ctx, tx, *request.ToSavedSearch(userID))
+	if errConflict != nil {
+		tx.Rollback()
+		errService = exception.NewError(errConflict, exception.ErrorConflict)
+		return
+	}
+	tx.Commit()
+	response = *search.ToSavedSearchResponse()
+	return
+}
+
+func (s *SavedSearchService) UpdateSavedSearch(ctx context.Context, request model.SavedSearchRequest, params web.Params) (errService error) {
+	tx := s.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	if errService = s.validateSavedSearch(request); errService != nil {
+		tx.Rollback()
+		return
+	}
+	ID, errSearch := s.parseSearchID(ctx, tx, params)
+	if errSearch != nil {
+		tx.Rollback()
+		errService = errSearch
+		return
+	}
+	userID, _ := params.UserID.ToUUID()
+	if errConflict := s.Repository.UpdateSavedSearchByID(ctx, tx, *request.ToSavedSearch(userID), ID, userID); errConflict != nil {
+		tx.Rollback()
+		errService = exception.NewError(errConflict, exception.ErrorConflict)
+		return
+	}
+	tx.Commit()
+	return
+}
+
+func (s *SavedSearchService) DeleteSavedSearch(ctx context.Context, params web.Params) (errService error) {
+	tx := s.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	ID, errSearch := s.parseSearchID(ctx, tx, params)
+	if errSearch != nil {
+		tx.Rollback()
+		errService = errSearch
+		return
+	}
+	userID, _ := params.UserID.ToUUID()
+	s.Repository.DeleteSavedSearchByID(ctx, tx, ID, userID)
+	tx.Commit()
+	return
+}
@@ -0,0 +1,242 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"go_gin/internal/domain/model"
+	"go_gin/internal/domain/model/web"
+	"go_gin/internal/exception"
+	"gorm.io/gorm"
+)
+
+type ShareService struct {
+	DB                     *gorm.DB
+	Validator              *validator.Validate
+	Repository             model.ShareRepository
+	TodoListRepository     model.TodoListRepository
+	ProjectRepository      model.ProjectRepository
+	NotificationRepository model.NotificationRepository
+	UsersRepository        model.UsersRepository
+}
+
+func NewShareService(DB *gorm.DB, validator *validator.Validate, repository model.ShareRepository, todolistRepository model.TodoListRepository, projectRepository model.ProjectRepository, notificationRepository model.NotificationRepository, usersRepository model.UsersRepository) *ShareService {
+	return &ShareService{DB: DB, Validator: validator, Repository: repository, TodoListRepository: todolistRepository, ProjectRepository: projectRepository, NotificationRepository: notificationRepository, UsersRepository: usersRepository}
+}
+
+// findShare loads the share addressed by the share_id query, it is only visible to its owner and grantee.
+func (s *ShareService) findShare(ctx context.Context, tx *gorm.DB, params web.Params, ID int) (share model.Share, errService error) {
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	share, errNotFound := s.Repository.GetShareByID(ctx, tx, ID)
+	if errNotFound != nil || (share.OwnerID != userID && share.UserID != userID) {
+		errService = exception.NewError(fmt.Errorf("share with id %v not found", ID), exception.ErrorNotFound)
+		return
+	}
+	return
+}
+
+func (s *ShareService) FindSharesReceived(ctx context.Context, params web.Params) (shares model.Shares, errService error) {
+	tx := s.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	queryParams, ok := params.Query.(web.ShareQuery)
+	if !ok {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("error parsing share query params"), exception.ErrorInternalServer)
+		return
+	}
+	validationQueryParams := s.Validator.Struct(queryParams)
+	if validationQueryParams != nil {
+		tx.Rollback()
+		errService = exception.NewError(validationQueryParams, exception.ErrorBadRequest)
+		return
+	}
+	shares = s.Repository.GetSharesReceived(ctx, tx, userID, queryParams.Status)
+	tx.Commit()
+	return
+}
+
+func (s *ShareService) FindSharesSent(ctx context.Context, params web.Params) (shares model.Shares, errService error) {
+	tx := s.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	shares = s.Repository.GetSharesSent(ctx, tx, userID)
+	tx.Commit()
+	return
+}
+
+func (s *ShareService) CreateShare(ctx context.Context, request model.ShareRequest, params web.Params) (share model.Share, errService error) {
+	tx := s.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	badRequest := s.Validator.Struct(request)
+	if badRequest != nil {
+		tx.Rollback()
+		errService = exception.NewError(badRequest, exception.ErrorBadRequest)
+		return
+	}
+	if request.UserID == userID {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("cannot share with yourself"), exception.ErrorBadRequest)
+		return
+	}
+	var target string
+	if request.TaskID != nil {
+		target = fmt.Sprintf("todolist %v", *request.TaskID)
+		if !s.TodoListRepository.TodoListExistByID(ctx, tx, *request.TaskID, userID) {
+			tx.Rollback()
+			errService = exception.NewError(fmt.Errorf("todolist with id %v not found", *request.TaskID), exception.ErrorNotFound)
+			return
+		}
+	} else {
+		target = fmt.Sprintf("project %v", *request.ProjectID)
+		if !s.ProjectRepository.ProjectExistByID(ctx, tx, *request.ProjectID, userID) {
+			tx.Rollback()
+			errService = exception.NewError(fmt.Errorf("project with id %v not found", *request.ProjectID), exception.ErrorNotFound)
+			return
+		}
+	}
+	if !s.UsersRepository.UsersExistByID(ctx, tx, request.UserID) {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("user with id %v not found", request.UserID), exception.ErrorNotFound)
+		return
+	}
+	share, errConflict := s.Repository.CreateShare(ctx, tx, *request.ToShare(userID))
+	if errConflict != nil {
+		tx.Rollback()
+		errService = exception.NewError(errConflict, exception.ErrorConflict)
+		return
+	}
+	errNotify := s.NotificationRepository.CreateNotifications(ctx, tx, model.Notifications{{
+		UserID:  share.UserID,
+		ActorID: userID,
+		TaskID:  share.TaskID,
+		Type:    model.NotificationShareInvitation,
+		Message: fmt.Sprintf("you were invited as %v on %s, share id %v", share.Permission, target, share.ShareID),
+	}})
+	if errNotify != nil {
+		tx.Rollback()
+		errService = exception.NewError(errNotify, exception.ErrorInternalServer)
+		return
+	}
+	tx.Commit()
+	return
+}
+
+func (s *ShareService) RespondShare(ctx context.Context, params web.Params) (errService error) {
+	tx := s.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	queryParams, ok := params.Query.(web.ShareRespondQuery)
+	if !ok {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("error parsing share query params"), exception.ErrorInternalServer)
+		return
+	}
+	validationQueryParams := s.Validator.Struct(queryParams)
+	if validationQueryParams != nil {
+		tx.Rollback()
+		errService = exception.NewError(validationQueryParams, exception.ErrorBadRequest)
+		return
+	}
+	ID, status, errParsing := queryParams.ToValue()
+	if errParsing != nil {
+		tx.Rollback()
+		errService = exception.NewError(errParsing, exception.ErrorInternalServer)
+		return
+	}
+	share, errShare := s.findShare(ctx, tx, params, ID)
+	if errShare != nil {
+		tx.Rollback()
+		errService = errShare
+		return
+	}
+	userID, _ := params.UserID.ToUUID()
+	if share.UserID != userID {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("only the invited user can respond to share %v", ID), exception.ErrorForbidden)
+		return
+	}
+	s.Repository.UpdateShareStatus(ctx, tx, ID, status)
+	tx.Commit()
+	return
+}
+
+func (s *ShareService) DeleteShare(ctx context.Context, params web.Params) (errService error) {
+	tx := s.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	queryParams, ok := params.Query.(web.ShareByIDQuery)
+	if !ok {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("error parsing share query params"), exception.ErrorInternalServer)
+		return
+	}
+	validationQueryParams := s.Validator.Struct(queryParams)
+	if validationQueryParams != nil {
+		tx.Rollback()
+		errService = exception.NewError(validationQueryParams, exception.ErrorBadRequest)
+		return
+	}
+	ID, errParsing := queryParams.ToValue()
+	if errParsing != nil {
+		tx.Rollback()
+		errService = exception.NewError(errParsing, exception.ErrorInternalServer)
+		return
+	}
+	if _, errShare := s.findShare(ctx, tx, params, ID); errShare != nil {
+		tx.Rollback()
+		errService = errShare
+		return
+	}
+	s.Repository.DeleteShareByID(ctx, tx, ID)
+	tx.Commit()
+	return
+}
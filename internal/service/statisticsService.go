@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"go_gin/internal/domain/model"
+	"go_gin/internal/domain/model/web"
+	"go_gin/internal/exception"
+	"gorm.io/gorm"
+	"time"
+)
+
+type StatisticsService struct {
+	DB         *gorm.DB
+	Validator  *validator.Validate
+	Repository model.StatisticsRepository
+}
+
+func NewStatisticsService(DB *gorm.DB, validator *validator.Validate, repository model.StatisticsRepository) *StatisticsService {
+	return &StatisticsService{DB: DB, Validator: validator, Repository: repository}
+}
+
+// FindStatistics sums up the todolists of the user in the range of the query.
+func (s *StatisticsService) FindStatistics(ctx context.Context, params web.Params) (response model.StatisticsResponse, errService error) {
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	return s.statistics(ctx, params, &userID)
+}
+
+// FindAllStatistics sums up the todolists of every user in the range of the query, with the number of users who
+// created any.
+func (s *StatisticsService) FindAllStatistics(ctx context.Context, params web.Params) (response model.StatisticsResponse, errService error) {
+	return s.statistics(ctx, params, nil)
+}
+
+// statistics runs every aggregation in one read-only snapshot, so that the totals agree with the series.
+func (s *StatisticsService) statistics(ctx context.Context, params web.Params, userID *uuid.UUID) (response model.StatisticsResponse, errService error) {
+	queryParams, ok := params.Query.(web.StatisticsQuery)
+	if !ok {
+		errService = exception.NewError(fmt.Errorf("error parsing statistics query params"), exception.ErrorInternalServer)
+		return
+	}
+	validationQueryParams := s.Validator.Struct(queryParams)
+	if validationQueryParams != nil {
+		errService = exception.NewError(validationQueryParams, exception.ErrorBadRequest)
+		return
+	}
+	value, errParsing := queryParams.ToValue()
+	if errParsing != nil {
+		errService = exception.NewError(errParsing, exception.ErrorBadRequest)
+		return
+	}
+
+	tx := s.DB.Begin(&sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	now := time.Now()
+	totals := s.Repository.GetStatisticsTotals(ctx, tx, *value, userID, now)
+	created := s.Repository.GetStatisticsCounts(ctx, tx, *value, userID, "created_at")
+	completed := s.Repository.GetStatisticsCounts(ctx, tx, *value, userID, "completed_at")
+	priorities := s.Repository.GetPriorityCounts(ctx, tx, *value, userID)
+	days := s.Repository.GetCompletionDays(ctx, tx, *value, userID)
+	tx.Commit()
+	response = model.NewStatisticsResponse(*value, totals, created, completed, priorities, days, now)
+	if userID == nil {
+		response.Users = &totals.Users
+	}
+	return
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"go_gin/internal/domain/model"
+	"go_gin/internal/domain/model/web"
+	"go_gin/internal/exception"
+	"gorm.io/gorm"
+)
+
+type TagService struct {
+	DB         *gorm.DB
+	Validator  *validator.Validate
+	Repository model.TagRepository
+	Access     *TodoListAccess
+}
+
+func NewTagService(DB *gorm.DB, validator *validator.Validate, repository model.TagRepository, access *TodoListAccess) *TagService {
+	return &TagService{DB: DB, Validator: validator, Repository: repository, Access: access}
+}
+
+func (t *TagService) parseTodoListID(ctx context.Context, tx *gorm.DB, params web.Params, permission model.SharePermission) (ID int, errService error) {
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	queryParams, ok := params.Query.(web.TodoListByIDQuery)
+	if !ok {
+		errService = exception.NewError(fmt.Errorf("error parsing get by id query params"), exception.ErrorInternalServer)
+		return
+	}
+	validationQueryParams := t.Validator.Struct(queryParams)
+	if validationQueryParams != nil {
+		errService = exception.NewError(validationQueryParams, exception.ErrorBadRequest)
+		return
+	}
+	value, errParsing := queryParams.ToValue()
+	if errParsing != nil {
+		errService = exception.NewError(errParsing, exception.ErrorInternalServer)
+		return
+	}
+	if _, errAccess := t.Access.Authorize(ctx, tx, value.ID, userID, permission); errAccess != nil {
+		errService = errAccess
+		return
+	}
+	ID = value.ID
+	return
+}
+
+func (t *TagService) FindTags(ctx context.Context, params web.Params) (tags []string, errService error) {
+	tx := t.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	ID, errTodoList := t.parseTodoListID(ctx, tx, params, model.PermissionViewer)
+	if errTodoList != nil {
+		tx.Rollback()
+		errService = errTodoList
+		return
+	}
+	tags = t.Repository.GetTagsByTaskID(ctx, tx, ID).ToTags()
+	tx.Commit()
+	return
+}
+
+func (t *TagService) UpdateTags(ctx context.Context, request model.TagsRequest, params web.Params) (tags []string, errService error) {
+	tx := t.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	badRequest := t.Validator.Struct(request)
+	if badRequest != nil {
+		tx.Rollback()
+		errService = exception.NewError(badRequest, exception.ErrorBadRequest)
+		return
+	}
+	ID, errTodoList := t.parseTodoListID(ctx, tx, params, model.PermissionEditor)
+	if errTodoList != nil {
+		tx.Rollback()
+		errService = errTodoList
+		return
+	}
+	tags = request.Normalize()
+	t.Repository.ReplaceTags(ctx, tx, ID, tags)
+	tx.Commit()
+	return
+}
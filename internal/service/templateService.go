@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"go_gin/internal/domain/model"
+	"go_gin/internal/domain/model/web"
+	"go_gin/internal/exception"
+	"gorm.io/gorm"
+	"time"
+)
+
+// TemplateService keeps task templates, instantiating one creates its todolists through TodoList.
+type TemplateService struct {
+	DB         *gorm.DB
+	Validator  *validator.Validate
+	Repository model.TemplateRepository
+	TodoList   *TodoListService
+}
+
+func NewTemplateService(DB *gorm.DB, validator *validator.Validate, repository model.TemplateRepository, todolist *TodoListService) *TemplateService {
+	return &TemplateService{DB: DB, Validator: validator, Repository: repository, TodoList: todolist}
+}
+
+func (t *TemplateService) validateTemplate(request model.TemplateRequest) error {
+	badRequest := t.Validator.Struct(request)
+	if badRequest != nil {
+		return exception.NewError(badRequest, exception.ErrorBadRequest)
+	}
+	for i, item := range request.Items {
+		if _, errDue := model.ParseDueOffset(item.Due); errDue != nil {
+			return exception.NewError(fmt.Errorf("item %d: %w", i, errDue), exception.ErrorBadRequest)
+		}
+	}
+	return nil
+}
+
+func (t *TemplateService) parseTemplateID(ctx context.Context, tx *gorm.DB, params web.Params) (template model.Template, errService error) {
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	queryParams, ok := params.Query.(web.TemplateByIDQuery)
+	if !ok {
+		errService = exception.NewError(fmt.Errorf("error parsing template query params"), exception.ErrorInternalServer)
+		return
+	}
+	validationQueryParams := t.Validator.Struct(queryParams)
+	if validationQueryParams != nil {
+		errService = exception.NewError(validationQueryParams, exception.ErrorBadRequest)
+		return
+	}
+	ID, errParsing := queryParams.ToValue()
+	if errParsing != nil {
+		errService = exception.NewError(errParsing, exception.ErrorInternalServer)
+		return
+	}
+	template, errNotFound := t.Repository.GetTemplateByID(ctx, tx, ID, userID)
+	if errNotFound != nil {
+		errService = exception.NewError(fmt.Errorf("template with id %v not found", ID), exception.ErrorNotFound)
+	}
+	return
+}
+
+// authorizeTarget checks that userID may create todolists owned by targetID: the user itself, an admin, or an editor
+// of the project of targetID the todolists go into.
+func (t *TemplateService) authorizeTarget(ctx context.Context, tx *gorm.DB, userID uuid.UUID, targetID uuid.UUID, projectID *int) error {
+	if targetID == userID {
+		return nil
+	}
+	if !t.TodoList.Users.UsersExistByID(ctx, tx, targetID) {
+		return exception.NewError(fmt.Errorf("user with id %v not found", targetID), exception.ErrorNotFound)
+	}
+	if user, errUser := t.TodoList.Users.GetUserByID(ctx, tx, userID); errUser == nil && user.Roles == model.Admin {
+		return nil
+	}
+	if projectID != nil && t.TodoList.Project.ProjectExistByID(ctx, tx, *projectID, targetID) {
+		return t.TodoList.Access.AuthorizeProject(ctx, tx, *projectID, userID, model.PermissionEditor)
+	}
+	return exception.NewError(fmt.Errorf("todolists of user %v can only be created by an admin or an editor of one of their projects", targetID), exception.ErrorForbidden)
+}
+
+func (t *TemplateService) FindTemplates(ctx context.Context, params web.Params) (responses model.TemplateResponses, errService error) {
+	tx := t.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	responses = t.Repository.GetTemplates(ctx, tx, userID).ToTemplateResponses()
+	tx.Commit()
+	return
+}
+
+func (t *TemplateService) FindTemplateByID(ctx context.Context, params web.Params) (response model.TemplateResponse, errService error) {
+	tx := t.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	template, errTemplate := t.parseTemplateID(ctx, tx, params)
+	if errTemplate != nil {
+		tx.Rollback()
+		errService = errTemplate
+		return
+	}
+	tx.Commit()
+	response = *template.ToTemplateResponse()
+	return
+}
+
+func (t *TemplateService) CreateTemplate(ctx context.Context, request model.TemplateRequest, params web.Params) (response model.TemplateResponse, errService error) {
+	tx := t.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	if errService = t.validateTemplate(request); errService != nil {
+		tx.Rollback()
+		return
+	}
+	template, errConflict := t.Repository.CreateTemplate(ctx, tx, *request.ToTemplate(userID))
+	if errConflict != nil {
+		tx.Rollback()
+		errService = exception.NewError(errConflict, exception.ErrorConflict)
+		return
+	}
+	tx.Commit()
+	response = *template.ToTemplateResponse()
+	return
+}
+
+func (t *TemplateService) UpdateTemplate(ctx context.Context, request model.TemplateRequest, params web.Params) (errService error) {
+	tx := t.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	if errService = t.validateTemplate(request); errService != nil {
+		tx.Rollback()
+		return
+	}
+	template, errTemplate := t.parseTemplateID(ctx, tx, params)
+	if errTemplate != nil {
+		tx.Rollback()
+		errService = errTemplate
+		return
+	}
+	if errConflict := t.Repository.UpdateTemplateByID(ctx, tx, *request.ToTemplate(template.UserID), template.TemplateID, template.UserID); errConflict != nil {
+		tx.Rollback()
+		errService = exception.NewError(errConflict, exception.ErrorConflict)
+		return
+	}
+	tx.Commit()
+	return
+}
+
+func (t *TemplateService) DeleteTemplate(ctx context.Context, params web.Params) (errService error) {
+	tx := t.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	template, errTemplate := t.parseTemplateID(ctx, tx, params)
+	if errTemplate != nil {
+		tx.Rollback()
+		errService = errTemplate
+		return
+	}
+	t.Repository.DeleteTemplateByID(ctx, tx, template.TemplateID, template.UserID)
+	tx.Commit()
+	return
+}
+
+// InstantiateTemplate creates every todolist of a template of the caller in the list of the target user in one
+// transaction, either all of them are created or none.
+func (t *TemplateService) InstantiateTemplate(ctx context.Context, request model.TemplateInstantiateRequest, params web.Params) (responses model.TodoListResponses, errService error) {
+	tx := t.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	badRequest := t.Validator.Struct(request)
+	if badRequest != nil {
+		tx.Rollback()
+		errService = exception.NewError(badRequest, exception.ErrorBadRequest)
+		return
+	}
+	template, errTemplate := t.parseTemplateID(ctx, tx, params)
+	if errTemplate != nil {
+		tx.Rollback()
+		errService = errTemplate
+		return
+	}
+	if errTarget := t.authorizeTarget(ctx, tx, template.UserID, *request.UserID, request.ProjectID); errTarget != nil {
+		tx.Rollback()
+		errService = errTarget
+		return
+	}
+	start := time.Date(request.StartDate.Year, time.Month(request.StartDate.Month), request.StartDate.Day, 0, 0, 0, 0, time.UTC)
+	requests, errDue := template.ToTodoListRequests(start, request)
+	if errDue != nil {
+		tx.Rollback()
+		errService = exception.NewError(errDue, exception.ErrorBadRequest)
+		return
+	}
+	badRequest = t.Validator.Struct(model.TodoListRequestsValidation{TodoList: requests})
+	if badRequest != nil {
+		tx.Rollback()
+		errService = exception.NewError(badRequest, exception.ErrorBadRequest)
+		return
+	}
+	todolists, errCreate := t.TodoList.createTodoLists(ctx, tx, requests, *request.UserID)
+	if errCreate != nil {
+		tx.Rollback()
+		errService = errCreate
+		return
+	}
+	tx.Commit()
+	responses = todolists.ToTodoListResponses()
+	return
+}
@@ -0,0 +1,327 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"go_gin/internal/domain/model"
+	"go_gin/internal/domain/model/web"
+	"go_gin/internal/exception"
+	"gorm.io/gorm"
+	"time"
+)
+
+// TimeService tracks the time spent on todolists with timers and manual entries, and reports on it.
+type TimeService struct {
+	DB                 *gorm.DB
+	Validator          *validator.Validate
+	Repository         model.TimeEntryRepository
+	TodoListRepository model.TodoListRepository
+	Access             *TodoListAccess
+}
+
+func NewTimeService(DB *gorm.DB, validator *validator.Validate, repository model.TimeEntryRepository, todolistRepository model.TodoListRepository, access *TodoListAccess) *TimeService {
+	return &TimeService{DB: DB, Validator: validator, Repository: repository, TodoListRepository: todolistRepository, Access: access}
+}
+
+func (t *TimeService) parseTodoList(ctx context.Context, tx *gorm.DB, params web.Params, permission model.SharePermission) (todolist model.TodoList, errService error) {
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	queryParams, ok := params.Query.(web.TodoListByIDQuery)
+	if !ok {
+		errService = exception.NewError(fmt.Errorf("error parsing get by id query params"), exception.ErrorInternalServer)
+		return
+	}
+	validationQueryParams := t.Validator.Struct(queryParams)
+	if validationQueryParams != nil {
+		errService = exception.NewError(validationQueryParams, exception.ErrorBadRequest)
+		return
+	}
+	value, errParsing := queryParams.ToValue()
+	if errParsing != nil {
+		errService = exception.NewError(errParsing, exception.ErrorInternalServer)
+		return
+	}
+	return t.Access.Authorize(ctx, tx, value.ID, userID, permission)
+}
+
+// StartTimer starts a timer on a todolist, a user can only run one timer at a time.
+func (t *TimeService) StartTimer(ctx context.Context, request model.TimerStartRequest, params web.Params) (response model.TimeEntryResponse, errService error) {
+	tx := t.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	badRequest := t.Validator.Struct(request)
+	if badRequest != nil {
+		tx.Rollback()
+		errService = exception.NewError(badRequest, exception.ErrorBadRequest)
+		return
+	}
+	todolist, errAccess := t.parseTodoList(ctx, tx, params, model.PermissionEditor)
+	if errAccess != nil {
+		tx.Rollback()
+		errService = errAccess
+		return
+	}
+	userID, _ := params.UserID.ToUUID()
+	if running, errRunning := t.Repository.GetRunningTimeEntry(ctx, tx, userID); errRunning == nil {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("a timer is already running on todolist %v, stop it first", running.TaskID), exception.ErrorConflict)
+		return
+	}
+	entry, errConflict := t.Repository.CreateTimeEntry(ctx, tx, model.TimeEntry{TaskID: todolist.TaskID, UserID: userID, StartedAt: time.Now(), Note: request.Note})
+	if errConflict != nil {
+		tx.Rollback()
+		errService = exception.NewError(errConflict, exception.ErrorConflict)
+		return
+	}
+	tx.Commit()
+	response = *entry.ToTimeEntryResponse(time.Now())
+	return
+}
+
+// StopTimer stops the running timer of the user, whatever todolist it runs on.
+func (t *TimeService) StopTimer(ctx context.Context, params web.Params) (response model.TimeEntryResponse, errService error) {
+	tx := t.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	entry, errNotFound := t.Repository.GetRunningTimeEntry(ctx, tx, userID)
+	if errNotFound != nil {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("no timer is running"), exception.ErrorNotFound)
+		return
+	}
+	now := time.Now()
+	t.Repository.StopTimeEntry(ctx, tx, entry.EntryID, now)
+	tx.Commit()
+	entry.EndedAt = &now
+	response = *entry.ToTimeEntryResponse(now)
+	return
+}
+
+// FindRunningTimer returns the running timer of the user, nil when none runs.
+func (t *TimeService) FindRunningTimer(ctx context.Context, params web.Params) (response *model.TimeEntryResponse, errService error) {
+	tx := t.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	if entry, errNotFound := t.Repository.GetRunningTimeEntry(ctx, tx, userID); errNotFound == nil {
+		response = entry.ToTimeEntryResponse(time.Now())
+	}
+	tx.Commit()
+	return
+}
+
+func (t *TimeService) CreateTimeEntry(ctx context.Context, request model.TimeEntryRequest, params web.Params) (response model.TimeEntryResponse, errService error) {
+	tx := t.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	badRequest := t.Validator.Struct(request)
+	if badRequest != nil {
+		tx.Rollback()
+		errService = exception.NewError(badRequest, exception.ErrorBadRequest)
+		return
+	}
+	if request.EndedAt.After(time.Now()) {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("ended_at cannot be in the future"), exception.ErrorBadRequest)
+		return
+	}
+	todolist, errAccess := t.parseTodoList(ctx, tx, params, model.PermissionEditor)
+	if errAccess != nil {
+		tx.Rollback()
+		errService = errAccess
+		return
+	}
+	userID, _ := params.UserID.ToUUID()
+	entry, errConflict := t.Repository.CreateTimeEntry(ctx, tx, *request.ToTimeEntry(todolist.TaskID, userID))
+	if errConflict != nil {
+		tx.Rollback()
+		errService = exception.NewError(errConflict, exception.ErrorConflict)
+		return
+	}
+	tx.Commit()
+	response = *entry.ToTimeEntryResponse(time.Now())
+	return
+}
+
+// DeleteTimeEntry deletes an entry of the user, a running timer is discarded the same way.
+func (t *TimeService) DeleteTimeEntry(ctx context.Context, params web.Params) (errService error) {
+	tx := t.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	queryParams, ok := params.Query.(web.TimeEntryByIDQuery)
+	if !ok {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("error parsing time entry query params"), exception.ErrorInternalServer)
+		return
+	}
+	validationQueryParams := t.Validator.Struct(queryParams)
+	if validationQueryParams != nil {
+		tx.Rollback()
+		errService = exception.NewError(validationQueryParams, exception.ErrorBadRequest)
+		return
+	}
+	ID, errParsing := queryParams.ToValue()
+	if errParsing != nil {
+		tx.Rollback()
+		errService = exception.NewError(errParsing, exception.ErrorInternalServer)
+		return
+	}
+	if _, errNotFound := t.Repository.GetTimeEntryByID(ctx, tx, ID, userID); errNotFound != nil {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("time entry with id %v not found", ID), exception.ErrorNotFound)
+		return
+	}
+	t.Repository.DeleteTimeEntryByID(ctx, tx, ID)
+	tx.Commit()
+	return
+}
+
+// FindTimeSummary returns the entries of a todolist with the time logged against its estimate.
+func (t *TimeService) FindTimeSummary(ctx context.Context, params web.Params) (response model.TimeSummary, errService error) {
+	tx := t.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	todolist, errAccess := t.parseTodoList(ctx, tx, params, model.PermissionViewer)
+	if errAccess != nil {
+		tx.Rollback()
+		errService = errAccess
+		return
+	}
+	entries := t.Repository.GetTimeEntriesByTaskID(ctx, tx, todolist.TaskID)
+	tx.Commit()
+	now := time.Now()
+	response = model.TimeSummary{TaskID: todolist.TaskID, EstimateMinutes: todolist.EstimateMinutes, Entries: entries.ToTimeEntryResponses(now)}
+	for _, entry := range entries {
+		response.ActualMinutes += entry.Minutes(now)
+	}
+	if todolist.EstimateMinutes != nil {
+		remaining := int64(*todolist.EstimateMinutes) - response.ActualMinutes
+		response.RemainingMinutes = &remaining
+	}
+	return
+}
+
+// UpdateEstimate sets the estimate of a todolist in minutes, null clears it.
+func (t *TimeService) UpdateEstimate(ctx context.Context, request model.TodoListEstimateRequest, params web.Params) (errService error) {
+	tx := t.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	badRequest := t.Validator.Struct(request)
+	if badRequest != nil {
+		tx.Rollback()
+		errService = exception.NewError(badRequest, exception.ErrorBadRequest)
+		return
+	}
+	todolist, errAccess := t.parseTodoList(ctx, tx, params, model.PermissionEditor)
+	if errAccess != nil {
+		tx.Rollback()
+		errService = errAccess
+		return
+	}
+	t.TodoListRepository.UpdateTodoListEstimate(ctx, tx, todolist.TaskID, request.EstimateMinutes)
+	tx.Commit()
+	return
+}
+
+// FindTimeReport sums the finished time entries per day or week, for a project when asked and else for the user.
+func (t *TimeService) FindTimeReport(ctx context.Context, params web.Params) (rows model.TimeReportRows, errService error) {
+	tx := t.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	queryParams, ok := params.Query.(web.TimeReportQuery)
+	if !ok {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("error parsing time report query params"), exception.ErrorInternalServer)
+		return
+	}
+	validationQueryParams := t.Validator.Struct(queryParams)
+	if validationQueryParams != nil {
+		tx.Rollback()
+		errService = exception.NewError(validationQueryParams, exception.ErrorBadRequest)
+		return
+	}
+	value, errParsing := queryParams.ToValue()
+	if errParsing != nil {
+		tx.Rollback()
+		errService = exception.NewError(errParsing, exception.ErrorBadRequest)
+		return
+	}
+	if value.ProjectID != nil {
+		if errAccess := t.Access.AuthorizeProject(ctx, tx, *value.ProjectID, userID, model.PermissionViewer); errAccess != nil {
+			tx.Rollback()
+			errService = errAccess
+			return
+		}
+	}
+	entries := t.Repository.GetTimeEntriesForReport(ctx, tx, *value, userID)
+	tx.Commit()
+	rows = model.BuildTimeReport(entries, value.Period, value.ProjectID != nil)
+	return
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"go_gin/internal/domain/model"
+	"go_gin/internal/exception"
+	"go_gin/internal/repository"
+	"gorm.io/gorm"
+)
+
+// TodoListAccess decides whether a user may act on a todolist, as its owner, its assignee or through an accepted share.
+type TodoListAccess struct {
+	TodoListRepository *repository.TodolistRepository
+	ShareRepository    *repository.ShareRepository
+	ProjectRepository  *repository.ProjectRepository
+}
+
+func NewTodoListAccess(todolistRepository *repository.TodolistRepository, shareRepository *repository.ShareRepository, projectRepository *repository.ProjectRepository) *TodoListAccess {
+	return &TodoListAccess{TodoListRepository: todolistRepository, ShareRepository: shareRepository, ProjectRepository: projectRepository}
+}
+
+// Authorize loads the todolist ID for userID when it owns it, is its assignee or holds a grant covering permission.
+// Todolists the user cannot see at all are reported as not found so their existence is not leaked.
+func (a *TodoListAccess) Authorize(ctx context.Context, tx *gorm.DB, ID int, userID uuid.UUID, permission model.SharePermission) (model.TodoList, error) {
+	todolist, errNotFound := a.TodoListRepository.GetTodoListByTaskID(ctx, tx, ID)
+	if errNotFound != nil {
+		return model.TodoList{}, exception.NewError(fmt.Errorf("todolist with id %v not found", ID), exception.ErrorNotFound)
+	}
+	if todolist.UserID == userID {
+		return todolist, nil
+	}
+	// the assignee works on the todolist, so it may edit it like a share editor
+	if todolist.AssigneeID != nil && *todolist.AssigneeID == userID {
+		return todolist, nil
+	}
+	grant, shared := a.ShareRepository.FindGrant(ctx, tx, todolist.TaskID, todolist.ProjectID, userID)
+	if !shared {
+		return model.TodoList{}, exception.NewError(fmt.Errorf("todolist with id %v not found", ID), exception.ErrorNotFound)
+	}
+	if !grant.Allows(permission) {
+		return model.TodoList{}, exception.NewError(fmt.Errorf("todolist with id %v is shared with you as %v", ID, grant), exception.ErrorForbidden)
+	}
+	return todolist, nil
+}
+
+// AuthorizeProject checks that userID owns the project or holds an accepted share on it covering permission.
+func (a *TodoListAccess) AuthorizeProject(ctx context.Context, tx *gorm.DB, projectID int, userID uuid.UUID, permission model.SharePermission) error {
+	if a.ProjectRepository.ProjectExistByID(ctx, tx, projectID, userID) {
+		return nil
+	}
+	grant, shared := a.ShareRepository.FindGrant(ctx, tx, 0, &projectID, userID)
+	if !shared {
+		return exception.NewError(fmt.Errorf("project with id %v not found", projectID), exception.ErrorNotFound)
+	}
+	if !grant.Allows(permission) {
+		return exception.NewError(fmt.Errorf("project with id %v is shared with you as %v", projectID, grant), exception.ErrorForbidden)
+	}
+	return nil
+}
@@ -2,29 +2,1024 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
 	"go_gin/internal/config"
 	"go_gin/internal/domain/model"
 	"go_gin/internal/domain/model/web"
 	"go_gin/internal/exception"
 	"go_gin/internal/repository"
+	"go_gin/pkg/fractional"
+	"go_gin/pkg/helper"
+	"go_gin/pkg/ical"
+	"go_gin/pkg/querylang"
+	"go_gin/pkg/storage"
+	"go_gin/pkg/taskio"
 	"gorm.io/gorm"
+	"io"
 	"math"
-	"strings"
+	"time"
 )
 
 type TodoListService struct {
 	DB         *gorm.DB
 	Validator  *validator.Validate
 	Repository *repository.TodolistRepository
+	Activity   model.ActivityRepository
+	Attachment model.AttachmentRepository
+	Storage    storage.Storage
+	Access     *TodoListAccess
+	Project    model.ProjectRepository
+	Assignment model.AssignmentRepository
+	Users      model.UsersRepository
+	Notify     model.NotificationRepository
+	Dependency model.DependencyRepository
+	Search     model.SavedSearchRepository
+	Revision   model.RevisionRepository
+	Workflow   *model.Workflow
 }
 
-func NewTodoListService(DB *gorm.DB, validator *validator.Validate, repository *repository.TodolistRepository) *TodoListService {
-	return &TodoListService{DB: DB, Validator: validator, Repository: repository}
+func NewTodoListService(DB *gorm.DB, validator *validator.Validate, repository *repository.TodolistRepository, activity model.ActivityRepository, attachment model.AttachmentRepository, storage storage.Storage, access *TodoListAccess, project model.ProjectRepository, assignment model.AssignmentRepository, users model.UsersRepository, notify model.NotificationRepository, dependency model.DependencyRepository, search model.SavedSearchRepository, revision model.RevisionRepository) *TodoListService {
+	workflow := model.NewWorkflow(config.Workflow.Initial, config.Workflow.Start, config.Workflow.Done, config.Workflow.Statuses, config.Workflow.Transitions)
+	return &TodoListService{DB: DB, Validator: validator, Repository: repository, Activity: activity, Attachment: attachment, Storage: storage, Access: access, Project: project, Assignment: assignment, Users: users, Notify: notify, Dependency: dependency, Search: search, Revision: revision, Workflow: workflow}
 }
 
-func (t *TodoListService) CreateTodoList(ctx context.Context, request model.TodoListRequest, params web.Params) (errService error) {
+// transition resolves the status a write moves todolist into and applies it, refusing moves the workflow does not allow.
+func (t *TodoListService) transition(todolist *model.TodoList, current model.TodoList, requested model.TaskStatus, completed bool) error {
+	status := t.Workflow.Resolve(current.Status, requested, completed)
+	if !t.Workflow.IsValid(status) {
+		return fmt.Errorf("status %v is not a valid status", status)
+	}
+	if current.Status != "" && !t.Workflow.CanTransition(current.Status, status) {
+		return fmt.Errorf("todolist cannot move from status %v to %v", current.Status, status)
+	}
+	todolist.StartedAt = current.StartedAt
+	todolist.CompletedAt = current.CompletedAt
+	t.Workflow.Apply(todolist, status, time.Now())
+	return nil
+}
+
+// checkRecurrence makes sure the recurrence of a todolist is a rule the calendar feed can publish.
+func (t *TodoListService) checkRecurrence(recurrence string) error {
+	if recurrence == "" {
+		return nil
+	}
+	return ical.ValidateRecurrence(recurrence)
+}
+
+// checkBlockers refuses to start or finish todolist while a todolist blocking it is still open.
+func (t *TodoListService) checkBlockers(ctx context.Context, tx *gorm.DB, todolist model.TodoList, current model.TodoList) error {
+	if todolist.Status == current.Status || (todolist.Status != t.Workflow.Start && todolist.Status != t.Workflow.Done) {
+		return nil
+	}
+	if open := t.Dependency.CountOpenBlockers(ctx, tx, current.TaskID); open > 0 {
+		return fmt.Errorf("todolist with id %v is blocked by %v open todolist", current.TaskID, open)
+	}
+	return nil
+}
+
+// checkProject makes sure a todolist is only filed under a project that belongs to its owner.
+func (t *TodoListService) checkProject(ctx context.Context, tx *gorm.DB, projectID *int, ownerID uuid.UUID) error {
+	if projectID == nil || t.Project.ProjectExistByID(ctx, tx, *projectID, ownerID) {
+		return nil
+	}
+	return fmt.Errorf("project with id %v not found", *projectID)
+}
+
+// checkAssignee makes sure a todolist is only assigned to a user that exists, nil unassigns it.
+func (t *TodoListService) checkAssignee(ctx context.Context, tx *gorm.DB, assigneeID *uuid.UUID) error {
+	if assigneeID == nil || t.Users.UsersExistByID(ctx, tx, *assigneeID) {
+		return nil
+	}
+	return exception.NewError(fmt.Errorf("user with id %v not found", *assigneeID), exception.ErrorNotFound)
+}
+
+// recordAssignment keeps the reassignment history of todolist and tells the new assignee, unless it assigned itself.
+func (t *TodoListService) recordAssignment(ctx context.Context, tx *gorm.DB, todolist model.TodoList, old *uuid.UUID, actorID uuid.UUID) error {
+	errHistory := t.Assignment.CreateAssignment(ctx, tx, model.Assignment{
+		TaskID:        todolist.TaskID,
+		AssignedBy:    actorID,
+		OldAssigneeID: old,
+		NewAssigneeID: todolist.AssigneeID,
+	})
+	if errHistory != nil || todolist.AssigneeID == nil || *todolist.AssigneeID == actorID {
+		return errHistory
+	}
+	taskID := todolist.TaskID
+	return t.Notify.CreateNotifications(ctx, tx, model.Notifications{{
+		UserID:  *todolist.AssigneeID,
+		ActorID: actorID,
+		TaskID:  &taskID,
+		Type:    model.NotificationAssignment,
+		Message: fmt.Sprintf("you were assigned to todolist %v", todolist.TaskName),
+	}})
+}
+
+func (t *TodoListService) CreateTodoList(ctx context.Context, request model.TodoListRequest, params web.Params) (taskID int, errService error) {
+	tx := t.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	badRequest := t.Validator.Struct(request)
+	if badRequest != nil {
+		tx.Rollback()
+		errService = exception.NewError(badRequest, exception.ErrorBadRequest)
+		return
+	}
+	if errProject := t.checkProject(ctx, tx, request.ProjectID, userID); errProject != nil {
+		tx.Rollback()
+		errService = exception.NewError(errProject, exception.ErrorBadRequest)
+		return
+	}
+	if errAssignee := t.checkAssignee(ctx, tx, request.AssigneeID); errAssignee != nil {
+		tx.Rollback()
+		errService = errAssignee
+		return
+	}
+	if errRecurrence := t.checkRecurrence(request.Recurrence); errRecurrence != nil {
+		tx.Rollback()
+		errService = exception.NewError(errRecurrence, exception.ErrorBadRequest)
+		return
+	}
+	todolist := request.ToTodoList(userID)
+	if errStatus := t.transition(todolist, model.TodoList{}, request.Status, request.Completed); errStatus != nil {
+		tx.Rollback()
+		errService = exception.NewError(errStatus, exception.ErrorBadRequest)
+		return
+	}
+	created, errConflict := t.Repository.CreateTodoList(ctx, tx, *todolist)
+	if errConflict != nil {
+		tx.Rollback()
+		errService = exception.NewError(errConflict, exception.ErrorConflict)
+		return
+	}
+	if created.AssigneeID != nil {
+		if errAssign := t.recordAssignment(ctx, tx, created, nil, userID); errAssign != nil {
+			tx.Rollback()
+			errService = exception.NewError(errAssign, exception.ErrorInternalServer)
+			return
+		}
+	}
+	tx.Commit()
+	taskID = created.TaskID
+	return
+}
+
+// createTodoLists creates validated requests for userID, assigned ones are recorded and their assignee notified.
+// It returns the todolists as created.
+func (t *TodoListService) createTodoLists(ctx context.Context, tx *gorm.DB, requests model.TodoListRequests, userID uuid.UUID) (model.TodoLists, error) {
+	todolists := requests.ToTodoLists(userID)
+	for i := range todolists {
+		if errProject := t.checkProject(ctx, tx, todolists[i].ProjectID, userID); errProject != nil {
+			return nil, exception.NewError(errProject, exception.ErrorBadRequest)
+		}
+		if errAssignee := t.checkAssignee(ctx, tx, todolists[i].AssigneeID); errAssignee != nil {
+			return nil, errAssignee
+		}
+		if errRecurrence := t.checkRecurrence(todolists[i].Recurrence); errRecurrence != nil {
+			return nil, exception.NewError(errRecurrence, exception.ErrorBadRequest)
+		}
+		if errStatus := t.transition(&todolists[i], model.TodoList{}, requests[i].Status, requests[i].Completed); errStatus != nil {
+			return nil, exception.NewError(errStatus, exception.ErrorBadRequest)
+		}
+	}
+	errConflict := t.Repository.CreateTodoLists(ctx, tx, todolists)
+	if errConflict != nil {
+		return nil, exception.NewError(errConflict, exception.ErrorConflict)
+	}
+	for _, todolist := range todolists {
+		if todolist.AssigneeID == nil {
+			continue
+		}
+		if errAssign := t.recordAssignment(ctx, tx, todolist, nil, userID); errAssign != nil {
+			return nil, exception.NewError(errAssign, exception.ErrorInternalServer)
+		}
+	}
+	return todolists, nil
+}
+
+func (t *TodoListService) CreatesTodoLists(ctx context.Context, requests model.TodoListRequests, params web.Params) (errService error) {
+	tx := t.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	requestValidation := model.TodoListRequestsValidation{TodoList: requests}
+	badRequest := t.Validator.Struct(requestValidation)
+	if badRequest != nil {
+		tx.Rollback()
+		errService = exception.NewError(badRequest, exception.ErrorBadRequest)
+		return
+	}
+	if _, errService = t.createTodoLists(ctx, tx, requests, userID); errService != nil {
+		tx.Rollback()
+		return
+	}
+	tx.Commit()
+	return
+}
+
+// ImportTodoLists creates the todolists read from body in one transaction through the same batched insert as
+// CreatesTodoLists. Every record is checked first, nothing is created when one of them fails.
+func (t *TodoListService) ImportTodoLists(ctx context.Context, body io.Reader, params web.Params) (response model.TodoListImportResponse, errService error) {
+	tx := t.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	queryParams, ok := params.Query.(web.TodoListImportQuery)
+	if !ok {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("error parsing import query params"), exception.ErrorInternalServer)
+		return
+	}
+	validationQueryParams := t.Validator.Struct(queryParams)
+	if validationQueryParams != nil {
+		tx.Rollback()
+		errService = exception.NewError(validationQueryParams, exception.ErrorBadRequest)
+		return
+	}
+	value, errParsing := queryParams.ToValue()
+	if errParsing != nil {
+		tx.Rollback()
+		errService = exception.NewError(errParsing, exception.ErrorBadRequest)
+		return
+	}
+	records, errRead := taskio.Read(value.Format, body, value.Mapping, web.TodoListImportFields)
+	if errRead != nil {
+		tx.Rollback()
+		errService = exception.NewError(errRead, exception.ErrorBadRequest)
+		return
+	}
+	response.DryRun = value.DryRun
+	response.Total = len(records)
+	response.Errors = []model.TodoListImportError{}
+	response.Warnings = []model.TodoListImportWarning{}
+	var requests model.TodoListRequests
+	for _, record := range records {
+		request, errRecord := model.NewTodoListImportRequest(record.Fields)
+		for _, warning := range record.Warnings {
+			response.Warnings = append(response.Warnings, model.TodoListImportWarning{Line: record.Line, TaskName: request.TaskName, Warning: warning})
+		}
+		if errRecord == nil {
+			errRecord = t.Validator.Struct(request)
+		}
+		if errRecord == nil {
+			errRecord = t.checkProject(ctx, tx, request.ProjectID, userID)
+		}
+		if errRecord == nil {
+			errRecord = t.checkRecurrence(request.Recurrence)
+		}
+		if errRecord == nil {
+			errRecord = t.transition(request.ToTodoList(userID), model.TodoList{}, request.Status, request.Completed)
+		}
+		if errRecord != nil {
+			response.Errors = append(response.Errors, model.TodoListImportError{Line: record.Line, TaskName: request.TaskName, Error: errRecord.Error()})
+			continue
+		}
+		requests = append(requests, request)
+	}
+	response.Failed = len(response.Errors)
+	if response.Failed > 0 || value.DryRun || len(requests) == 0 {
+		tx.Rollback()
+		return
+	}
+	if _, errService = t.createTodoLists(ctx, tx, requests, userID); errService != nil {
+		tx.Rollback()
+		return
+	}
+	tx.Commit()
+	response.Created = len(requests)
+	return
+}
+
+// ExportTodoLists writes the todolists of the user matching the query to out, config.Other.BatchSize at a time from
+// a single snapshot so that a long export stays consistent.
+func (t *TodoListService) ExportTodoLists(ctx context.Context, params web.Params, out io.Writer) (errService error) {
+	tx := t.DB.Begin(&sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	queryParams, ok := params.Query.(web.TodoListExportQuery)
+	if !ok {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("error parsing export query params"), exception.ErrorInternalServer)
+		return
+	}
+	validationQueryParams := t.Validator.Struct(queryParams)
+	if validationQueryParams != nil {
+		tx.Rollback()
+		errService = exception.NewError(validationQueryParams, exception.ErrorBadRequest)
+		return
+	}
+	value, errParsing := queryParams.ToValue()
+	if errParsing != nil {
+		tx.Rollback()
+		errService = exception.NewError(errParsing, exception.ErrorBadRequest)
+		return
+	}
+	writer, errWrite := taskio.NewWriter(value.Format, out, value.Fields)
+	afterID := 0
+	for errWrite == nil {
+		todolists := t.Repository.GetTodoListsAfterID(ctx, tx, web.TodoListsValue{Query: value.Query}, userID, afterID, config.Other.BatchSize)
+		if len(todolists) == 0 {
+			errWrite = writer.Close()
+			break
+		}
+		afterID = todolists[len(todolists)-1].TaskID
+		items, errSelect := todolists.ToTodoListResponses().SelectFields(value.Fields)
+		if errSelect != nil {
+			errWrite = errSelect
+			break
+		}
+		for _, item := range items {
+			if errWrite = writer.Write(item); errWrite != nil {
+				break
+			}
+		}
+		if errWrite == nil {
+			errWrite = writer.Flush()
+		}
+	}
+	tx.Commit()
+	if errWrite != nil {
+		errService = exception.NewError(errWrite, exception.ErrorInternalServer)
+	}
+	return
+}
+
+func (t *TodoListService) UpdateTodoList(ctx context.Context, request model.TodoListRequest, params web.Params) (version int, errService error) {
+	tx := t.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	if params.Query == nil {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("error query params is nil"), exception.ErrorInternalServer)
+		return
+	}
+	badRequest := t.Validator.Struct(request)
+	if badRequest != nil {
+		tx.Rollback()
+		errService = exception.NewError(badRequest, exception.ErrorBadRequest)
+		return
+	}
+	userID, validUUID := params.UserID.ToUUID()
+	queryParams, ok := params.Query.(web.TodoListByIDQuery)
+	if !ok {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("error parsing get by id query params"), exception.ErrorInternalServer)
+		return
+	}
+
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+
+	validationQueryParams := t.Validator.Struct(queryParams)
+	if validationQueryParams != nil {
+		tx.Rollback()
+		errService = exception.NewError(validationQueryParams, exception.ErrorBadRequest)
+		return
+	}
+	value, errParsing := queryParams.ToValue()
+	if errParsing != nil {
+		tx.Rollback()
+		errService = exception.NewError(errParsing, exception.ErrorInternalServer)
+		return
+	}
+	// the lock comes first so that the todolist checked below is the one the update is made against
+	locked := t.Repository.LockTodoListVersion(ctx, tx, value.ID)
+	current, errAccess := t.Access.Authorize(ctx, tx, value.ID, userID, model.PermissionEditor)
+	if errAccess != nil {
+		tx.Rollback()
+		errService = errAccess
+		return
+	}
+	if errMatch := checkIfMatch(params.IfMatch, locked, fmt.Sprintf("todolist with id %v", value.ID)); errMatch != nil {
+		tx.Rollback()
+		errService = errMatch
+		return
+	}
+	if errUpdate := t.update(ctx, tx, request.ToTodoList(current.UserID), current, userID, request.Status, request.Completed); errUpdate != nil {
+		tx.Rollback()
+		errService = errUpdate
+		return
+	}
+	version = t.Repository.LockTodoListVersion(ctx, tx, value.ID)
+	tx.Commit()
+	return
+}
+
+// update writes the editable fields of todolist over current as userID, checking the project, the workflow and the
+// blockers the same way whether the values come from a request or from a revision.
+func (t *TodoListService) update(ctx context.Context, tx *gorm.DB, todolist *model.TodoList, current model.TodoList, userID uuid.UUID, status model.TaskStatus, completed bool) error {
+	if current.UserID != userID {
+		// only the owner may move a todolist between its projects
+		todolist.ProjectID = current.ProjectID
+	}
+	if errProject := t.checkProject(ctx, tx, todolist.ProjectID, current.UserID); errProject != nil {
+		return exception.NewError(errProject, exception.ErrorBadRequest)
+	}
+	if errRecurrence := t.checkRecurrence(todolist.Recurrence); errRecurrence != nil {
+		return exception.NewError(errRecurrence, exception.ErrorBadRequest)
+	}
+	if errStatus := t.transition(todolist, current, status, completed); errStatus != nil {
+		return exception.NewError(errStatus, exception.ErrorBadRequest)
+	}
+	if errBlocked := t.checkBlockers(ctx, tx, *todolist, current); errBlocked != nil {
+		return exception.NewError(errBlocked, exception.ErrorConflict)
+	}
+	t.Repository.UpdateTodoListByID(ctx, tx, *todolist, current.TaskID, current.UserID)
+	if errRecord := t.record(ctx, tx, current, *todolist, userID); errRecord != nil {
+		return exception.NewError(errRecord, exception.ErrorInternalServer)
+	}
+	return nil
+}
+
+// record logs the activities of a change of a todolist from before to after made by userID and keeps the revision it
+// produced. The first recorded change also keeps the state before it as revision 1.
+func (t *TodoListService) record(ctx context.Context, tx *gorm.DB, before model.TodoList, after model.TodoList, userID uuid.UUID) error {
+	if err := t.Activity.CreateActivities(ctx, tx, model.DiffTodoList(before, after, userID)); err != nil {
+		return err
+	}
+	changes := model.DiffRevision(before, after)
+	if len(changes) == 0 {
+		return nil
+	}
+	after.TaskID = before.TaskID
+	var revisions model.TodoListRevisions
+	number := t.Revision.GetLastRevisionNumber(ctx, tx, before.TaskID)
+	if number == 0 {
+		number++
+		baseline := model.NewTodoListRevision(before, number, before.UserID, nil)
+		baseline.CreatedAt = before.UpdatedAt
+		revisions = append(revisions, *baseline)
+	}
+	revisions = append(revisions, *model.NewTodoListRevision(after, number+1, userID, changes))
+	return t.Revision.CreateRevisions(ctx, tx, revisions)
+}
+
+// UpdatesTodoLists applies one patch to many todolists in a single transaction. Every todolist is tried so that the
+// report is complete, but nothing is written when one of them fails or when the request is a dry run.
+func (t *TodoListService) UpdatesTodoLists(ctx context.Context, request model.TodoListBulkUpdateRequest, params web.Params) (response model.TodoListBulkUpdateResponse, errService error) {
+	tx := t.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	badRequest := t.Validator.Struct(request)
+	if badRequest == nil && request.Patch.IsEmpty() {
+		badRequest = fmt.Errorf("patch does not set any field")
+	}
+	if badRequest != nil {
+		tx.Rollback()
+		errService = exception.NewError(badRequest, exception.ErrorBadRequest)
+		return
+	}
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	IDs := request.IDs
+	if request.Filter != "" {
+		filter, errFilter := querylang.Parse(request.Filter, web.TodoListQueryFields)
+		if errFilter != nil {
+			tx.Rollback()
+			errService = exception.NewError(errFilter, exception.ErrorBadRequest)
+			return
+		}
+		IDs = t.Repository.GetTodoListIDs(ctx, tx, web.TodoListsValue{Query: filter}, userID)
+	}
+	if len(IDs) > config.Other.LimitInsert {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("bulk update matches %v todolists, at most %v are allowed", len(IDs), config.Other.LimitInsert), exception.ErrorBadRequest)
+		return
+	}
+	response.DryRun = request.DryRun
+	response.Results = []model.TodoListBulkResult{}
+	seen := map[int]bool{}
+	for _, ID := range IDs {
+		if seen[ID] {
+			continue
+		}
+		seen[ID] = true
+		result := t.patch(ctx, tx, ID, request.Patch, userID)
+		switch result.Result {
+		case model.BulkUpdated:
+			response.Updated++
+		case model.BulkUnchanged:
+			response.Unchanged++
+		case model.BulkFailed:
+			response.Failed++
+		}
+		response.Results = append(response.Results, result)
+	}
+	response.Matched = len(response.Results)
+	if request.DryRun || response.Failed > 0 {
+		tx.Rollback()
+		return
+	}
+	tx.Commit()
+	response.Applied = true
+	return
+}
+
+// patch applies the patch of a bulk update to the todolist ID as userID with the checks of a single update.
+func (t *TodoListService) patch(ctx context.Context, tx *gorm.DB, ID int, patch model.TodoListPatch, userID uuid.UUID) model.TodoListBulkResult {
+	result := model.TodoListBulkResult{TaskID: ID}
+	current, errAccess := t.Access.Authorize(ctx, tx, ID, userID, model.PermissionEditor)
+	if errAccess != nil {
+		result.Result, result.Error = model.BulkFailed, bulkError(errAccess)
+		return result
+	}
+	todolist, status, completed := patch.Apply(current)
+	if errUpdate := t.update(ctx, tx, &todolist, current, userID, status, completed); errUpdate != nil {
+		result.Result, result.Error = model.BulkFailed, bulkError(errUpdate)
+		return result
+	}
+	result.Changes = model.DiffRevision(current, todolist)
+	result.Result = model.BulkUpdated
+	if len(result.Changes) == 0 {
+		result.Result = model.BulkUnchanged
+	}
+	return result
+}
+
+// bulkError is the message of err without its type, the type shows in the result of the item already.
+func bulkError(err error) string {
+	typeError := &exception.Error{}
+	if errors.As(err, &typeError) {
+		return typeError.MessageError().Error()
+	}
+	return err.Error()
+}
+
+func (t *TodoListService) UpdateTodoListStatus(ctx context.Context, request model.TodoListStatusRequest, params web.Params) (errService error) {
+	tx := t.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	if params.Query == nil {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("error query params is nil"), exception.ErrorInternalServer)
+		return
+	}
+	badRequest := t.Validator.Struct(request)
+	if badRequest != nil {
+		tx.Rollback()
+		errService = exception.NewError(badRequest, exception.ErrorBadRequest)
+		return
+	}
+	userID, validUUID := params.UserID.ToUUID()
+	queryParams, ok := params.Query.(web.TodoListByIDQuery)
+	if !ok {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("error parsing get by id query params"), exception.ErrorInternalServer)
+		return
+	}
+
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+
+	validationQueryParams := t.Validator.Struct(queryParams)
+	if validationQueryParams != nil {
+		tx.Rollback()
+		errService = exception.NewError(validationQueryParams, exception.ErrorBadRequest)
+		return
+	}
+	value, errParsing := queryParams.ToValue()
+	if errParsing != nil {
+		tx.Rollback()
+		errService = exception.NewError(errParsing, exception.ErrorInternalServer)
+		return
+	}
+	todolist, errAccess := t.Access.Authorize(ctx, tx, value.ID, userID, model.PermissionEditor)
+	if errAccess != nil {
+		tx.Rollback()
+		errService = errAccess
+		return
+	}
+	current := todolist
+	if errStatus := t.transition(&todolist, current, request.Status, false); errStatus != nil {
+		tx.Rollback()
+		errService = exception.NewError(errStatus, exception.ErrorBadRequest)
+		return
+	}
+	if errBlocked := t.checkBlockers(ctx, tx, todolist, current); errBlocked != nil {
+		tx.Rollback()
+		errService = exception.NewError(errBlocked, exception.ErrorConflict)
+		return
+	}
+	t.Repository.UpdateTodoListStatus(ctx, tx, todolist, value.ID, todolist.UserID)
+	if errRecord := t.record(ctx, tx, current, todolist, userID); errRecord != nil {
+		tx.Rollback()
+		errService = exception.NewError(errRecord, exception.ErrorInternalServer)
+		return
+	}
+	tx.Commit()
+	return
+}
+
+func (t *TodoListService) FindTodoListsGroupByStatus(ctx context.Context, params web.Params) (groups []model.TodoListStatusGroup, errService error) {
+	tx := t.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	counts := t.Repository.CountTodoListsByStatus(ctx, tx, userID)
+	for _, status := range t.Workflow.Statuses {
+		value := web.TodoListsValue{Page: 1, Limit: config.Other.Limit, Status: []string{string(status)}}
+		groups = append(groups, model.TodoListStatusGroup{
+			Status:   status,
+			Count:    counts[status],
+			TodoList: t.Repository.GetTodoLists(ctx, tx, value, userID).ToTodoListResponses(),
+		})
+	}
+	tx.Commit()
+	return
+}
+
+// FindRevisions returns the history of a todolist newest first, each revision with the fields it changed.
+func (t *TodoListService) FindRevisions(ctx context.Context, params web.Params) (responses []model.TodoListRevisionResponse, errService error) {
+	tx := t.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	queryParams, ok := params.Query.(web.TodoListByIDQuery)
+	if !ok {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("error parsing get by id query params"), exception.ErrorInternalServer)
+		return
+	}
+	validationQueryParams := t.Validator.Struct(queryParams)
+	if validationQueryParams != nil {
+		tx.Rollback()
+		errService = exception.NewError(validationQueryParams, exception.ErrorBadRequest)
+		return
+	}
+	value, errParsing := queryParams.ToValue()
+	if errParsing != nil {
+		tx.Rollback()
+		errService = exception.NewError(errParsing, exception.ErrorInternalServer)
+		return
+	}
+	if _, errAccess := t.Access.Authorize(ctx, tx, value.ID, userID, model.PermissionViewer); errAccess != nil {
+		tx.Rollback()
+		errService = errAccess
+		return
+	}
+	responses = t.Revision.GetRevisionsByTaskID(ctx, tx, value.ID).ToTodoListRevisionResponses()
+	tx.Commit()
+	return
+}
+
+// RevertTodoList writes the fields of a previous revision back as a new update, which becomes the latest revision.
+func (t *TodoListService) RevertTodoList(ctx context.Context, request model.TodoListRevertRequest, params web.Params) (errService error) {
+	tx := t.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	badRequest := t.Validator.Struct(request)
+	if badRequest != nil {
+		tx.Rollback()
+		errService = exception.NewError(badRequest, exception.ErrorBadRequest)
+		return
+	}
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	queryParams, ok := params.Query.(web.TodoListByIDQuery)
+	if !ok {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("error parsing get by id query params"), exception.ErrorInternalServer)
+		return
+	}
+	validationQueryParams := t.Validator.Struct(queryParams)
+	if validationQueryParams != nil {
+		tx.Rollback()
+		errService = exception.NewError(validationQueryParams, exception.ErrorBadRequest)
+		return
+	}
+	value, errParsing := queryParams.ToValue()
+	if errParsing != nil {
+		tx.Rollback()
+		errService = exception.NewError(errParsing, exception.ErrorInternalServer)
+		return
+	}
+	current, errAccess := t.Access.Authorize(ctx, tx, value.ID, userID, model.PermissionEditor)
+	if errAccess != nil {
+		tx.Rollback()
+		errService = errAccess
+		return
+	}
+	revision, errNotFound := t.Revision.GetRevision(ctx, tx, value.ID, request.Number)
+	if errNotFound != nil {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("revision %v of todolist %v not found", request.Number, value.ID), exception.ErrorNotFound)
+		return
+	}
+	todolist := revision.ToTodoList(current.UserID)
+	// the recurrence is not versioned, a revert keeps it
+	todolist.Recurrence = current.Recurrence
+	if errUpdate := t.update(ctx, tx, todolist, current, userID, revision.Status, revision.Completed); errUpdate != nil {
+		tx.Rollback()
+		errService = errUpdate
+		return
+	}
+	tx.Commit()
+	return
+}
+
+// undo returns the token that restores the todolists userID moved to the trash at deletedAt.
+func undo(userID uuid.UUID, deletedAt time.Time) model.TodoListUndoResponse {
+	return model.TodoListUndoResponse{
+		Token:     helper.NewUndoToken(userID, deletedAt),
+		ExpiresAt: deletedAt.Add(time.Duration(config.Trash.UndoMinutes) * time.Minute),
+	}
+}
+
+// DeleteTodoList moves a todolist to the trash, it is purged for good once config.Trash.RetentionDays passed.
+func (t *TodoListService) DeleteTodoList(ctx context.Context, params web.Params) (response model.TodoListUndoResponse, errService error) {
+	tx := t.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	if params.Query == nil {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("error query params is nil"), exception.ErrorInternalServer)
+		return
+	}
+	userID, validUUID := params.UserID.ToUUID()
+	queryParams, ok := params.Query.(web.TodoListByIDQuery)
+	if !ok {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("error parsing get by id query params"), exception.ErrorInternalServer)
+		return
+	}
+
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+
+	validationQueryParams := t.Validator.Struct(queryParams)
+	if validationQueryParams != nil {
+		tx.Rollback()
+		errService = exception.NewError(validationQueryParams, exception.ErrorBadRequest)
+		return
+	}
+	value, errParsing := queryParams.ToValue()
+	if errParsing != nil {
+		tx.Rollback()
+		errService = exception.NewError(errParsing, exception.ErrorInternalServer)
+		return
+	}
+	exist := t.Repository.TodoListExistByID(ctx, tx, value.ID, userID)
+	if !exist {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("todolist with id %v not found", value.ID), exception.ErrorNotFound)
+		return
+	}
+	if errMatch := checkIfMatch(params.IfMatch, t.Repository.LockTodoListVersion(ctx, tx, value.ID), fmt.Sprintf("todolist with id %v", value.ID)); errMatch != nil {
+		tx.Rollback()
+		errService = errMatch
+		return
+	}
+	deletedAt := time.Now().Truncate(time.Microsecond)
+	t.Repository.DeleteTodoListByID(ctx, tx, value.ID, userID, deletedAt)
+	tx.Commit()
+	response = undo(userID, deletedAt)
+	return
+}
+
+func (t *TodoListService) DeletesTodoLists(ctx context.Context, params web.Params) (response model.TodoListUndoResponse, errService error) {
+	tx := t.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	if params.Query == nil {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("error query params is nil"), exception.ErrorInternalServer)
+		return
+	}
+	userID, validUUID := params.UserID.ToUUID()
+	queryParams, ok := params.Query.(web.TodoListByIDsQuery)
+	if !ok {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("error parsing get by id query params"), exception.ErrorInternalServer)
+		return
+	}
+
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+
+	validationQueryParams := t.Validator.Struct(queryParams)
+	if validationQueryParams != nil {
+		tx.Rollback()
+		errService = exception.NewError(validationQueryParams, exception.ErrorBadRequest)
+		return
+	}
+	value, errParsing := queryParams.ToValue()
+	if errParsing != nil {
+		tx.Rollback()
+		errService = exception.NewError(errParsing, exception.ErrorInternalServer)
+		return
+	}
+	exist := t.Repository.TodoListsExistByIDs(ctx, tx, value.IDs, userID)
+	if !exist {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("todolist with id %v not found", value.IDs), exception.ErrorNotFound)
+		return
+	}
+	deletedAt := time.Now().Truncate(time.Microsecond)
+	t.Repository.DeleteTodoListsByIDs(ctx, tx, value.IDs, userID, deletedAt)
+	tx.Commit()
+	response = undo(userID, deletedAt)
+	return
+}
+
+func (t *TodoListService) FindTrashedTodoLists(ctx context.Context, params web.Params) (responses model.TodoListResponses, pagination web.Pagination, errService error) {
+	tx := t.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	queryParams, ok := params.Query.(web.GetAllQuery)
+	if !ok {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("error parsing get all query params"), exception.ErrorInternalServer)
+		return
+	}
+	validationQueryParams := t.Validator.Struct(queryParams)
+	if validationQueryParams != nil {
+		tx.Rollback()
+		errService = exception.NewError(validationQueryParams, exception.ErrorBadRequest)
+		return
+	}
+	value, errParsing := queryParams.ToValue()
+	if errParsing != nil {
+		tx.Rollback()
+		errService = exception.NewError(errParsing, exception.ErrorBadRequest)
+		return
+	}
+	responses = t.Repository.GetTrashedTodoLists(ctx, tx, *value, userID).ToTodoListResponses()
+	totalData := t.Repository.CountTrashedTodoLists(ctx, tx, userID)
+	tx.Commit()
+	pagination = web.NewPagination(value.Page, value.Limit, totalData)
+	return
+}
+
+func (t *TodoListService) RestoreTodoList(ctx context.Context, params web.Params) (errService error) {
+	tx := t.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	queryParams, ok := params.Query.(web.TodoListByIDQuery)
+	if !ok {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("error parsing get by id query params"), exception.ErrorInternalServer)
+		return
+	}
+	validationQueryParams := t.Validator.Struct(queryParams)
+	if validationQueryParams != nil {
+		tx.Rollback()
+		errService = exception.NewError(validationQueryParams, exception.ErrorBadRequest)
+		return
+	}
+	value, errParsing := queryParams.ToValue()
+	if errParsing != nil {
+		tx.Rollback()
+		errService = exception.NewError(errParsing, exception.ErrorInternalServer)
+		return
+	}
+	if !t.Repository.TrashedTodoListsExistByIDs(ctx, tx, []int{value.ID}, userID) {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("todolist with id %v not found in trash", value.ID), exception.ErrorNotFound)
+		return
+	}
+	t.Repository.RestoreTodoListsByIDs(ctx, tx, []int{value.ID}, userID)
+	tx.Commit()
+	return
+}
+
+func (t *TodoListService) RestoresTodoLists(ctx context.Context, params web.Params) (errService error) {
 	tx := t.DB.Begin()
 	defer func() {
 		if r := recover(); r != nil {
@@ -39,23 +1034,74 @@ func (t *TodoListService) CreateTodoList(ctx context.Context, request model.Todo
 		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
 		return
 	}
+	queryParams, ok := params.Query.(web.TodoListByIDsQuery)
+	if !ok {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("error parsing get by ids query params"), exception.ErrorInternalServer)
+		return
+	}
+	validationQueryParams := t.Validator.Struct(queryParams)
+	if validationQueryParams != nil {
+		tx.Rollback()
+		errService = exception.NewError(validationQueryParams, exception.ErrorBadRequest)
+		return
+	}
+	value, errParsing := queryParams.ToValue()
+	if errParsing != nil {
+		tx.Rollback()
+		errService = exception.NewError(errParsing, exception.ErrorInternalServer)
+		return
+	}
+	if !t.Repository.TrashedTodoListsExistByIDs(ctx, tx, value.IDs, userID) {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("todolist with id %v not found in trash", value.IDs), exception.ErrorNotFound)
+		return
+	}
+	t.Repository.RestoreTodoListsByIDs(ctx, tx, value.IDs, userID)
+	tx.Commit()
+	return
+}
+
+// UndoDeleteTodoLists restores the todolists removed by the delete call that returned the token, those purged or
+// restored since are skipped.
+func (t *TodoListService) UndoDeleteTodoLists(ctx context.Context, request model.TodoListUndoRequest, params web.Params) (errService error) {
+	tx := t.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
 	badRequest := t.Validator.Struct(request)
 	if badRequest != nil {
 		tx.Rollback()
 		errService = exception.NewError(badRequest, exception.ErrorBadRequest)
 		return
 	}
-	errConflict := t.Repository.CreateTodoList(ctx, tx, *request.ToTodoList(userID))
-	if errConflict != nil {
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
 		tx.Rollback()
-		errService = exception.NewError(errConflict, exception.ErrorConflict)
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	deletedAt, errToken := helper.ParseUndoToken(request.Token, userID, time.Now())
+	if errToken != nil {
+		tx.Rollback()
+		errService = exception.NewError(errToken, exception.ErrorBadRequest)
+		return
+	}
+	if t.Repository.RestoreTodoListsDeletedAt(ctx, tx, userID, deletedAt) == 0 {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("nothing left to undo"), exception.ErrorNotFound)
 		return
 	}
 	tx.Commit()
 	return
 }
 
-func (t *TodoListService) CreatesTodoLists(ctx context.Context, requests model.TodoListRequests, params web.Params) (errService error) {
+// EmptyTrash purges every todolist in the trash of the user right away.
+func (t *TodoListService) EmptyTrash(ctx context.Context, params web.Params) (errService error) {
 	tx := t.DB.Begin()
 	defer func() {
 		if r := recover(); r != nil {
@@ -70,24 +1116,42 @@ func (t *TodoListService) CreatesTodoLists(ctx context.Context, requests model.T
 		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
 		return
 	}
-	requestValidation := model.TodoListRequestsValidation{TodoList: requests}
-	badRequest := t.Validator.Struct(requestValidation)
-	if badRequest != nil {
+	IDs := t.Repository.GetTrashedTodoListIDs(ctx, tx, &userID, nil)
+	if len(IDs) == 0 {
 		tx.Rollback()
-		errService = exception.NewError(badRequest, exception.ErrorBadRequest)
 		return
 	}
-	errConflict := t.Repository.CreateTodoLists(ctx, tx, requests.ToTodoLists(userID))
-	if errConflict != nil {
+	attachments := t.Attachment.GetAttachmentsByTaskIDs(ctx, tx, IDs)
+	t.Repository.PurgeTodoListsByIDs(ctx, tx, IDs)
+	tx.Commit()
+	removeBlobs(ctx, t.Storage, attachments)
+	return
+}
+
+// PurgeTrash purges the todolists of every user deleted before deletedBefore, it runs on a schedule.
+func (t *TodoListService) PurgeTrash(ctx context.Context, deletedBefore time.Time) (purged int, errService error) {
+	tx := t.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	IDs := t.Repository.GetTrashedTodoListIDs(ctx, tx, nil, &deletedBefore)
+	if len(IDs) == 0 {
 		tx.Rollback()
-		errService = exception.NewError(errConflict, exception.ErrorConflict)
 		return
 	}
+	attachments := t.Attachment.GetAttachmentsByTaskIDs(ctx, tx, IDs)
+	t.Repository.PurgeTodoListsByIDs(ctx, tx, IDs)
 	tx.Commit()
+	removeBlobs(ctx, t.Storage, attachments)
+	purged = len(IDs)
 	return
 }
 
-func (t *TodoListService) UpdateTodoList(ctx context.Context, request model.TodoListRequest, params web.Params) (errService error) {
+func (t *TodoListService) FindTodoListsBySearch(ctx context.Context, params web.Params) (responses model.TodoListSearchResponses, pagination web.Pagination, errService error) {
 	tx := t.DB.Begin()
 	defer func() {
 		if r := recover(); r != nil {
@@ -101,50 +1165,82 @@ func (t *TodoListService) UpdateTodoList(ctx context.Context, request model.Todo
 		errService = exception.NewError(fmt.Errorf("error query params is nil"), exception.ErrorInternalServer)
 		return
 	}
-	badRequest := t.Validator.Struct(request)
-	if badRequest != nil {
-		tx.Rollback()
-		errService = exception.NewError(badRequest, exception.ErrorBadRequest)
-		return
-	}
 	userID, validUUID := params.UserID.ToUUID()
-	queryParams, ok := params.Query.(web.TodoListByIDQuery)
+	queryParams, ok := params.Query.(web.SearchQuery)
 	if !ok {
 		tx.Rollback()
-		errService = exception.NewError(fmt.Errorf("error parsing get by id query params"), exception.ErrorInternalServer)
+		errService = exception.NewError(fmt.Errorf("error parsing search query"), exception.ErrorInternalServer)
 		return
 	}
-
 	if validUUID != nil {
 		tx.Rollback()
 		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
 		return
 	}
-
 	validationQueryParams := t.Validator.Struct(queryParams)
 	if validationQueryParams != nil {
 		tx.Rollback()
 		errService = exception.NewError(validationQueryParams, exception.ErrorBadRequest)
 		return
 	}
+
 	value, errParsing := queryParams.ToValue()
 	if errParsing != nil {
 		tx.Rollback()
 		errService = exception.NewError(errParsing, exception.ErrorInternalServer)
 		return
 	}
-	exist := t.Repository.TodoListExistByID(ctx, tx, value.ID, userID)
-	if !exist {
+	user, errUser := t.Users.GetUserByID(ctx, tx, userID)
+	if errUser != nil {
 		tx.Rollback()
-		errService = exception.NewError(fmt.Errorf("todolist with id %v not found", value.ID), exception.ErrorNotFound)
+		errService = exception.NewError(fmt.Errorf("user with id %v not found", userID), exception.ErrorNotFound)
 		return
 	}
-	t.Repository.UpdateTodoListByID(ctx, tx, *request.ToTodoList(userID), value.ID, userID)
+	responses = t.Repository.GetTodoListsSearch(ctx, tx, *value, userID, user.SearchLanguage).ToTodoListSearchResponses()
+	totalData := t.Repository.CountTodoListsSearch(ctx, tx, *value, userID, user.SearchLanguage)
 	tx.Commit()
+	totalPage := int(math.Ceil(float64(totalData) / float64(value.Limit)))
+	pagination = web.Pagination{
+		Next:      value.Page + 1,
+		Current:   value.Page,
+		Previous:  value.Page - 1,
+		TotalPage: totalPage,
+		Data:      int(totalData),
+	}
+	return
+}
+
+// pageTodoLists reads one page of the todolists matching value, by keyset when value has a cursor and by offset otherwise.
+func pageTodoLists(ctx context.Context, tx *gorm.DB, repository model.TodoListRepository, value web.TodoListsValue, userID uuid.UUID) (todolists model.TodoLists, pagination web.Pagination, errService error) {
+	totalData := repository.CountTodoLists(ctx, tx, value, userID)
+	if value.Cursor != nil {
+		var next, prev *web.Cursor
+		var errCursor error
+		todolists, next, prev, errCursor = repository.GetTodoListsAfter(ctx, tx, value, userID)
+		if errCursor != nil {
+			errService = exception.NewError(errCursor, exception.ErrorBadRequest)
+			return
+		}
+		pagination = web.NewCursorPagination(value.Limit, totalData, next, prev)
+		return
+	}
+	todolists = repository.GetTodoLists(ctx, tx, value, userID)
+	pagination = web.NewPagination(value.Page, value.Limit, totalData)
 	return
 }
 
-func (t *TodoListService) DeleteTodoList(ctx context.Context, params web.Params) (errService error) {
+// combineQueries matches what both queries match, either one may be nil.
+func combineQueries(first querylang.Node, second querylang.Node) querylang.Node {
+	if first == nil {
+		return second
+	}
+	if second == nil {
+		return first
+	}
+	return &querylang.And{Nodes: []querylang.Node{first, second}, Pos: first.Position()}
+}
+
+func (t *TodoListService) FindTodoLists(ctx context.Context, params web.Params) (responses model.TodoListResponses, pagination web.Pagination, errService error) {
 	tx := t.DB.Begin()
 	defer func() {
 		if r := recover(); r != nil {
@@ -159,10 +1255,10 @@ func (t *TodoListService) DeleteTodoList(ctx context.Context, params web.Params)
 		return
 	}
 	userID, validUUID := params.UserID.ToUUID()
-	queryParams, ok := params.Query.(web.TodoListByIDQuery)
+	queryParams, ok := params.Query.(web.TodoListsQuery)
 	if !ok {
 		tx.Rollback()
-		errService = exception.NewError(fmt.Errorf("error parsing get by id query params"), exception.ErrorInternalServer)
+		errService = exception.NewError(fmt.Errorf("error parsing get all query params"), exception.ErrorInternalServer)
 		return
 	}
 
@@ -178,24 +1274,41 @@ func (t *TodoListService) DeleteTodoList(ctx context.Context, params web.Params)
 		errService = exception.NewError(validationQueryParams, exception.ErrorBadRequest)
 		return
 	}
+
 	value, errParsing := queryParams.ToValue()
 	if errParsing != nil {
+		// sort and fields are checked while parsing, so a failure here is the caller's
 		tx.Rollback()
-		errService = exception.NewError(errParsing, exception.ErrorInternalServer)
+		errService = exception.NewError(errParsing, exception.ErrorBadRequest)
 		return
 	}
-	exist := t.Repository.TodoListExistByID(ctx, tx, value.ID, userID)
-	if !exist {
+	if value.SearchID != nil {
+		search, errSearch := t.Search.GetSavedSearchByID(ctx, tx, *value.SearchID, userID)
+		if errSearch != nil {
+			tx.Rollback()
+			errService = exception.NewError(fmt.Errorf("saved search with id %v not found", *value.SearchID), exception.ErrorNotFound)
+			return
+		}
+		saved, errQuery := querylang.Parse(search.Query, web.TodoListQueryFields)
+		if errQuery != nil {
+			tx.Rollback()
+			errService = exception.NewError(errQuery, exception.ErrorBadRequest)
+			return
+		}
+		value.Query = combineQueries(saved, value.Query)
+	}
+	todolists, pagination, errPage := pageTodoLists(ctx, tx, t.Repository, *value, userID)
+	if errPage != nil {
 		tx.Rollback()
-		errService = exception.NewError(fmt.Errorf("todolist with id %v not found", value.ID), exception.ErrorNotFound)
+		errService = errPage
 		return
 	}
-	t.Repository.DeleteTodoListByID(ctx, tx, value.ID, userID)
 	tx.Commit()
+	responses = todolists.ToTodoListResponses()
 	return
 }
 
-func (t *TodoListService) DeletesTodoLists(ctx context.Context, params web.Params) (errService error) {
+func (t *TodoListService) FindTodoListByID(ctx context.Context, params web.Params) (response model.TodoListResponse, errService error) {
 	tx := t.DB.Begin()
 	defer func() {
 		if r := recover(); r != nil {
@@ -210,7 +1323,7 @@ func (t *TodoListService) DeletesTodoLists(ctx context.Context, params web.Param
 		return
 	}
 	userID, validUUID := params.UserID.ToUUID()
-	queryParams, ok := params.Query.(web.TodoListByIDsQuery)
+	queryParams, ok := params.Query.(web.TodoListByIDQuery)
 	if !ok {
 		tx.Rollback()
 		errService = exception.NewError(fmt.Errorf("error parsing get by id query params"), exception.ErrorInternalServer)
@@ -235,18 +1348,18 @@ func (t *TodoListService) DeletesTodoLists(ctx context.Context, params web.Param
 		errService = exception.NewError(errParsing, exception.ErrorInternalServer)
 		return
 	}
-	exist := t.Repository.TodoListsExistByIDs(ctx, tx, value.IDs, userID)
-	if !exist {
+	todolist, errAccess := t.Access.Authorize(ctx, tx, value.ID, userID, model.PermissionViewer)
+	if errAccess != nil {
 		tx.Rollback()
-		errService = exception.NewError(fmt.Errorf("todolist with id %v not found", value.IDs), exception.ErrorNotFound)
+		errService = errAccess
 		return
 	}
-	t.Repository.DeleteTodoListsByIDs(ctx, tx, value.IDs, userID)
 	tx.Commit()
+	response = *todolist.ToTodoListResponse()
 	return
 }
 
-func (t *TodoListService) FindTodoListsBySearch(ctx context.Context, params web.Params) (responses model.TodoListResponses, pagination web.Pagination, errService error) {
+func (t *TodoListService) FindSharedTodoLists(ctx context.Context, params web.Params) (responses model.TodoListResponses, pagination web.Pagination, errService error) {
 	tx := t.DB.Begin()
 	defer func() {
 		if r := recover(); r != nil {
@@ -255,21 +1368,16 @@ func (t *TodoListService) FindTodoListsBySearch(ctx context.Context, params web.
 			errService = exception.NewError(err, exception.ErrorInternalServer)
 		}
 	}()
-	if params.Query == nil {
-		tx.Rollback()
-		errService = exception.NewError(fmt.Errorf("error query params is nil"), exception.ErrorInternalServer)
-		return
-	}
 	userID, validUUID := params.UserID.ToUUID()
-	queryParams, ok := params.Query.(web.SearchQuery)
-	if !ok {
+	if validUUID != nil {
 		tx.Rollback()
-		errService = exception.NewError(fmt.Errorf("error parsing search query"), exception.ErrorInternalServer)
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
 		return
 	}
-	if validUUID != nil {
+	queryParams, ok := params.Query.(web.GetAllQuery)
+	if !ok {
 		tx.Rollback()
-		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		errService = exception.NewError(fmt.Errorf("error parsing get all query params"), exception.ErrorInternalServer)
 		return
 	}
 	validationQueryParams := t.Validator.Struct(queryParams)
@@ -278,36 +1386,20 @@ func (t *TodoListService) FindTodoListsBySearch(ctx context.Context, params web.
 		errService = exception.NewError(validationQueryParams, exception.ErrorBadRequest)
 		return
 	}
-
 	value, errParsing := queryParams.ToValue()
 	if errParsing != nil {
 		tx.Rollback()
-		errService = exception.NewError(errParsing, exception.ErrorInternalServer)
-		return
-	}
-	responses = t.Repository.GetTodoListsSearch(ctx, tx, *value, userID).ToTodoListResponses()
-	var totalData int64
-	valueSearch := []string{"%", value.Search, "%"}
-	key := strings.Join(valueSearch, "")
-	errCount := tx.WithContext(ctx).Model(&model.TodoList{}).Where("user_id = ?", userID).Where("task_name LIKE ?", key).Or("description LIKE ?", key).Count(&totalData).Error
-	if errCount != nil {
-		tx.Rollback()
-		errService = exception.NewError(errCount, exception.ErrorInternalServer)
+		errService = exception.NewError(errParsing, exception.ErrorBadRequest)
 		return
 	}
+	responses = t.Repository.GetSharedTodoLists(ctx, tx, *value, userID).ToTodoListResponses()
+	totalData := t.Repository.CountSharedTodoLists(ctx, tx, userID)
 	tx.Commit()
-	totalPage := int(math.Ceil(float64(totalData) / float64(config.Other.Limit)))
-	pagination = web.Pagination{
-		Next:      value.Page + 1,
-		Current:   value.Page,
-		Previous:  value.Page - 1,
-		TotalPage: totalPage,
-		Data:      int(totalData),
-	}
+	pagination = web.NewPagination(value.Page, value.Limit, totalData)
 	return
 }
 
-func (t *TodoListService) FindTodoLists(ctx context.Context, params web.Params) (responses model.TodoListResponses, pagination web.Pagination, errService error) {
+func (t *TodoListService) FindAssignedTodoLists(ctx context.Context, params web.Params) (responses model.TodoListResponses, pagination web.Pagination, errService error) {
 	tx := t.DB.Begin()
 	defer func() {
 		if r := recover(); r != nil {
@@ -316,59 +1408,137 @@ func (t *TodoListService) FindTodoLists(ctx context.Context, params web.Params)
 			errService = exception.NewError(err, exception.ErrorInternalServer)
 		}
 	}()
-	if params.Query == nil {
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
 		tx.Rollback()
-		errService = exception.NewError(fmt.Errorf("error query params is nil"), exception.ErrorInternalServer)
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
 		return
 	}
-	userID, validUUID := params.UserID.ToUUID()
 	queryParams, ok := params.Query.(web.GetAllQuery)
 	if !ok {
 		tx.Rollback()
 		errService = exception.NewError(fmt.Errorf("error parsing get all query params"), exception.ErrorInternalServer)
 		return
 	}
+	validationQueryParams := t.Validator.Struct(queryParams)
+	if validationQueryParams != nil {
+		tx.Rollback()
+		errService = exception.NewError(validationQueryParams, exception.ErrorBadRequest)
+		return
+	}
+	value, errParsing := queryParams.ToValue()
+	if errParsing != nil {
+		tx.Rollback()
+		errService = exception.NewError(errParsing, exception.ErrorBadRequest)
+		return
+	}
+	responses = t.Repository.GetAssignedTodoLists(ctx, tx, *value, userID).ToTodoListResponses()
+	totalData := t.Repository.CountAssignedTodoLists(ctx, tx, userID)
+	tx.Commit()
+	pagination = web.NewPagination(value.Page, value.Limit, totalData)
+	return
+}
 
+// authorizeByID parses the todolist id query and checks that the caller holds permission on it.
+func (t *TodoListService) authorizeByID(ctx context.Context, tx *gorm.DB, params web.Params, permission model.SharePermission) (todolist model.TodoList, errService error) {
+	userID, validUUID := params.UserID.ToUUID()
 	if validUUID != nil {
-		tx.Rollback()
 		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
 		return
 	}
-
+	queryParams, ok := params.Query.(web.TodoListByIDQuery)
+	if !ok {
+		errService = exception.NewError(fmt.Errorf("error parsing get by id query params"), exception.ErrorInternalServer)
+		return
+	}
 	validationQueryParams := t.Validator.Struct(queryParams)
 	if validationQueryParams != nil {
-		tx.Rollback()
 		errService = exception.NewError(validationQueryParams, exception.ErrorBadRequest)
 		return
 	}
-
 	value, errParsing := queryParams.ToValue()
 	if errParsing != nil {
-		tx.Rollback()
 		errService = exception.NewError(errParsing, exception.ErrorInternalServer)
 		return
 	}
-	responses = t.Repository.GetTodoLists(ctx, tx, *value, userID).ToTodoListResponses()
-	var totalData int64
-	errCount := tx.WithContext(ctx).Model(&model.TodoList{}).Where("user_id = ?", userID).Count(&totalData).Error
-	if errCount != nil {
+	todolist, errAccess := t.Access.Authorize(ctx, tx, value.ID, userID, permission)
+	if errAccess != nil {
+		errService = errAccess
+		return
+	}
+	return
+}
+
+func (t *TodoListService) AssignTodoList(ctx context.Context, request model.TodoListAssignRequest, params web.Params) (errService error) {
+	tx := t.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	todolist, errAccess := t.authorizeByID(ctx, tx, params, model.PermissionEditor)
+	if errAccess != nil {
+		tx.Rollback()
+		errService = errAccess
+		return
+	}
+	if errAssignee := t.checkAssignee(ctx, tx, request.AssigneeID); errAssignee != nil {
 		tx.Rollback()
-		errService = exception.NewError(errCount, exception.ErrorInternalServer)
+		errService = errAssignee
 		return
 	}
-	tx.Commit()
-	totalPage := int(math.Ceil(float64(totalData) / float64(config.Other.Limit)))
-	pagination = web.Pagination{
-		Next:      value.Page + 1,
-		Current:   value.Page,
-		Previous:  value.Page - 1,
-		TotalPage: totalPage,
-		Data:      int(totalData),
+	if model.SameAssignee(todolist.AssigneeID, request.AssigneeID) {
+		tx.Rollback()
+		return
+	}
+	userID, _ := params.UserID.ToUUID()
+	old := todolist.AssigneeID
+	todolist.AssigneeID = request.AssigneeID
+	t.Repository.UpdateTodoListAssignee(ctx, tx, todolist.TaskID, todolist.AssigneeID)
+	if errAssign := t.recordAssignment(ctx, tx, todolist, old, userID); errAssign != nil {
+		tx.Rollback()
+		errService = exception.NewError(errAssign, exception.ErrorInternalServer)
+		return
 	}
+	tx.Commit()
 	return
 }
 
-func (t *TodoListService) FindTodoListByID(ctx context.Context, params web.Params) (response model.TodoListResponse, errService error) {
+// placeNextTo rewrites the position of todolist alone to fall right after or right before neighbourID in the list of
+// its owner, the list is rebalanced when the new key got too long or the neighbours share the same position.
+func (t *TodoListService) placeNextTo(ctx context.Context, tx *gorm.DB, todolist model.TodoList, neighbourID int, after bool) error {
+	var position string
+	for attempt := 0; ; attempt++ {
+		neighbour, errNotFound := t.Repository.GetTodoListByID(ctx, tx, neighbourID, todolist.UserID)
+		if errNotFound != nil {
+			return exception.NewError(fmt.Errorf("todolist with id %v not found in the same list", neighbourID), exception.ErrorNotFound)
+		}
+		other := t.Repository.GetAdjacentPosition(ctx, tx, neighbour, todolist.TaskID, after)
+		var errKey error
+		if after {
+			position, errKey = fractional.Between(neighbour.Position, other)
+		} else {
+			position, errKey = fractional.Between(other, neighbour.Position)
+		}
+		if errKey == nil {
+			break
+		}
+		if attempt > 0 {
+			return exception.NewError(errKey, exception.ErrorInternalServer)
+		}
+		t.Repository.RebalanceTodoLists(ctx, tx, todolist.UserID)
+	}
+	t.Repository.UpdateTodoListPosition(ctx, tx, todolist.TaskID, position)
+	if len(position) > fractional.RebalanceLength {
+		t.Repository.RebalanceTodoLists(ctx, tx, todolist.UserID)
+	}
+	return nil
+}
+
+// MoveTodoList places a todolist between its new neighbours in the manual order of its list.
+func (t *TodoListService) MoveTodoList(ctx context.Context, request model.TodoListMoveRequest, params web.Params) (response model.TodoListResponse, errService error) {
 	tx := t.DB.Begin()
 	defer func() {
 		if r := recover(); r != nil {
@@ -377,44 +1547,59 @@ func (t *TodoListService) FindTodoListByID(ctx context.Context, params web.Param
 			errService = exception.NewError(err, exception.ErrorInternalServer)
 		}
 	}()
-	if params.Query == nil {
+	badRequest := t.Validator.Struct(request)
+	if badRequest != nil {
 		tx.Rollback()
-		errService = exception.NewError(fmt.Errorf("error query params is nil"), exception.ErrorInternalServer)
+		errService = exception.NewError(badRequest, exception.ErrorBadRequest)
 		return
 	}
-	userID, validUUID := params.UserID.ToUUID()
-	queryParams, ok := params.Query.(web.TodoListByIDQuery)
-	if !ok {
+	todolist, errAccess := t.authorizeByID(ctx, tx, params, model.PermissionEditor)
+	if errAccess != nil {
 		tx.Rollback()
-		errService = exception.NewError(fmt.Errorf("error parsing get by id query params"), exception.ErrorInternalServer)
+		errService = errAccess
 		return
 	}
-
-	if validUUID != nil {
+	neighbourID, after := request.BeforeID, false
+	if request.AfterID != nil {
+		neighbourID, after = request.AfterID, true
+	}
+	if *neighbourID == todolist.TaskID {
 		tx.Rollback()
-		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		errService = exception.NewError(fmt.Errorf("a todolist cannot be moved next to itself"), exception.ErrorBadRequest)
 		return
 	}
-
-	validationQueryParams := t.Validator.Struct(queryParams)
-	if validationQueryParams != nil {
+	if errPlace := t.placeNextTo(ctx, tx, todolist, *neighbourID, after); errPlace != nil {
 		tx.Rollback()
-		errService = exception.NewError(validationQueryParams, exception.ErrorBadRequest)
+		errService = errPlace
 		return
 	}
-	value, errParsing := queryParams.ToValue()
-	if errParsing != nil {
+	moved, errNotFound := t.Repository.GetTodoListByTaskID(ctx, tx, todolist.TaskID)
+	if errNotFound != nil {
 		tx.Rollback()
-		errService = exception.NewError(errParsing, exception.ErrorInternalServer)
+		errService = exception.NewError(errNotFound, exception.ErrorInternalServer)
 		return
 	}
-	todolist, err := t.Repository.GetTodoListByID(ctx, tx, value.ID, userID)
-	if err != nil {
+	tx.Commit()
+	response = *moved.ToTodoListResponse()
+	return
+}
+
+func (t *TodoListService) FindAssignments(ctx context.Context, params web.Params) (assignments model.Assignments, errService error) {
+	tx := t.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	todolist, errAccess := t.authorizeByID(ctx, tx, params, model.PermissionViewer)
+	if errAccess != nil {
 		tx.Rollback()
-		errService = exception.NewError(err, exception.ErrorNotFound)
+		errService = errAccess
 		return
 	}
+	assignments = t.Assignment.GetAssignmentsByTaskID(ctx, tx, todolist.TaskID)
 	tx.Commit()
-	response = *todolist.ToTodoListResponse()
 	return
 }
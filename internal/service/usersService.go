@@ -50,14 +50,14 @@ func (u *UsersService) FindUsersBySearch(ctx context.Context, params web.SearchQ
 	valueSearch := []string{"%", value.Search, "%"}
 	key := strings.Join(valueSearch, "")
 	var totalData int64
-	errCount := tx.Unscoped().Model(&model.User{}).Where("username LIKE ?", key).Where("email LIKE ?", key).Offset(int(value.Offset)).Limit(config.Other.Limit).Count(&totalData).Error
+	errCount := tx.Unscoped().Model(&model.User{}).Where("username LIKE ?", key).Where("email LIKE ?", key).Count(&totalData).Error
 	if errCount != nil {
 		tx.Rollback()
 		errService = exception.NewError(errCount, exception.ErrorInternalServer)
 		return
 	}
 	tx.Commit()
-	totalPage := int(math.Ceil(float64(totalData) / float64(config.Other.Limit)))
+	totalPage := int(math.Ceil(float64(totalData) / float64(value.Limit)))
 	pagination = web.Pagination{
 		Next:      value.Page + 1,
 		Current:   value.Page,
@@ -68,7 +68,7 @@ func (u *UsersService) FindUsersBySearch(ctx context.Context, params web.SearchQ
 	return
 }
 
-func (u *UsersService) FindUsers(ctx context.Context, params web.GetAllQuery) (responses model.UsersResponses, pagination web.Pagination, errService error) {
+func (u *UsersService) FindUsers(ctx context.Context, params web.UsersQuery) (responses model.UsersResponses, pagination web.Pagination, errService error) {
 	tx := u.DB.Begin()
 	defer func() {
 		if r := recover(); r != nil {
@@ -85,27 +85,33 @@ func (u *UsersService) FindUsers(ctx context.Context, params web.GetAllQuery) (r
 	}
 	value, errParsing := params.ToValue()
 	if errParsing != nil {
+		// limit and cursor are checked while parsing, so a failure here is the caller's
 		tx.Rollback()
-		errService = exception.NewError(errParsing, exception.ErrorInternalServer)
+		errService = exception.NewError(errParsing, exception.ErrorBadRequest)
 		return
 	}
-	responses = u.Repository.GetUsers(ctx, tx, *value).ToUsersResponses()
 	var totalData int64
-	errCount := tx.Unscoped().Model(&model.User{}).Offset(int(value.Offset)).Limit(config.Other.Limit).Count(&totalData).Error
+	errCount := tx.Unscoped().Model(&model.User{}).Count(&totalData).Error
 	if errCount != nil {
 		tx.Rollback()
 		errService = exception.NewError(errCount, exception.ErrorInternalServer)
 		return
 	}
-	tx.Commit()
-	totalPage := int(math.Ceil(float64(totalData) / float64(config.Other.Limit)))
-	pagination = web.Pagination{
-		Next:      value.Page + 1,
-		Current:   value.Page,
-		Previous:  value.Page - 1,
-		TotalPage: totalPage,
-		Data:      int(totalData),
+	if value.Cursor != nil {
+		users, next, prev, errCursor := u.Repository.GetUsersAfter(ctx, tx, *value)
+		if errCursor != nil {
+			tx.Rollback()
+			errService = exception.NewError(errCursor, exception.ErrorBadRequest)
+			return
+		}
+		tx.Commit()
+		responses = users.ToUsersResponses()
+		pagination = web.NewCursorPagination(value.Limit, totalData, next, prev)
+		return
 	}
+	responses = u.Repository.GetUsers(ctx, tx, *value).ToUsersResponses()
+	tx.Commit()
+	pagination = web.NewPagination(value.Page, value.Limit, totalData)
 	return
 }
 
@@ -402,7 +408,7 @@ func (u *UsersService) CreateUsers(ctx context.Context, users model.UsersRequest
 	return
 }
 
-func (u *UsersService) UpdateUserID(ctx context.Context, user model.UserLoginUpdateRequest, ID uuid.UUID) (errService error) {
+func (u *UsersService) UpdateUserID(ctx context.Context, user model.UserLoginUpdateRequest, ID uuid.UUID, ifMatch string) (version int, errService error) {
 	tx := u.DB.Begin()
 	defer func() {
 		if r := recover(); r != nil {
@@ -416,10 +422,19 @@ func (u *UsersService) UpdateUserID(ctx context.Context, user model.UserLoginUpd
 	badRequest := helper.NewCustomError(validationError, exception.ErrorBadRequest)
 
 	exist := u.Repository.UsersExistByID(ctx, tx, ID)
+	if badRequest == nil && user.SearchLanguage != "" && !u.Repository.SearchLanguageExists(ctx, tx, user.SearchLanguage) {
+		badRequest = exception.NewError(fmt.Errorf("search language %s is not supported", user.SearchLanguage), exception.ErrorBadRequest)
+	}
+	if badRequest == nil && exist {
+		badRequest = checkIfMatch(ifMatch, u.Repository.LockUserVersion(ctx, tx, ID), fmt.Sprintf("user with id %v", ID))
+	}
 	if user.Password != "" {
 		user.Password, _ = bcrypts.HashPassword(user.Password, config.Other.SaltLevel)
 	}
-	u.Repository.UpdateUserID(ctx, tx, *user.ToUser(), ID)
+	if badRequest == nil {
+		u.Repository.UpdateUserID(ctx, tx, *user.ToUser(), ID)
+		version = u.Repository.LockUserVersion(ctx, tx, ID)
+	}
 	if badRequest != nil {
 		tx.Rollback()
 		errService = badRequest
@@ -435,7 +450,7 @@ func (u *UsersService) UpdateUserID(ctx context.Context, user model.UserLoginUpd
 	return
 }
 
-func (u *UsersService) DeleteUserByID(ctx context.Context, ID uuid.UUID) (errService error) {
+func (u *UsersService) DeleteUserByID(ctx context.Context, ID uuid.UUID, ifMatch string) (errService error) {
 	tx := u.DB.Begin()
 
 	defer func() {
@@ -446,6 +461,13 @@ func (u *UsersService) DeleteUserByID(ctx context.Context, ID uuid.UUID) (errSer
 		}
 	}()
 	exist := u.Repository.UsersExistByID(ctx, tx, ID)
+	if exist {
+		if errMatch := checkIfMatch(ifMatch, u.Repository.LockUserVersion(ctx, tx, ID), fmt.Sprintf("user with id %v", ID)); errMatch != nil {
+			tx.Rollback()
+			errService = errMatch
+			return
+		}
+	}
 
 	u.Repository.DeleteUserByID(ctx, tx, ID)
 	if !exist {
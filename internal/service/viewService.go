@@ -0,0 +1,352 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"go_gin/internal/domain/model"
+	"go_gin/internal/domain/model/web"
+	"go_gin/internal/exception"
+	"go_gin/pkg/querylang"
+	"gorm.io/gorm"
+)
+
+type ViewService struct {
+	DB                 *gorm.DB
+	Validator          *validator.Validate
+	Repository         model.ViewRepository
+	TodoListRepository model.TodoListRepository
+	Users              model.UsersRepository
+}
+
+func NewViewService(DB *gorm.DB, validator *validator.Validate, repository model.ViewRepository, todolistRepository model.TodoListRepository, users model.UsersRepository) *ViewService {
+	return &ViewService{DB: DB, Validator: validator, Repository: repository, TodoListRepository: todolistRepository, Users: users}
+}
+
+// validateView checks the request and that its query and sort parse, so a saved view can always be run.
+func (v *ViewService) validateView(request model.ViewRequest) error {
+	badRequest := v.Validator.Struct(request)
+	if badRequest != nil {
+		return exception.NewError(badRequest, exception.ErrorBadRequest)
+	}
+	if _, errQuery := querylang.Parse(request.Query, web.TodoListQueryFields); errQuery != nil {
+		return exception.NewError(errQuery, exception.ErrorBadRequest)
+	}
+	if _, errSort := web.ParseSort(request.Sort, web.TodoListSortColumns); errSort != nil {
+		return exception.NewError(errSort, exception.ErrorBadRequest)
+	}
+	return nil
+}
+
+// loadView returns the view when userID owns it or, unless ownerOnly, when it is shared with them.
+// Views the caller cannot see are reported as not found, shared views they cannot change as forbidden.
+func (v *ViewService) loadView(ctx context.Context, tx *gorm.DB, ID int, userID uuid.UUID, ownerOnly bool) (model.View, error) {
+	view, errNotFound := v.Repository.GetViewByID(ctx, tx, ID)
+	notFound := exception.NewError(fmt.Errorf("view with id %v not found", ID), exception.ErrorNotFound)
+	if errNotFound != nil {
+		return model.View{}, notFound
+	}
+	if view.UserID == userID {
+		return view, nil
+	}
+	if !v.Repository.ViewSharedWith(ctx, tx, ID, userID) {
+		return model.View{}, notFound
+	}
+	if ownerOnly {
+		return model.View{}, exception.NewError(fmt.Errorf("only the owner can change view %v", ID), exception.ErrorForbidden)
+	}
+	return view, nil
+}
+
+func (v *ViewService) parseViewID(ctx context.Context, tx *gorm.DB, params web.Params, ownerOnly bool) (view model.View, errService error) {
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	queryParams, ok := params.Query.(web.ViewByIDQuery)
+	if !ok {
+		errService = exception.NewError(fmt.Errorf("error parsing view query params"), exception.ErrorInternalServer)
+		return
+	}
+	validationQueryParams := v.Validator.Struct(queryParams)
+	if validationQueryParams != nil {
+		errService = exception.NewError(validationQueryParams, exception.ErrorBadRequest)
+		return
+	}
+	ID, errParsing := queryParams.ToValue()
+	if errParsing != nil {
+		errService = exception.NewError(errParsing, exception.ErrorInternalServer)
+		return
+	}
+	return v.loadView(ctx, tx, ID, userID, ownerOnly)
+}
+
+func (v *ViewService) FindViews(ctx context.Context, params web.Params) (responses model.ViewResponses, errService error) {
+	tx := v.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	responses = v.Repository.GetViews(ctx, tx, userID).ToViewResponses()
+	tx.Commit()
+	return
+}
+
+func (v *ViewService) CreateView(ctx context.Context, request model.ViewRequest, params web.Params) (response model.ViewResponse, errService error) {
+	tx := v.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	if errService = v.validateView(request); errService != nil {
+		tx.Rollback()
+		return
+	}
+	view, errConflict := v.Repository.CreateView(ctx, tx, *request.ToView(userID))
+	if errConflict != nil {
+		tx.Rollback()
+		errService = exception.NewError(errConflict, exception.ErrorConflict)
+		return
+	}
+	tx.Commit()
+	response = *view.ToViewResponse()
+	return
+}
+
+func (v *ViewService) UpdateView(ctx context.Context, request model.ViewRequest, params web.Params) (errService error) {
+	tx := v.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	if errService = v.validateView(request); errService != nil {
+		tx.Rollback()
+		return
+	}
+	view, errView := v.parseViewID(ctx, tx, params, true)
+	if errView != nil {
+		tx.Rollback()
+		errService = errView
+		return
+	}
+	if errConflict := v.Repository.UpdateViewByID(ctx, tx, *request.ToView(view.UserID), view.ViewID); errConflict != nil {
+		tx.Rollback()
+		errService = exception.NewError(errConflict, exception.ErrorConflict)
+		return
+	}
+	tx.Commit()
+	return
+}
+
+func (v *ViewService) DeleteView(ctx context.Context, params web.Params) (errService error) {
+	tx := v.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	view, errView := v.parseViewID(ctx, tx, params, true)
+	if errView != nil {
+		tx.Rollback()
+		errService = errView
+		return
+	}
+	v.Repository.DeleteViewByID(ctx, tx, view.ViewID)
+	tx.Commit()
+	return
+}
+
+func (v *ViewService) FindViewShares(ctx context.Context, params web.Params) (shares model.ViewShares, errService error) {
+	tx := v.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	view, errView := v.parseViewID(ctx, tx, params, true)
+	if errView != nil {
+		tx.Rollback()
+		errService = errView
+		return
+	}
+	shares = v.Repository.GetViewShares(ctx, tx, view.ViewID)
+	tx.Commit()
+	return
+}
+
+func (v *ViewService) ShareView(ctx context.Context, request model.ViewShareRequest, params web.Params) (errService error) {
+	tx := v.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	badRequest := v.Validator.Struct(request)
+	if badRequest != nil {
+		tx.Rollback()
+		errService = exception.NewError(badRequest, exception.ErrorBadRequest)
+		return
+	}
+	view, errView := v.parseViewID(ctx, tx, params, true)
+	if errView != nil {
+		tx.Rollback()
+		errService = errView
+		return
+	}
+	if request.UserID == view.UserID {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("a view cannot be shared with its owner"), exception.ErrorBadRequest)
+		return
+	}
+	if !v.Users.UsersExistByID(ctx, tx, request.UserID) {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("user with id %v not found", request.UserID), exception.ErrorNotFound)
+		return
+	}
+	if errConflict := v.Repository.CreateViewShare(ctx, tx, model.ViewShare{ViewID: view.ViewID, UserID: request.UserID}); errConflict != nil {
+		tx.Rollback()
+		errService = exception.NewError(errConflict, exception.ErrorConflict)
+		return
+	}
+	tx.Commit()
+	return
+}
+
+// UnshareView lets the owner revoke a share and a grantee leave a view shared with them.
+func (v *ViewService) UnshareView(ctx context.Context, params web.Params) (errService error) {
+	tx := v.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	queryParams, ok := params.Query.(web.ViewShareQuery)
+	if !ok {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("error parsing view share query params"), exception.ErrorInternalServer)
+		return
+	}
+	validationQueryParams := v.Validator.Struct(queryParams)
+	if validationQueryParams != nil {
+		tx.Rollback()
+		errService = exception.NewError(validationQueryParams, exception.ErrorBadRequest)
+		return
+	}
+	ID, granteeID, errParsing := queryParams.ToValue()
+	if errParsing != nil {
+		tx.Rollback()
+		errService = exception.NewError(errParsing, exception.ErrorBadRequest)
+		return
+	}
+	view, errView := v.loadView(ctx, tx, ID, userID, false)
+	if errView != nil {
+		tx.Rollback()
+		errService = errView
+		return
+	}
+	if view.UserID != userID && granteeID != userID {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("only the owner can revoke the shares of view %v", ID), exception.ErrorForbidden)
+		return
+	}
+	if v.Repository.DeleteViewShare(ctx, tx, ID, granteeID) == 0 {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("view %v is not shared with user %v", ID, granteeID), exception.ErrorNotFound)
+		return
+	}
+	tx.Commit()
+	return
+}
+
+// FindViewTodoLists runs a view against the todolists of the caller, groups counts every group over all pages.
+func (v *ViewService) FindViewTodoLists(ctx context.Context, params web.Params) (responses model.TodoListResponses, groups model.TodoListGroups, pagination web.Pagination, errService error) {
+	tx := v.DB.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err := r.(error)
+			errService = exception.NewError(err, exception.ErrorInternalServer)
+		}
+	}()
+	userID, validUUID := params.UserID.ToUUID()
+	if validUUID != nil {
+		tx.Rollback()
+		errService = exception.NewError(validUUID, exception.ErrorBadRequest)
+		return
+	}
+	queryParams, ok := params.Query.(web.ViewTodoListsQuery)
+	if !ok {
+		tx.Rollback()
+		errService = exception.NewError(fmt.Errorf("error parsing view query params"), exception.ErrorInternalServer)
+		return
+	}
+	validationQueryParams := v.Validator.Struct(queryParams)
+	if validationQueryParams != nil {
+		tx.Rollback()
+		errService = exception.NewError(validationQueryParams, exception.ErrorBadRequest)
+		return
+	}
+	ID, _ := (&web.ViewByIDQuery{ViewID: queryParams.ViewID}).ToValue()
+	view, errView := v.loadView(ctx, tx, ID, userID, false)
+	if errView != nil {
+		tx.Rollback()
+		errService = errView
+		return
+	}
+	listQuery := queryParams.ToTodoListsQuery(view.Query, view.Sort, view.GroupBy)
+	value, errParsing := listQuery.ToValue()
+	if errParsing != nil {
+		tx.Rollback()
+		errService = exception.NewError(errParsing, exception.ErrorBadRequest)
+		return
+	}
+	todolists, pagination, errPage := pageTodoLists(ctx, tx, v.TodoListRepository, *value, userID)
+	if errPage != nil {
+		tx.Rollback()
+		errService = errPage
+		return
+	}
+	if view.GroupBy != "" {
+		groups = v.TodoListRepository.CountTodoListsByGroup(ctx, tx, *value, userID, web.TodoListSortColumns[view.GroupBy])
+	}
+	tx.Commit()
+	responses = todolists.ToTodoListResponses()
+	return
+}
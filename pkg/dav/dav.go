@@ -0,0 +1,226 @@
+package dav
+
+import (
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	NamespaceDAV            = "DAV:"
+	NamespaceCalDAV         = "urn:ietf:params:xml:ns:caldav"
+	NamespaceCalendarServer = "http://calendarserver.org/ns/"
+)
+
+// prefixes are the namespaces declared on a multistatus, other namespaces are declared on the element using them.
+var prefixes = map[string]string{NamespaceDAV: "d", NamespaceCalDAV: "c", NamespaceCalendarServer: "cs"}
+
+// Element is an XML element of a WebDAV body with either its text or its children.
+type Element struct {
+	Name     xml.Name
+	Attrs    []xml.Attr
+	Text     string
+	Children []Element
+}
+
+func New(space string, local string, children ...Element) Element {
+	return Element{Name: xml.Name{Space: space, Local: local}, Children: children}
+}
+
+func NewText(space string, local string, text string) Element {
+	return Element{Name: xml.Name{Space: space, Local: local}, Text: text}
+}
+
+// Href is a DAV:href element.
+func Href(href string) Element {
+	return NewText(NamespaceDAV, "href", href)
+}
+
+func (e Element) Is(space string, local string) bool {
+	return e.Name.Space == space && e.Name.Local == local
+}
+
+// Child returns the first child of e with the given name.
+func (e Element) Child(space string, local string) (Element, bool) {
+	for _, child := range e.Children {
+		if child.Is(space, local) {
+			return child, true
+		}
+	}
+	return Element{}, false
+}
+
+func (e Element) Attr(local string) string {
+	for _, attr := range e.Attrs {
+		if attr.Name.Local == local {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// Decode reads the XML body of a request, an empty body gives an Element without a name.
+func Decode(r io.Reader) (Element, error) {
+	decoder := xml.NewDecoder(r)
+	var stack []Element
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			if len(stack) > 0 {
+				return Element{}, fmt.Errorf("xml body is not closed")
+			}
+			return Element{}, nil
+		}
+		if err != nil {
+			return Element{}, err
+		}
+		switch token := token.(type) {
+		case xml.StartElement:
+			stack = append(stack, Element{Name: token.Name, Attrs: token.Copy().Attr})
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].Text += string(token)
+			}
+		case xml.EndElement:
+			element := stack[len(stack)-1]
+			element.Text = strings.TrimSpace(element.Text)
+			stack = stack[:len(stack)-1]
+			if len(stack) == 0 {
+				return element, nil
+			}
+			stack[len(stack)-1].Children = append(stack[len(stack)-1].Children, element)
+		}
+	}
+}
+
+// Requested returns the names of the properties asked for in the DAV:prop of a PROPFIND or REPORT body, nil when
+// every property is asked for.
+func Requested(body Element) []xml.Name {
+	prop, ok := body.Child(NamespaceDAV, "prop")
+	if !ok {
+		return nil
+	}
+	names := make([]xml.Name, 0, len(prop.Children))
+	for _, child := range prop.Children {
+		names = append(names, child.Name)
+	}
+	return names
+}
+
+// Response is the status of one resource in a multistatus. A response with a Status has no properties, like a member
+// removed since a sync token.
+type Response struct {
+	Href     string
+	Status   int
+	Found    []Element
+	NotFound []xml.Name
+}
+
+// NewResponse answers requested out of the properties available on href, all of them when requested is nil.
+func NewResponse(href string, requested []xml.Name, available []Element) Response {
+	response := Response{Href: href}
+	if requested == nil {
+		response.Found = available
+		return response
+	}
+	for _, name := range requested {
+		found := false
+		for _, property := range available {
+			if property.Name == name {
+				response.Found = append(response.Found, property)
+				found = true
+				break
+			}
+		}
+		if !found {
+			response.NotFound = append(response.NotFound, name)
+		}
+	}
+	return response
+}
+
+type Multistatus struct {
+	Responses []Response
+	// SyncToken is only written in answer to a sync-collection report
+	SyncToken string
+}
+
+func (m Multistatus) Encode(w io.Writer) error {
+	writer := bufio.NewWriter(w)
+	writer.WriteString(xml.Header)
+	writer.WriteString(`<d:multistatus xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav" xmlns:cs="http://calendarserver.org/ns/">`)
+	for _, response := range m.Responses {
+		writer.WriteString("<d:response>")
+		encode(writer, Href(response.Href))
+		if response.Status != 0 {
+			encode(writer, NewText(NamespaceDAV, "status", statusLine(response.Status)))
+		}
+		if len(response.Found) > 0 {
+			encode(writer, New(NamespaceDAV, "propstat",
+				New(NamespaceDAV, "prop", response.Found...),
+				NewText(NamespaceDAV, "status", statusLine(http.StatusOK))))
+		}
+		if len(response.NotFound) > 0 {
+			missing := make([]Element, len(response.NotFound))
+			for i, name := range response.NotFound {
+				missing[i] = Element{Name: name}
+			}
+			encode(writer, New(NamespaceDAV, "propstat",
+				New(NamespaceDAV, "prop", missing...),
+				NewText(NamespaceDAV, "status", statusLine(http.StatusNotFound))))
+		}
+		writer.WriteString("</d:response>")
+	}
+	if m.SyncToken != "" {
+		encode(writer, NewText(NamespaceDAV, "sync-token", m.SyncToken))
+	}
+	writer.WriteString("</d:multistatus>")
+	return writer.Flush()
+}
+
+// EncodeError writes the DAV:error body of a failed precondition, such as DAV:valid-sync-token.
+func EncodeError(w io.Writer, condition Element) error {
+	writer := bufio.NewWriter(w)
+	writer.WriteString(xml.Header)
+	writer.WriteString(`<d:error xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">`)
+	encode(writer, condition)
+	writer.WriteString("</d:error>")
+	return writer.Flush()
+}
+
+func encode(w *bufio.Writer, e Element) {
+	name := e.Name.Local
+	declaration := ""
+	if prefix, ok := prefixes[e.Name.Space]; ok {
+		name = prefix + ":" + name
+	} else if e.Name.Space != "" {
+		declaration = ` xmlns="` + escape(e.Name.Space) + `"`
+	}
+	w.WriteString("<" + name + declaration)
+	for _, attr := range e.Attrs {
+		w.WriteString(" " + attr.Name.Local + `="` + escape(attr.Value) + `"`)
+	}
+	if e.Text == "" && len(e.Children) == 0 {
+		w.WriteString("/>")
+		return
+	}
+	w.WriteString(">" + escape(e.Text))
+	for _, child := range e.Children {
+		encode(w, child)
+	}
+	w.WriteString("</" + name + ">")
+}
+
+func escape(text string) string {
+	var escaped strings.Builder
+	xml.EscapeText(&escaped, []byte(text))
+	return escaped.String()
+}
+
+func statusLine(status int) string {
+	return fmt.Sprintf("HTTP/1.1 %d %s", status, http.StatusText(status))
+}
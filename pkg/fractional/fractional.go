@@ -0,0 +1,122 @@
+package fractional
+
+import (
+	"errors"
+	"strings"
+)
+
+// Digits are the base 62 digits of a key in ascending byte order, so keys compare like the fractions they encode
+// as long as they are compared byte by byte (COLLATE "C" in Postgres).
+const Digits = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// RebalanceLength is the key length past which a list should be given fresh keys with Spread.
+const RebalanceLength = 32
+
+var (
+	ErrInvalidKey = errors.New("invalid fractional key")
+	ErrOrder      = errors.New("fractional keys out of order")
+)
+
+// Valid reports whether key is made of Digits and does not end with the zero digit, which every key between two
+// others needs to stay reachable.
+func Valid(key string) bool {
+	if key == "" || key[len(key)-1] == Digits[0] {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if strings.IndexByte(Digits, key[i]) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Between returns a key sorting after a and before b, an empty a means the start of the list and an empty b its end.
+func Between(a, b string) (string, error) {
+	if (a != "" && !Valid(a)) || (b != "" && !Valid(b)) {
+		return "", ErrInvalidKey
+	}
+	if a != "" && b != "" && a >= b {
+		return "", ErrOrder
+	}
+	if a != "" && b == "" {
+		return after(a), nil
+	}
+	return midpoint(a, b), nil
+}
+
+// after returns the shortest key following a by bumping its first digit, so appending to a list grows keys by one
+// digit every 31 appends or so instead of halving the gap left to the end each time.
+func after(a string) string {
+	i := strings.IndexByte(Digits, a[0])
+	if i < len(Digits)-1 {
+		return string(Digits[i+1])
+	}
+	if len(a) == 1 {
+		return a + string(Digits[len(Digits)/2])
+	}
+	return a[:1] + after(a[1:])
+}
+
+// midpoint is Between on checked keys, b is never a proper prefix of a so b stays non-empty once bounded.
+func midpoint(a, b string) string {
+	if b != "" {
+		n := 0
+		for n < len(b) && digitAt(a, n) == b[n] {
+			n++
+		}
+		if n > 0 {
+			rest := ""
+			if n < len(a) {
+				rest = a[n:]
+			}
+			return b[:n] + midpoint(rest, b[n:])
+		}
+	}
+	digitA := 0
+	if a != "" {
+		digitA = strings.IndexByte(Digits, a[0])
+	}
+	digitB := len(Digits)
+	if b != "" {
+		digitB = strings.IndexByte(Digits, b[0])
+	}
+	if digitB-digitA > 1 {
+		return string(Digits[(digitA+digitB+1)/2])
+	}
+	if len(b) > 1 {
+		return b[:1]
+	}
+	rest := ""
+	if len(a) > 1 {
+		rest = a[1:]
+	}
+	return string(Digits[digitA]) + midpoint(rest, "")
+}
+
+func digitAt(key string, i int) byte {
+	if i < len(key) {
+		return key[i]
+	}
+	return Digits[0]
+}
+
+// Spread returns n ascending keys evenly spaced over the whole range, all of the same short width.
+func Spread(n int) []string {
+	width, space := 1, uint64(len(Digits))
+	for space <= uint64(n) {
+		width++
+		space *= uint64(len(Digits))
+	}
+	keys := make([]string, n)
+	for i := range keys {
+		value := (uint64(i) + 1) * space / (uint64(n) + 1)
+		key := make([]byte, width)
+		for j := width - 1; j >= 0; j-- {
+			key[j] = Digits[value%uint64(len(Digits))]
+			value /= uint64(len(Digits))
+		}
+		keys[i] = strings.TrimRight(string(key), Digits[:1])
+	}
+	return keys
+}
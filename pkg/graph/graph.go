@@ -0,0 +1,90 @@
+package graph
+
+import (
+	"errors"
+	"sort"
+)
+
+var ErrCycle = errors.New("graph has a cycle")
+
+// Edge points from a node that must finish first to the node it blocks.
+type Edge struct {
+	From int
+	To   int
+}
+
+// TopologicalSort orders nodes so every edge goes forward, ties are broken by the smallest node first.
+// Edges touching nodes outside of nodes are ignored.
+func TopologicalSort(nodes []int, edges []Edge) ([]int, error) {
+	known := make(map[int]bool, len(nodes))
+	for _, node := range nodes {
+		known[node] = true
+	}
+	indegree := make(map[int]int, len(nodes))
+	next := make(map[int][]int, len(nodes))
+	for _, edge := range edges {
+		if !known[edge.From] || !known[edge.To] {
+			continue
+		}
+		next[edge.From] = append(next[edge.From], edge.To)
+		indegree[edge.To]++
+	}
+	var ready []int
+	for node := range known {
+		if indegree[node] == 0 {
+			ready = append(ready, node)
+		}
+	}
+	order := make([]int, 0, len(known))
+	for len(ready) > 0 {
+		sort.Ints(ready)
+		node := ready[0]
+		ready = ready[1:]
+		order = append(order, node)
+		for _, to := range next[node] {
+			indegree[to]--
+			if indegree[to] == 0 {
+				ready = append(ready, to)
+			}
+		}
+	}
+	if len(order) != len(known) {
+		return nil, ErrCycle
+	}
+	return order, nil
+}
+
+// CriticalPath returns the heaviest chain of order, which must be topologically sorted, and its total weight.
+func CriticalPath(order []int, edges []Edge, weight map[int]float64) ([]int, float64) {
+	previous := make(map[int][]int, len(order))
+	for _, edge := range edges {
+		previous[edge.To] = append(previous[edge.To], edge.From)
+	}
+	total := make(map[int]float64, len(order))
+	parent := make(map[int]int, len(order))
+	end, best := 0, -1.0
+	for _, node := range order {
+		total[node] = weight[node]
+		for _, from := range previous[node] {
+			if sum, ok := total[from]; ok && sum+weight[node] > total[node] {
+				total[node] = sum + weight[node]
+				parent[node] = from
+			}
+		}
+		if total[node] > best {
+			end, best = node, total[node]
+		}
+	}
+	if best < 0 {
+		return nil, 0
+	}
+	path := []int{end}
+	for {
+		from, ok := parent[path[0]]
+		if !ok {
+			break
+		}
+		path = append([]int{from}, path...)
+	}
+	return path, best
+}
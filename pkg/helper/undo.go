@@ -0,0 +1,49 @@
+package helper
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"github.com/google/uuid"
+	"go_gin/internal/config"
+	"time"
+)
+
+var (
+	ErrUndoTokenInvalid = errors.New("undo token is invalid")
+	ErrUndoTokenExpired = errors.New("undo token has expired")
+)
+
+// NewUndoToken signs the moment userID deleted a batch of todolists, every todolist of the batch shares that
+// deleted_at so the token alone is enough to restore them.
+func NewUndoToken(userID uuid.UUID, deletedAt time.Time) string {
+	payload := make([]byte, 24, 24+sha256.Size)
+	copy(payload, userID[:])
+	binary.BigEndian.PutUint64(payload[16:], uint64(deletedAt.UnixMicro()))
+	return base64.RawURLEncoding.EncodeToString(append(payload, undoSignature(payload)...))
+}
+
+// ParseUndoToken returns the deleted_at signed in token for userID, refusing it once config.Trash.UndoMinutes passed.
+func ParseUndoToken(token string, userID uuid.UUID, now time.Time) (time.Time, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(raw) != 24+sha256.Size {
+		return time.Time{}, ErrUndoTokenInvalid
+	}
+	payload := raw[:24]
+	if !hmac.Equal(raw[24:], undoSignature(payload)) || uuid.UUID(payload[:16]) != userID {
+		return time.Time{}, ErrUndoTokenInvalid
+	}
+	deletedAt := time.UnixMicro(int64(binary.BigEndian.Uint64(payload[16:])))
+	if now.After(deletedAt.Add(time.Duration(config.Trash.UndoMinutes) * time.Minute)) {
+		return time.Time{}, ErrUndoTokenExpired
+	}
+	return deletedAt, nil
+}
+
+func undoSignature(payload []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(config.Other.SecretKey))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,349 @@
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Property is a content line of a calendar, NAME;PARAM=value:value.
+type Property struct {
+	Name   string
+	Params map[string]string
+	Value  string
+}
+
+// Component is a BEGIN:NAME ... END:NAME block of a calendar. Line is where it begins when it was decoded.
+type Component struct {
+	Name       string
+	Line       int
+	Properties []Property
+	Components []Component
+}
+
+// Add appends a property, params are given as NAME=value pairs.
+func (c *Component) Add(name string, value string, params ...string) {
+	property := Property{Name: name, Value: value}
+	for _, param := range params {
+		key, paramValue, _ := strings.Cut(param, "=")
+		if property.Params == nil {
+			property.Params = map[string]string{}
+		}
+		property.Params[key] = paramValue
+	}
+	c.Properties = append(c.Properties, property)
+}
+
+// Set replaces the value of the first property called name, or appends it when there is none.
+func (c *Component) Set(name string, value string) {
+	for i := range c.Properties {
+		if c.Properties[i].Name == name {
+			c.Properties[i].Value = value
+			return
+		}
+	}
+	c.Add(name, value)
+}
+
+// AddText appends a property of type TEXT, escaping its value.
+func (c *Component) AddText(name string, text string) {
+	c.Add(name, EscapeText(text))
+}
+
+// Get returns the first property called name.
+func (c *Component) Get(name string) (Property, bool) {
+	for _, property := range c.Properties {
+		if property.Name == name {
+			return property, true
+		}
+	}
+	return Property{}, false
+}
+
+// Text returns the unescaped value of the first property called name, empty when there is none.
+func (c *Component) Text(name string) string {
+	property, _ := c.Get(name)
+	return UnescapeText(property.Value)
+}
+
+// Find returns the components called name anywhere below c.
+func (c *Component) Find(name string) []Component {
+	var found []Component
+	for _, component := range c.Components {
+		if component.Name == name {
+			found = append(found, component)
+		}
+		found = append(found, component.Find(name)...)
+	}
+	return found
+}
+
+func EscapeText(text string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(text)
+}
+
+func UnescapeText(text string) string {
+	return strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n").Replace(text)
+}
+
+// FormatDate is a DATE value, FormatDateTime a DATE-TIME value in UTC.
+func FormatDate(t time.Time) string {
+	return t.Format("20060102")
+}
+
+func FormatDateTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// Time reads a DATE or DATE-TIME property. A local time is read in the zone of its TZID, or in UTC without one.
+func (p Property) Time() (time.Time, error) {
+	location := time.UTC
+	if tzid := p.Params["TZID"]; tzid != "" {
+		if zone, err := time.LoadLocation(tzid); err == nil {
+			location = zone
+		}
+	}
+	for _, layout := range []string{"20060102T150405Z", "20060102T150405", "20060102"} {
+		if t, err := time.ParseInLocation(layout, p.Value, location); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%s %q is not a date", p.Name, p.Value)
+}
+
+// Encode writes c with CRLF line endings, folding lines longer than 75 octets.
+func Encode(w io.Writer, c Component) error {
+	writer := bufio.NewWriter(w)
+	encode(writer, c)
+	return writer.Flush()
+}
+
+func encode(w *bufio.Writer, c Component) {
+	writeLine(w, "BEGIN:"+c.Name)
+	for _, property := range c.Properties {
+		line := property.Name
+		keys := make([]string, 0, len(property.Params))
+		for key := range property.Params {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			value := property.Params[key]
+			if strings.ContainsAny(value, ";:,") {
+				value = `"` + value + `"`
+			}
+			line += ";" + key + "=" + value
+		}
+		writeLine(w, line+":"+property.Value)
+	}
+	for _, component := range c.Components {
+		encode(w, component)
+	}
+	writeLine(w, "END:"+c.Name)
+}
+
+// writeLine folds line into chunks of at most 75 octets without splitting a character.
+func writeLine(w *bufio.Writer, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		w.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		// the leading space of a continuation line counts
+		limit = 74
+	}
+	w.WriteString(line + "\r\n")
+}
+
+// Decode reads the first component of r, usually a VCALENDAR.
+func Decode(r io.Reader) (Component, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	var lines []string
+	var numbers []int
+	for number := 1; scanner.Scan(); number++ {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if number == 1 {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line == "" {
+			continue
+		}
+		lines = append(lines, line)
+		numbers = append(numbers, number)
+	}
+	if err := scanner.Err(); err != nil {
+		return Component{}, err
+	}
+	var stack []Component
+	for i, line := range lines {
+		property, err := parseLine(line)
+		if err != nil {
+			return Component{}, fmt.Errorf("line %d: %w", numbers[i], err)
+		}
+		switch property.Name {
+		case "BEGIN":
+			stack = append(stack, Component{Name: strings.ToUpper(property.Value), Line: numbers[i]})
+		case "END":
+			if len(stack) == 0 || stack[len(stack)-1].Name != strings.ToUpper(property.Value) {
+				return Component{}, fmt.Errorf("line %d: END:%s does not close a component", numbers[i], property.Value)
+			}
+			component := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if len(stack) == 0 {
+				return component, nil
+			}
+			stack[len(stack)-1].Components = append(stack[len(stack)-1].Components, component)
+		default:
+			if len(stack) == 0 {
+				return Component{}, fmt.Errorf("line %d: %s is outside of a component", numbers[i], property.Name)
+			}
+			stack[len(stack)-1].Properties = append(stack[len(stack)-1].Properties, property)
+		}
+	}
+	return Component{}, fmt.Errorf("calendar is empty or not closed")
+}
+
+// parseLine splits a content line into its name, params and value, a colon inside a quoted param is not the end of
+// the name.
+func parseLine(line string) (Property, error) {
+	quoted := false
+	colon := -1
+	for i, r := range line {
+		if r == '"' {
+			quoted = !quoted
+		} else if r == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return Property{}, fmt.Errorf("%q is not a content line", line)
+	}
+	parts := strings.Split(line[:colon], ";")
+	property := Property{Name: strings.ToUpper(parts[0]), Value: line[colon+1:]}
+	for _, param := range parts[1:] {
+		key, value, _ := strings.Cut(param, "=")
+		if property.Params == nil {
+			property.Params = map[string]string{}
+		}
+		property.Params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+	}
+	return property, nil
+}
+
+// recurrenceParts are the parts of a recurrence rule that are kept with the check of their value, see
+// ValidateRecurrence.
+var recurrenceParts = map[string]func(string) bool{
+	"FREQ":     func(value string) bool { return recurrenceFrequencies[value] },
+	"INTERVAL": func(value string) bool { return recurrenceNumber(value, false, 1, 0) },
+	"COUNT":    func(value string) bool { return recurrenceNumber(value, false, 1, 0) },
+	"UNTIL":    recurrenceUntil,
+	"BYDAY":    func(value string) bool { return recurrenceList(value, recurrenceDay) },
+	"BYMONTHDAY": func(value string) bool {
+		return recurrenceList(value, func(v string) bool { return recurrenceNumber(v, true, 1, 31) })
+	},
+	"BYMONTH": func(value string) bool {
+		return recurrenceList(value, func(v string) bool { return recurrenceNumber(v, false, 1, 12) })
+	},
+	"BYSETPOS": func(value string) bool {
+		return recurrenceList(value, func(v string) bool { return recurrenceNumber(v, true, 1, 366) })
+	},
+	"WKST": func(value string) bool { return recurrenceWeekdays[value] },
+}
+
+var recurrenceFrequencies = map[string]bool{"DAILY": true, "WEEKLY": true, "MONTHLY": true, "YEARLY": true}
+
+var recurrenceWeekdays = map[string]bool{"MO": true, "TU": true, "WE": true, "TH": true, "FR": true, "SA": true, "SU": true}
+
+// recurrenceNumber checks that value is a decimal number from min to max, or from min up when max is 0, with a sign
+// allowed when signed.
+func recurrenceNumber(value string, signed bool, min, max int) bool {
+	if signed && (strings.HasPrefix(value, "+") || strings.HasPrefix(value, "-")) {
+		value = value[1:]
+	}
+	if value == "" || len(value) > 9 {
+		return false
+	}
+	number := 0
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+		number = number*10 + int(r-'0')
+	}
+	return number >= min && (max == 0 || number <= max)
+}
+
+// recurrenceDay checks a BYDAY day, a weekday code with an optional signed position such as -1FR.
+func recurrenceDay(value string) bool {
+	if len(value) < 2 || !recurrenceWeekdays[value[len(value)-2:]] {
+		return false
+	}
+	position := value[:len(value)-2]
+	return position == "" || recurrenceNumber(position, true, 1, 53)
+}
+
+// recurrenceUntil checks that value is a DATE or a DATE-TIME, local or in UTC.
+func recurrenceUntil(value string) bool {
+	for _, layout := range []string{"20060102", "20060102T150405", "20060102T150405Z"} {
+		if _, err := time.Parse(layout, value); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func recurrenceList(value string, valid func(string) bool) bool {
+	for _, item := range strings.Split(value, ",") {
+		if !valid(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidateRecurrence checks that rule is an RRULE value with a daily, weekly, monthly or yearly frequency made of the
+// parts calendar apps commonly understand. Every value is checked, as the rule is written as is into calendars, a
+// control character would start a content line of its own.
+func ValidateRecurrence(rule string) error {
+	for _, r := range rule {
+		if r < ' ' || r == 0x7f {
+			return fmt.Errorf("recurrence %q has a control character", rule)
+		}
+	}
+	seen := make(map[string]bool)
+	freq := ""
+	for _, part := range strings.Split(rule, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		valid, known := recurrenceParts[key]
+		if !ok || value == "" || !known || seen[key] {
+			return fmt.Errorf("recurrence part %q is not supported", part)
+		}
+		seen[key] = true
+		if key == "FREQ" {
+			freq = value
+		}
+		if !valid(value) {
+			if key == "FREQ" {
+				return fmt.Errorf("recurrence frequency %s is not supported", value)
+			}
+			return fmt.Errorf("recurrence part %q has an invalid value", part)
+		}
+	}
+	if freq == "" {
+		return fmt.Errorf("recurrence %q has no FREQ", rule)
+	}
+	return nil
+}
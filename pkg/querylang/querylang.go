@@ -0,0 +1,357 @@
+package querylang
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Kind is the type of the values a field accepts.
+type Kind int
+
+const (
+	KindInt Kind = iota
+	KindDate
+	KindBool
+	KindString
+)
+
+type Op string
+
+const (
+	OpEq Op = ":"
+	OpNe Op = "!="
+	OpLt Op = "<"
+	OpLe Op = "<="
+	OpGt Op = ">"
+	OpGe Op = ">="
+)
+
+// Node is an element of a parsed query, Pos is the 1-based character it starts at.
+type Node interface {
+	Position() int
+}
+
+// And matches when every node matches, terms separated by spaces are joined with And.
+type And struct {
+	Nodes []Node
+	Pos   int
+}
+
+// Or matches when one of the nodes matches, written with the OR keyword.
+type Or struct {
+	Nodes []Node
+	Pos   int
+}
+
+// Not negates a node, written with a leading "-".
+type Not struct {
+	Node Node
+	Pos  int
+}
+
+// Compare checks a field against a value, Value is an int, a time.Time, a bool or a string following the field Kind.
+// A bare boolean field such as "completed" is a Compare with the value true.
+type Compare struct {
+	Field string
+	Op    Op
+	Value interface{}
+	Pos   int
+}
+
+// Text matches free text, a quoted phrase is kept as one Text.
+type Text struct {
+	Value string
+	Pos   int
+}
+
+func (n *And) Position() int     { return n.Pos }
+func (n *Or) Position() int      { return n.Pos }
+func (n *Not) Position() int     { return n.Pos }
+func (n *Compare) Position() int { return n.Pos }
+func (n *Text) Position() int    { return n.Pos }
+
+// SyntaxError tells where a query could not be parsed, Pos is the 1-based character of the offending token.
+type SyntaxError struct {
+	Pos int
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("query error at position %d: %s", e.Pos, e.Msg)
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenOp
+	tokenMinus
+	tokenOpen
+	tokenClose
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func isDelimiter(r rune) bool {
+	return unicode.IsSpace(r) || strings.ContainsRune(`()":<>=!`, r)
+}
+
+// isSigned tells whether a "-" followed by rest is the sign of a value such as priority:-1 rather than a negation: it
+// directly precedes a digit and comes right after an operator.
+func isSigned(tokens []token, rest []rune) bool {
+	return len(tokens) > 0 && tokens[len(tokens)-1].kind == tokenOp && len(rest) > 0 && unicode.IsDigit(rest[0])
+}
+
+func lex(input string) ([]token, error) {
+	runes := []rune(input)
+	var tokens []token
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		pos := i + 1
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{tokenOpen, "(", pos})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{tokenClose, ")", pos})
+			i++
+		case r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, &SyntaxError{Pos: pos, Msg: "missing closing quote"}
+			}
+			tokens = append(tokens, token{tokenString, string(runes[i+1 : end]), pos})
+			i = end + 1
+		case r == ':' || r == '=':
+			tokens = append(tokens, token{tokenOp, string(OpEq), pos})
+			i++
+		case r == '!':
+			if i+1 >= len(runes) || runes[i+1] != '=' {
+				return nil, &SyntaxError{Pos: pos, Msg: `unexpected "!", did you mean "!="`}
+			}
+			tokens = append(tokens, token{tokenOp, string(OpNe), pos})
+			i += 2
+		case r == '<' || r == '>':
+			op := string(r)
+			i++
+			if i < len(runes) && runes[i] == '=' {
+				op += "="
+				i++
+			}
+			tokens = append(tokens, token{tokenOp, op, pos})
+		case r == '-' && !isSigned(tokens, runes[i+1:]):
+			tokens = append(tokens, token{tokenMinus, "-", pos})
+			i++
+		default:
+			end := i
+			for end < len(runes) && !isDelimiter(runes[end]) {
+				end++
+			}
+			tokens = append(tokens, token{tokenWord, string(runes[i:end]), pos})
+			i = end
+		}
+	}
+	return append(tokens, token{tokenEOF, "", len(runes) + 1}), nil
+}
+
+type parser struct {
+	tokens []token
+	at     int
+	fields map[string]Kind
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.at]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.at]
+	if t.kind != tokenEOF {
+		p.at++
+	}
+	return t
+}
+
+func isOr(t token) bool {
+	return t.kind == tokenWord && t.text == "OR"
+}
+
+func (p *parser) parseOr() (Node, error) {
+	pos := p.peek().pos
+	first, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	nodes := []Node{first}
+	for isOr(p.peek()) {
+		p.next()
+		node, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+	if len(nodes) == 1 {
+		return first, nil
+	}
+	return &Or{Nodes: nodes, Pos: pos}, nil
+}
+
+func (p *parser) parseAnd() (Node, error) {
+	pos := p.peek().pos
+	var nodes []Node
+	for {
+		t := p.peek()
+		if t.kind == tokenEOF || t.kind == tokenClose || isOr(t) {
+			break
+		}
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+	switch len(nodes) {
+	case 0:
+		return nil, &SyntaxError{Pos: pos, Msg: "expected a search term"}
+	case 1:
+		return nodes[0], nil
+	}
+	return &And{Nodes: nodes, Pos: pos}, nil
+}
+
+func (p *parser) parseUnary() (Node, error) {
+	if t := p.peek(); t.kind == tokenMinus {
+		p.next()
+		if next := p.peek(); next.kind == tokenEOF || next.kind == tokenClose || isOr(next) {
+			return nil, &SyntaxError{Pos: t.pos, Msg: `expected a search term after "-"`}
+		}
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &Not{Node: node, Pos: t.pos}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (Node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenOpen:
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek().kind != tokenClose {
+			return nil, &SyntaxError{Pos: t.pos, Msg: `missing closing ")"`}
+		}
+		p.next()
+		return node, nil
+	case tokenString:
+		return &Text{Value: t.text, Pos: t.pos}, nil
+	case tokenWord:
+		if p.peek().kind == tokenOp {
+			return p.parseCompare(t)
+		}
+		if kind, ok := p.fields[strings.ToLower(t.text)]; ok && kind == KindBool {
+			return &Compare{Field: strings.ToLower(t.text), Op: OpEq, Value: true, Pos: t.pos}, nil
+		}
+		return &Text{Value: t.text, Pos: t.pos}, nil
+	}
+	return nil, &SyntaxError{Pos: t.pos, Msg: fmt.Sprintf("unexpected %q", t.text)}
+}
+
+func (p *parser) parseCompare(field token) (Node, error) {
+	name := strings.ToLower(field.text)
+	kind, ok := p.fields[name]
+	if !ok {
+		return nil, &SyntaxError{Pos: field.pos, Msg: fmt.Sprintf("unknown field %q", field.text)}
+	}
+	op := p.next()
+	value := p.next()
+	if value.kind != tokenWord && value.kind != tokenString {
+		return nil, &SyntaxError{Pos: value.pos, Msg: fmt.Sprintf("expected a value after %s%s", field.text, op.text)}
+	}
+	if (kind == KindBool || kind == KindString) && Op(op.text) != OpEq && Op(op.text) != OpNe {
+		return nil, &SyntaxError{Pos: op.pos, Msg: fmt.Sprintf("field %s only supports : and !=", name)}
+	}
+	compare := &Compare{Field: name, Op: Op(op.text), Pos: field.pos}
+	var err error
+	switch kind {
+	case KindInt:
+		compare.Value, err = strconv.Atoi(value.text)
+		if err != nil {
+			return nil, &SyntaxError{Pos: value.pos, Msg: fmt.Sprintf("%s expects a number, got %q", name, value.text)}
+		}
+	case KindDate:
+		compare.Value, err = parseDate(value.text)
+		if err != nil {
+			return nil, &SyntaxError{Pos: value.pos, Msg: fmt.Sprintf("%s expects a date as YYYY-MM-DD or today, today+N, today-N, got %q", name, value.text)}
+		}
+	case KindBool:
+		compare.Value, err = strconv.ParseBool(value.text)
+		if err != nil {
+			return nil, &SyntaxError{Pos: value.pos, Msg: fmt.Sprintf("%s expects true or false, got %q", name, value.text)}
+		}
+	default:
+		compare.Value = value.text
+	}
+	return compare, nil
+}
+
+// parseDate reads a YYYY-MM-DD date or a date relative to the current day such as today+7, so a saved query
+// like due<today+7 keeps meaning "due within a week".
+func parseDate(text string) (time.Time, error) {
+	lower := strings.ToLower(text)
+	if !strings.HasPrefix(lower, "today") {
+		return time.Parse("2006-01-02", text)
+	}
+	days := 0
+	if offset := strings.TrimPrefix(lower, "today"); offset != "" {
+		if offset[0] != '+' && offset[0] != '-' {
+			return time.Time{}, fmt.Errorf("invalid relative date %q", text)
+		}
+		var err error
+		if days, err = strconv.Atoi(offset); err != nil {
+			return time.Time{}, err
+		}
+	}
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day()+days, 0, 0, 0, 0, time.UTC), nil
+}
+
+// Parse reads a query such as `priority>=3 due<2026-11-01 tag:work -completed "quarterly report"` into a Node,
+// fields lists the fields the query may use and the Kind of their values. An empty query returns a nil Node.
+func Parse(input string, fields map[string]Kind) (Node, error) {
+	tokens, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+	if tokens[0].kind == tokenEOF {
+		return nil, nil
+	}
+	p := &parser{tokens: tokens, fields: fields}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, &SyntaxError{Pos: t.pos, Msg: fmt.Sprintf("unexpected %q", t.text)}
+	}
+	return node, nil
+}
@@ -0,0 +1,563 @@
+package quickadd
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Task is what Parse reads out of a line of text. Due is in the location of the now given to Parse and is only set
+// when the text names a date, a time or a recurrence; HasTime tells whether it has a time of day. Priority is 0 when
+// the text names none and Recurrence is an RRULE value.
+type Task struct {
+	Title      string
+	Due        *time.Time
+	HasTime    bool
+	Priority   int
+	Tags       []string
+	Recurrence string
+}
+
+// Parse reads a task written the way people jot them down, such as "Pay rent every 1st of month !high #home" or
+// "Call Anna tomorrow at 5pm". The words that are not a date, time, priority, tag or recurrence make the title.
+func Parse(text string, now time.Time) Task {
+	p := parser{now: now, words: strings.Fields(text)}
+	for _, word := range p.words {
+		p.lower = append(p.lower, strings.ToLower(strings.TrimRight(word, ",.;")))
+	}
+	var title []string
+	for i := 0; i < len(p.words); {
+		if n := p.match(i); n > 0 {
+			i += n
+			continue
+		}
+		title = append(title, p.words[i])
+		i++
+	}
+	p.task.Title = strings.Join(title, " ")
+	p.resolve()
+	return p.task
+}
+
+type parser struct {
+	now   time.Time
+	words []string
+	lower []string
+	task  Task
+	// day is the date named by the text, at midnight
+	day     *time.Time
+	hour    int
+	minute  int
+	hasTime bool
+	// at is an exact moment such as "in 2 hours"
+	at   *time.Time
+	rule *recurrence
+}
+
+func (p *parser) word(i int) string {
+	if i < 0 || i >= len(p.lower) {
+		return ""
+	}
+	return p.lower[i]
+}
+
+// match returns how many words starting at i it read, 0 when they are part of the title.
+func (p *parser) match(i int) int {
+	for _, matcher := range []func(int) int{p.priority, p.tag, p.recurrence, p.date, p.time} {
+		if n := matcher(i); n > 0 {
+			return n
+		}
+	}
+	return 0
+}
+
+var priorities = map[string]int{
+	"low": 1, "l": 1, "medium": 2, "med": 2, "m": 2, "high": 3, "h": 3, "urgent": 4, "u": 4,
+}
+
+// priority reads !high, !2, !!! and the p1 (most urgent) to p4 of Todoist. A higher priority is more urgent.
+func (p *parser) priority(i int) int {
+	word := p.word(i)
+	if level, ok := strings.CutPrefix(word, "!"); ok {
+		if priority, ok := priorities[level]; ok {
+			p.task.Priority = priority
+			return 1
+		}
+		if priority, err := strconv.Atoi(level); err == nil && priority >= 1 && priority <= 99 {
+			p.task.Priority = priority
+			return 1
+		}
+		if strings.Trim(word, "!") == "" && len(word) <= 4 {
+			p.task.Priority = len(word)
+			return 1
+		}
+		return 0
+	}
+	if len(word) == 2 && word[0] == 'p' && word[1] >= '1' && word[1] <= '4' {
+		p.task.Priority = 5 - int(word[1]-'0')
+		return 1
+	}
+	return 0
+}
+
+func (p *parser) tag(i int) int {
+	tag, ok := strings.CutPrefix(strings.TrimRight(p.words[i], ",.;"), "#")
+	if !ok || tag == "" || strings.HasPrefix(tag, "#") {
+		return 0
+	}
+	p.task.Tags = append(p.task.Tags, tag)
+	return 1
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "monday": time.Monday, "tuesday": time.Tuesday, "wednesday": time.Wednesday,
+	"thursday": time.Thursday, "friday": time.Friday, "saturday": time.Saturday,
+}
+
+var weekdayCodes = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+var months = map[string]time.Month{
+	"january": time.January, "jan": time.January, "february": time.February, "feb": time.February,
+	"march": time.March, "mar": time.March, "april": time.April, "apr": time.April, "may": time.May,
+	"june": time.June, "jun": time.June, "july": time.July, "jul": time.July, "august": time.August,
+	"aug": time.August, "september": time.September, "sep": time.September, "sept": time.September,
+	"october": time.October, "oct": time.October, "november": time.November, "nov": time.November,
+	"december": time.December, "dec": time.December,
+}
+
+// weekday reads a day of the week, plural included as in "every mondays".
+func weekday(word string) (time.Weekday, bool) {
+	day, ok := weekdays[strings.TrimSuffix(word, "s")]
+	if !ok {
+		day, ok = weekdays[word]
+	}
+	return day, ok
+}
+
+var ordinalPattern = regexp.MustCompile(`^(\d{1,2})(st|nd|rd|th)?$`)
+
+// ordinal reads a day of the month such as 1st, 22nd or 15.
+func ordinal(word string) (int, bool) {
+	match := ordinalPattern.FindStringSubmatch(word)
+	if match == nil {
+		return 0, false
+	}
+	day, _ := strconv.Atoi(match[1])
+	return day, day >= 1 && day <= 31
+}
+
+// unit reads a unit of time in singular or plural.
+func unit(word string) string {
+	switch strings.TrimSuffix(word, "s") {
+	case "minute", "min":
+		return "minute"
+	case "hour", "hr":
+		return "hour"
+	case "day":
+		return "day"
+	case "week", "wk":
+		return "week"
+	case "month":
+		return "month"
+	case "year", "yr":
+		return "year"
+	}
+	return ""
+}
+
+type recurrence struct {
+	freq       string
+	interval   int
+	byDay      []time.Weekday
+	byMonthDay int
+	byMonth    time.Month
+}
+
+// String is the RRULE value of the recurrence.
+func (r *recurrence) String() string {
+	rule := "FREQ=" + r.freq
+	if r.interval > 1 {
+		rule += ";INTERVAL=" + strconv.Itoa(r.interval)
+	}
+	if r.byMonth != 0 {
+		rule += ";BYMONTH=" + strconv.Itoa(int(r.byMonth))
+	}
+	if len(r.byDay) > 0 {
+		codes := make([]string, len(r.byDay))
+		for i, day := range r.byDay {
+			codes[i] = weekdayCodes[day]
+		}
+		rule += ";BYDAY=" + strings.Join(codes, ",")
+	}
+	if r.byMonthDay != 0 {
+		rule += ";BYMONTHDAY=" + strconv.Itoa(r.byMonthDay)
+	}
+	return rule
+}
+
+// recurrence reads daily, weekly, monthly, yearly and "every" followed by a unit ("every 2 weeks", "every other
+// day"), days of the week ("every monday and thursday", "every weekday") or a day of the month ("every 1st of month",
+// "every month on the 15th", "every 5th of june").
+func (p *parser) recurrence(i int) int {
+	frequencies := map[string]string{"daily": "DAILY", "weekly": "WEEKLY", "monthly": "MONTHLY", "yearly": "YEARLY", "annually": "YEARLY"}
+	if freq, ok := frequencies[p.word(i)]; ok {
+		p.rule = &recurrence{freq: freq}
+		return 1
+	}
+	if p.word(i) != "every" {
+		return 0
+	}
+	rule := recurrence{interval: 1}
+	j := i + 1
+	if p.word(j) == "other" {
+		rule.interval = 2
+		j++
+	} else if interval, err := strconv.Atoi(p.word(j)); err == nil && interval > 1 && unit(p.word(j+1)) != "" {
+		rule.interval = interval
+		j++
+	}
+	switch unit(p.word(j)) {
+	case "day":
+		rule.freq = "DAILY"
+		j++
+	case "week":
+		rule.freq = "WEEKLY"
+		j++
+		if p.word(j) == "on" {
+			if n := p.weekdays(j+1, &rule); n > 0 {
+				j += n + 1
+			}
+		}
+	case "month":
+		rule.freq = "MONTHLY"
+		j++
+		if p.word(j) == "on" {
+			k := j + 1
+			if p.word(k) == "the" {
+				k++
+			}
+			if day, ok := ordinal(p.word(k)); ok {
+				rule.byMonthDay = day
+				j = k + 1
+			}
+		}
+	case "year":
+		rule.freq = "YEARLY"
+		j++
+	default:
+		switch n := p.weekdays(j, &rule); {
+		case n > 0:
+			rule.freq = "WEEKLY"
+			j += n
+		case p.word(j) == "weekday" || p.word(j) == "weekdays":
+			rule.freq = "WEEKLY"
+			rule.byDay = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+			j++
+		case p.word(j) == "weekend" || p.word(j) == "weekends":
+			rule.freq = "WEEKLY"
+			rule.byDay = []time.Weekday{time.Saturday, time.Sunday}
+			j++
+		default:
+			day, ok := ordinal(p.word(j))
+			if !ok {
+				return 0
+			}
+			rule.freq, rule.byMonthDay = "MONTHLY", day
+			j++
+			k := j
+			if p.word(k) == "of" {
+				k++
+			}
+			if p.word(k) == "the" || p.word(k) == "each" || p.word(k) == "every" {
+				k++
+			}
+			if unit(p.word(k)) == "month" {
+				j = k + 1
+			} else if month, ok := months[p.word(k)]; ok {
+				if day > time.Date(2024, month+1, 0, 0, 0, 0, 0, time.UTC).Day() {
+					// the 31st of a shorter month never comes, 2024 being a leap year
+					return 0
+				}
+				rule.freq, rule.byMonth = "YEARLY", month
+				j = k + 1
+			}
+		}
+	}
+	p.rule = &rule
+	return j - i
+}
+
+// weekdays reads days of the week joined by commas, "and" or "&" into rule, it returns how many words it read.
+func (p *parser) weekdays(i int, rule *recurrence) int {
+	j := i
+	for {
+		day, ok := weekday(p.word(j))
+		if !ok {
+			break
+		}
+		rule.byDay = append(rule.byDay, day)
+		j++
+		if (p.word(j) == "and" || p.word(j) == "&") && p.word(j+1) != "" {
+			if _, ok := weekday(p.word(j + 1)); ok {
+				j++
+			}
+		}
+	}
+	return j - i
+}
+
+var isoDatePattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+
+// date reads today, tomorrow, next week or month, a day of the week, "in 3 days", 2026-11-05, "nov 5" or "5th
+// november", with an optional on, due or by before it.
+func (p *parser) date(i int) int {
+	switch p.word(i) {
+	case "on", "due", "by":
+		if n := p.dateAt(i + 1); n > 0 {
+			return n + 1
+		}
+		return 0
+	}
+	return p.dateAt(i)
+}
+
+func (p *parser) dateAt(i int) int {
+	today := time.Date(p.now.Year(), p.now.Month(), p.now.Day(), 0, 0, 0, 0, p.now.Location())
+	word := p.word(i)
+	switch word {
+	case "today", "tonight":
+		p.setDay(today)
+		return 1
+	case "tomorrow", "tmr", "tmrw":
+		p.setDay(today.AddDate(0, 0, 1))
+		return 1
+	case "next":
+		switch next := p.word(i + 1); {
+		case next == "week":
+			p.setDay(nextWeekday(today, time.Monday))
+		case next == "month":
+			p.setDay(time.Date(today.Year(), today.Month()+1, 1, 0, 0, 0, 0, today.Location()))
+		case next == "year":
+			p.setDay(time.Date(today.Year()+1, time.January, 1, 0, 0, 0, 0, today.Location()))
+		default:
+			day, ok := weekdays[next]
+			if !ok {
+				return 0
+			}
+			p.setDay(nextWeekday(today, day))
+		}
+		return 2
+	case "in":
+		count, err := strconv.Atoi(p.word(i + 1))
+		if p.word(i+1) == "a" || p.word(i+1) == "an" {
+			count, err = 1, nil
+		}
+		if err != nil || count < 1 {
+			return 0
+		}
+		switch unit(p.word(i + 2)) {
+		case "minute":
+			p.setAt(p.now.Add(time.Duration(count) * time.Minute))
+		case "hour":
+			p.setAt(p.now.Add(time.Duration(count) * time.Hour))
+		case "day":
+			p.setDay(today.AddDate(0, 0, count))
+		case "week":
+			p.setDay(today.AddDate(0, 0, 7*count))
+		case "month":
+			p.setDay(today.AddDate(0, count, 0))
+		case "year":
+			p.setDay(today.AddDate(count, 0, 0))
+		default:
+			return 0
+		}
+		return 3
+	}
+	if day, ok := weekdays[word]; ok {
+		p.setDay(nextWeekday(today, day))
+		return 1
+	}
+	if isoDatePattern.MatchString(word) {
+		day, err := time.ParseInLocation("2006-01-02", word, today.Location())
+		if err != nil {
+			return 0
+		}
+		p.setDay(day)
+		return 1
+	}
+	// nov 5, november 5th 2027, 5 nov, 5th of november
+	month, day, n := time.Month(0), 0, 0
+	if m, ok := months[word]; ok {
+		if d, ok := ordinal(p.word(i + 1)); ok {
+			month, day, n = m, d, 2
+		}
+	} else if d, ok := ordinal(word); ok {
+		k := i + 1
+		if p.word(k) == "of" {
+			k++
+		}
+		if m, ok := months[p.word(k)]; ok {
+			month, day, n = m, d, k-i+1
+		}
+	}
+	if n == 0 {
+		return 0
+	}
+	year, explicit := today.Year(), false
+	if y, err := strconv.Atoi(p.word(i + n)); err == nil && len(p.word(i+n)) == 4 {
+		year, explicit = y, true
+		n++
+	}
+	date := time.Date(year, month, day, 0, 0, 0, 0, today.Location())
+	if date.Month() != month {
+		// the 31st of a shorter month
+		return 0
+	}
+	if !explicit && date.Before(today) {
+		date = date.AddDate(1, 0, 0)
+	}
+	p.setDay(date)
+	return n
+}
+
+var timePattern = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(am|pm)?$`)
+
+// time reads 5pm, 5:30 pm, 17:30, noon or midnight, a bare hour needs an at before it.
+func (p *parser) time(i int) int {
+	if p.word(i) == "at" {
+		if n := p.timeAt(i+1, true); n > 0 {
+			return n + 1
+		}
+		return 0
+	}
+	return p.timeAt(i, false)
+}
+
+func (p *parser) timeAt(i int, bare bool) int {
+	switch p.word(i) {
+	case "noon":
+		p.setTime(12, 0)
+		return 1
+	case "midnight":
+		p.setTime(0, 0)
+		return 1
+	}
+	match := timePattern.FindStringSubmatch(p.word(i))
+	if match == nil {
+		return 0
+	}
+	n := 1
+	meridiem := match[3]
+	if meridiem == "" && (p.word(i+1) == "am" || p.word(i+1) == "pm") {
+		meridiem = p.word(i + 1)
+		n = 2
+	}
+	if match[2] == "" && meridiem == "" && !bare {
+		return 0
+	}
+	hour, _ := strconv.Atoi(match[1])
+	minute, _ := strconv.Atoi(match[2])
+	if minute > 59 {
+		return 0
+	}
+	switch meridiem {
+	case "":
+		if hour > 23 {
+			return 0
+		}
+	default:
+		if hour < 1 || hour > 12 {
+			return 0
+		}
+		hour %= 12
+		if meridiem == "pm" {
+			hour += 12
+		}
+	}
+	p.setTime(hour, minute)
+	return n
+}
+
+func (p *parser) setDay(day time.Time) {
+	p.day = &day
+}
+
+func (p *parser) setAt(at time.Time) {
+	p.at = &at
+}
+
+func (p *parser) setTime(hour int, minute int) {
+	p.hour, p.minute, p.hasTime = hour, minute, true
+}
+
+// nextWeekday is the first day after from that falls on day.
+func nextWeekday(from time.Time, day time.Weekday) time.Time {
+	days := (int(day) - int(from.Weekday()) + 7) % 7
+	if days == 0 {
+		days = 7
+	}
+	return from.AddDate(0, 0, days)
+}
+
+// resolve settles the due date. A recurrence without a date starts at its first occurrence from today and a time
+// without a date is today, or tomorrow once that time has passed.
+func (p *parser) resolve() {
+	if p.rule != nil {
+		p.task.Recurrence = p.rule.String()
+	}
+	if p.at != nil {
+		p.task.Due, p.task.HasTime = p.at, true
+		return
+	}
+	today := time.Date(p.now.Year(), p.now.Month(), p.now.Day(), 0, 0, 0, 0, p.now.Location())
+	if p.day == nil && p.rule != nil {
+		first := p.rule.first(today)
+		p.day = &first
+	}
+	if p.day == nil && p.hasTime {
+		day := today
+		if !time.Date(today.Year(), today.Month(), today.Day(), p.hour, p.minute, 0, 0, today.Location()).After(p.now) {
+			day = today.AddDate(0, 0, 1)
+		}
+		p.day = &day
+	}
+	if p.day == nil {
+		return
+	}
+	due := *p.day
+	if p.hasTime {
+		due = time.Date(due.Year(), due.Month(), due.Day(), p.hour, p.minute, 0, 0, due.Location())
+	}
+	p.task.Due, p.task.HasTime = &due, p.hasTime
+}
+
+// first is the first day on or after from the recurrence falls on.
+func (r *recurrence) first(from time.Time) time.Time {
+	switch {
+	case len(r.byDay) > 0:
+		for days := 0; days < 7; days++ {
+			day := from.AddDate(0, 0, days)
+			for _, weekday := range r.byDay {
+				if day.Weekday() == weekday {
+					return day
+				}
+			}
+		}
+	case r.byMonth != 0:
+		for years := 0; years < 8; years++ {
+			day := time.Date(from.Year()+years, r.byMonth, r.byMonthDay, 0, 0, 0, 0, from.Location())
+			if day.Month() == r.byMonth && !day.Before(from) {
+				return day
+			}
+		}
+	case r.byMonthDay != 0:
+		for months := 0; months < 12; months++ {
+			day := time.Date(from.Year(), from.Month()+time.Month(months), r.byMonthDay, 0, 0, 0, 0, from.Location())
+			if day.Day() == r.byMonthDay && !day.Before(from) {
+				return day
+			}
+		}
+	}
+	return from
+}
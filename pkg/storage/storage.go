@@ -0,0 +1,27 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"go_gin/internal/config"
+	"io"
+)
+
+// Storage keeps attachment blobs addressed by key.
+type Storage interface {
+	Put(ctx context.Context, key string, reader io.Reader, size int64, contentType string) error
+	Open(ctx context.Context, key string, size int64) (io.ReadSeekCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// New returns the storage backend selected by the configured driver, local disk when none is set.
+func New(cfg *config.STORAGE) (Storage, error) {
+	switch cfg.Driver {
+	case "", "local":
+		return NewLocalStorage(cfg.Path), nil
+	case "s3":
+		return NewS3Storage(cfg.Endpoint, cfg.Region, cfg.Bucket, cfg.AccessKey, cfg.SecretKey, cfg.PathStyle), nil
+	default:
+		return nil, fmt.Errorf("storage driver %s not supported", cfg.Driver)
+	}
+}
@@ -0,0 +1,428 @@
+package taskio
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go_gin/pkg/ical"
+	"go_gin/pkg/quickadd"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	FormatCSV     = "csv"
+	FormatJSON    = "json"
+	FormatNDJSON  = "ndjson"
+	FormatTodoist = "todoist"
+	FormatTrello  = "trello"
+	FormatICS     = "ics"
+)
+
+// Record is one task read from an import, its fields are named after the target fields of the mapping. Line is the
+// line of the record in the file, or its position in a JSON array. Warnings are what was left out of the task.
+type Record struct {
+	Line     int
+	Fields   map[string]string
+	Warnings []string
+}
+
+// Mapping sends the columns of an import to the fields of a task, a column may feed several fields.
+type Mapping map[string][]string
+
+// ParseMapping reads a comma separated list of column=field pairs, every field must be in fields.
+func ParseMapping(mapping string, fields []string) (Mapping, error) {
+	parsed := Mapping{}
+	for _, pair := range strings.Split(mapping, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		column, field, ok := strings.Cut(pair, "=")
+		column, field = strings.TrimSpace(column), strings.TrimSpace(field)
+		if !ok || column == "" {
+			return nil, fmt.Errorf("mapping %q is not a column=field pair", pair)
+		}
+		if !contains(fields, field) {
+			return nil, fmt.Errorf("mapping %q targets the unknown field %q", pair, field)
+		}
+		parsed[column] = append(parsed[column], field)
+	}
+	return parsed, nil
+}
+
+// todoistMapping, trelloMapping and icsMapping map the columns of the exports read by readTodoist, readTrello and
+// readICS.
+var (
+	todoistMapping = Mapping{"CONTENT": {"task_name"}, "DESCRIPTION": {"description"}, "PRIORITY": {"priority"}, "DATE": {"due_date"}, "RECURRENCE": {"recurrence"}}
+	trelloMapping  = Mapping{"name": {"task_name"}, "desc": {"description"}, "due": {"due_date"}, "dueComplete": {"completed"}}
+	icsMapping     = Mapping{"SUMMARY": {"task_name"}, "DESCRIPTION": {"description"}, "DUE": {"due_date"}, "TZID": {"due_timezone"}, "PRIORITY": {"priority"}, "COMPLETED": {"completed"}, "RRULE": {"recurrence"}}
+)
+
+// Read reads every task of r in format. Columns named after one of fields map to it unless mapping says otherwise,
+// the known columns of Todoist, Trello and iCalendar exports are mapped the same way.
+func Read(format string, r io.Reader, mapping Mapping, fields []string) ([]Record, error) {
+	var rows []row
+	var err error
+	defaults := Mapping{}
+	switch format {
+	case FormatCSV:
+		rows, err = readCSV(r)
+	case FormatJSON:
+		rows, err = readJSON(r)
+	case FormatNDJSON:
+		rows, err = readNDJSON(r)
+	case FormatTodoist:
+		rows, err = readTodoist(r)
+		defaults = todoistMapping
+	case FormatTrello:
+		rows, err = readTrello(r)
+		defaults = trelloMapping
+	case FormatICS:
+		rows, err = readICS(r)
+		defaults = icsMapping
+	default:
+		return nil, fmt.Errorf("import format %s is not supported", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	records := make([]Record, 0, len(rows))
+	for _, row := range rows {
+		record := Record{Line: row.line, Fields: map[string]string{}, Warnings: row.warnings}
+		for column, value := range row.values {
+			targets, ok := mapping[column]
+			if !ok {
+				targets, ok = defaults[column]
+			}
+			if !ok && contains(fields, column) {
+				targets = []string{column}
+			}
+			for _, field := range targets {
+				record.Fields[field] = value
+			}
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// row is a line of an import before its columns are mapped.
+type row struct {
+	line     int
+	values   map[string]string
+	warnings []string
+}
+
+func readCSV(r io.Reader) ([]row, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+	var rows []row
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		values := make(map[string]string, len(header))
+		for i, column := range header {
+			if i < len(record) {
+				values[strings.TrimSpace(column)] = record[i]
+			}
+		}
+		rows = append(rows, row{line: line, values: values})
+	}
+}
+
+func readJSON(r io.Reader) ([]row, error) {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+	if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+		return nil, fmt.Errorf("json import must be an array of objects")
+	}
+	var rows []row
+	for decoder.More() {
+		var object map[string]interface{}
+		if err := decoder.Decode(&object); err != nil {
+			return nil, fmt.Errorf("item %d: %w", len(rows)+1, err)
+		}
+		rows = append(rows, row{line: len(rows) + 1, values: stringify(object)})
+	}
+	return rows, nil
+}
+
+func readNDJSON(r io.Reader) ([]row, error) {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+	var rows []row
+	for line := 1; ; line++ {
+		var object map[string]interface{}
+		err := decoder.Decode(&object)
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		rows = append(rows, row{line: line, values: stringify(object)})
+	}
+}
+
+// readTodoist reads the CSV export of a Todoist project, only the rows of TYPE task are tasks. Todoist ranks its
+// priorities from 1 for the most urgent to 4, they are turned around so that a higher priority is more urgent. Its
+// DATE is written the way it was typed, see todoistDate.
+func readTodoist(r io.Reader) ([]row, error) {
+	rows, err := readCSV(r)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	tasks := rows[:0]
+	for _, row := range rows {
+		if row.values["TYPE"] != "task" {
+			continue
+		}
+		if priority, err := strconv.Atoi(row.values["PRIORITY"]); err == nil && priority >= 1 && priority <= 4 {
+			row.values["PRIORITY"] = strconv.Itoa(5 - priority)
+		}
+		if date := strings.TrimSpace(row.values["DATE"]); date != "" {
+			due, recurrence, ok := todoistDate(date, now)
+			if !ok {
+				row.warnings = append(row.warnings, fmt.Sprintf("DATE %q is not a date, the task has no due date", date))
+			}
+			row.values["DATE"], row.values["RECURRENCE"] = due, recurrence
+		}
+		tasks = append(tasks, row)
+	}
+	return tasks, nil
+}
+
+// todoistDate reads a Todoist DATE, a date such as 2026-10-20 or one in words such as "Oct 20" or "every day" read
+// like a quick add from now, into a due date and a recurrence. It is not ok when words are left over.
+func todoistDate(date string, now time.Time) (due string, recurrence string, ok bool) {
+	for _, layout := range []string{time.DateOnly, time.RFC3339, "2006-01-02T15:04:05", "2006-01-02T15:04"} {
+		if _, err := time.Parse(layout, date); err == nil {
+			return date, "", true
+		}
+	}
+	task := quickadd.Parse(date, now)
+	if task.Due == nil || task.Title != "" {
+		return "", "", false
+	}
+	if task.HasTime {
+		return task.Due.Format("2006-01-02T15:04"), task.Recurrence, true
+	}
+	return task.Due.Format(time.DateOnly), task.Recurrence, true
+}
+
+// readTrello reads the JSON export of a Trello board, every open card is a task. Its list shows as the column list.
+func readTrello(r io.Reader) ([]row, error) {
+	var board struct {
+		Lists []struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"lists"`
+		Cards []struct {
+			Name        string  `json:"name"`
+			Desc        string  `json:"desc"`
+			Due         *string `json:"due"`
+			DueComplete bool    `json:"dueComplete"`
+			Closed      bool    `json:"closed"`
+			IDList      string  `json:"idList"`
+		} `json:"cards"`
+	}
+	if err := json.NewDecoder(r).Decode(&board); err != nil {
+		return nil, fmt.Errorf("trello import: %w", err)
+	}
+	lists := make(map[string]string, len(board.Lists))
+	for _, list := range board.Lists {
+		lists[list.ID] = list.Name
+	}
+	var rows []row
+	for i, card := range board.Cards {
+		if card.Closed {
+			continue
+		}
+		values := map[string]string{
+			"name":        card.Name,
+			"desc":        card.Desc,
+			"dueComplete": strconv.FormatBool(card.DueComplete),
+			"list":        lists[card.IDList],
+		}
+		if card.Due != nil {
+			values["due"] = *card.Due
+		}
+		rows = append(rows, row{line: i + 1, values: values})
+	}
+	return rows, nil
+}
+
+// readICS reads the VTODO of an iCalendar file, Line is where each of them begins. The due date is read as a day or
+// as an RFC 3339 time with the TZID it was given in, from DTSTART when there is no DUE, and the 1 (highest) to 9
+// priorities of iCalendar are turned around like those of Todoist.
+func readICS(r io.Reader) ([]row, error) {
+	calendar, err := ical.Decode(r)
+	if err != nil {
+		return nil, fmt.Errorf("ics import: %w", err)
+	}
+	var rows []row
+	for _, todo := range calendar.Find("VTODO") {
+		values := map[string]string{
+			"UID":         todo.Text("UID"),
+			"SUMMARY":     todo.Text("SUMMARY"),
+			"DESCRIPTION": todo.Text("DESCRIPTION"),
+			"COMPLETED":   strconv.FormatBool(todo.Text("STATUS") == "COMPLETED"),
+		}
+		due, ok := todo.Get("DUE")
+		if !ok {
+			due, ok = todo.Get("DTSTART")
+		}
+		if ok {
+			moment, err := due.Time()
+			switch {
+			case err != nil:
+				values["DUE"] = due.Value
+			case due.Params["VALUE"] == "DATE" || len(due.Value) == len("20060102"):
+				values["DUE"] = moment.Format("2006-01-02")
+			default:
+				values["DUE"] = moment.Format(time.RFC3339)
+				if moment.Location() != time.UTC {
+					values["TZID"] = due.Params["TZID"]
+				}
+			}
+		}
+		if priority, err := strconv.Atoi(todo.Text("PRIORITY")); err == nil && priority >= 1 && priority <= 9 {
+			values["PRIORITY"] = strconv.Itoa(10 - priority)
+		}
+		if rule, ok := todo.Get("RRULE"); ok {
+			values["RRULE"] = rule.Value
+		}
+		rows = append(rows, row{line: todo.Line, values: values})
+	}
+	return rows, nil
+}
+
+// stringify turns the values of a JSON object into the text a CSV cell would hold, null becomes empty.
+func stringify(object map[string]interface{}) map[string]string {
+	values := make(map[string]string, len(object))
+	for key, value := range object {
+		values[key] = text(value)
+	}
+	return values
+}
+
+func text(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case json.Number:
+		return v.String()
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		raw, _ := json.Marshal(v)
+		return string(raw)
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Writer streams tasks as CSV, a JSON array or NDJSON. Close must be called once every task was written.
+type Writer struct {
+	format  string
+	out     io.Writer
+	csv     *csv.Writer
+	columns []string
+	count   int
+}
+
+// NewWriter writes tasks to out in format, columns are the fields written and their order in CSV.
+func NewWriter(format string, out io.Writer, columns []string) (*Writer, error) {
+	writer := &Writer{format: format, out: out, columns: columns}
+	switch format {
+	case FormatCSV:
+		writer.csv = csv.NewWriter(out)
+		return writer, writer.csv.Write(columns)
+	case FormatJSON:
+		_, err := io.WriteString(out, "[")
+		return writer, err
+	case FormatNDJSON:
+		return writer, nil
+	default:
+		return nil, fmt.Errorf("export format %s is not supported", format)
+	}
+}
+
+// Write writes one task, given as the fields of its JSON form.
+func (w *Writer) Write(task map[string]interface{}) error {
+	w.count++
+	switch w.format {
+	case FormatCSV:
+		record := make([]string, len(w.columns))
+		for i, column := range w.columns {
+			record[i] = text(task[column])
+		}
+		return w.csv.Write(record)
+	case FormatJSON:
+		if w.count > 1 {
+			if _, err := io.WriteString(w.out, ","); err != nil {
+				return err
+			}
+		}
+		return json.NewEncoder(w.out).Encode(w.pick(task))
+	default:
+		return json.NewEncoder(w.out).Encode(w.pick(task))
+	}
+}
+
+// Flush pushes the tasks written so far to the underlying writer.
+func (w *Writer) Flush() error {
+	if w.csv != nil {
+		w.csv.Flush()
+		return w.csv.Error()
+	}
+	return nil
+}
+
+func (w *Writer) Close() error {
+	if w.format == FormatJSON {
+		if _, err := io.WriteString(w.out, "]\n"); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+func (w *Writer) pick(task map[string]interface{}) map[string]interface{} {
+	picked := make(map[string]interface{}, len(w.columns))
+	for _, column := range w.columns {
+		picked[column] = task[column]
+	}
+	return picked
+}
@@ -0,0 +1,46 @@
+package test
+
+import (
+	"go_gin/internal/domain/model"
+	"testing"
+)
+
+func TestPlaceCards(t *testing.T) {
+	todo, done := model.StatusTodo, model.StatusDone
+	lane := 3
+	columns := model.BoardColumns{
+		{ColumnID: 1, Status: &todo},
+		{ColumnID: 2, Status: &done},
+		{ColumnID: 3},
+		{ColumnID: 4, Status: &todo},
+	}
+	doneColumn := 2
+	todolists := model.TodoLists{
+		{TaskID: 10, Status: model.StatusTodo},
+		{TaskID: 11, Status: model.StatusDone, ColumnID: &lane},
+		{TaskID: 12, Status: model.StatusTodo, ColumnID: &doneColumn},
+		{TaskID: 13, Status: model.StatusBlocked},
+		{TaskID: 14, Status: model.StatusTodo, ColumnID: &lane},
+	}
+	cards, unplaced := model.PlaceCards(columns, todolists)
+	ids := func(todolists model.TodoLists) []int {
+		var ids []int
+		for _, todolist := range todolists {
+			ids = append(ids, todolist.TaskID)
+		}
+		return ids
+	}
+	// a card moved to a column of another status falls back to the first column of its own status
+	if got := ids(cards[1]); len(got) != 2 || got[0] != 10 || got[1] != 12 {
+		t.Errorf("todo column = %v", got)
+	}
+	if got := ids(cards[3]); len(got) != 2 || got[0] != 11 || got[1] != 14 {
+		t.Errorf("custom lane = %v", got)
+	}
+	if len(cards[2]) != 0 || len(cards[4]) != 0 {
+		t.Errorf("done = %v, second todo = %v", ids(cards[2]), ids(cards[4]))
+	}
+	if got := ids(unplaced); len(got) != 1 || got[0] != 13 {
+		t.Errorf("unplaced = %v", got)
+	}
+}
@@ -0,0 +1,34 @@
+package test
+
+import (
+	"go_gin/internal/domain/model"
+	"testing"
+)
+
+func TestTodoListPatchApply(t *testing.T) {
+	current := model.TodoList{TaskID: 1, Priority: 2, Status: "in_progress"}
+	if !(model.TodoListPatch{}).IsEmpty() {
+		t.Fatal("empty patch is not empty")
+	}
+
+	down := -5
+	patched, status, completed := model.TodoListPatch{PriorityBy: &down}.Apply(current)
+	if patched.Priority != 1 || status != "in_progress" || completed {
+		t.Errorf("priority_by: got priority %v status %v completed %v", patched.Priority, status, completed)
+	}
+	if current.Priority != 2 {
+		t.Errorf("apply changed the current todolist")
+	}
+
+	done := true
+	_, status, completed = model.TodoListPatch{Completed: &done}.Apply(current)
+	if status != "" || !completed {
+		t.Errorf("completed: got status %q completed %v, want the workflow to resolve it", status, completed)
+	}
+
+	project := 7
+	patched, _, _ = model.TodoListPatch{ProjectID: &project, DueDate: &model.Date{Year: 2026, Month: 11, Day: 2}}.Apply(current)
+	if patched.ProjectID == nil || *patched.ProjectID != 7 || patched.DueDate.Format("2006-01-02") != "2026-11-02" {
+		t.Errorf("project and due date: got %v %v", patched.ProjectID, patched.DueDate)
+	}
+}
@@ -0,0 +1,79 @@
+package test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"go_gin/internal/domain/model"
+	"go_gin/pkg/dav"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDAVMultistatus(t *testing.T) {
+	body := `<?xml version="1.0"?>
+<d:propfind xmlns:d="DAV:" xmlns:cs="http://calendarserver.org/ns/">
+  <d:prop><d:getetag/><cs:getctag/><d:quota-used-bytes/></d:prop>
+</d:propfind>`
+	request, err := dav.Decode(strings.NewReader(body))
+	if err != nil || !request.Is(dav.NamespaceDAV, "propfind") {
+		t.Fatalf("%+v, %v", request, err)
+	}
+	requested := dav.Requested(request)
+	if len(requested) != 3 {
+		t.Fatalf("requested %+v", requested)
+	}
+	response := dav.NewResponse("/dav/u/todolist/a&b.ics", requested, []dav.Element{
+		dav.NewText(dav.NamespaceDAV, "getetag", `"3"`),
+		dav.NewText(dav.NamespaceCalendarServer, "getctag", "urn:todolist:sync:1"),
+		dav.NewText(dav.NamespaceDAV, "displayname", "not asked"),
+	})
+	if len(response.Found) != 2 || len(response.NotFound) != 1 || response.NotFound[0].Local != "quota-used-bytes" {
+		t.Errorf("response %+v", response)
+	}
+
+	var out bytes.Buffer
+	multistatus := dav.Multistatus{Responses: []dav.Response{response, {Href: "/dav/u/todolist/gone.ics", Status: http.StatusNotFound}}, SyncToken: "urn:todolist:sync:2"}
+	if err := multistatus.Encode(&out); err != nil {
+		t.Fatal(err)
+	}
+	var parsed struct {
+		Responses []struct {
+			Href     string   `xml:"href"`
+			Status   string   `xml:"status"`
+			Statuses []string `xml:"propstat>status"`
+		} `xml:"DAV: response"`
+		SyncToken string `xml:"DAV: sync-token"`
+	}
+	if err := xml.Unmarshal(out.Bytes(), &parsed); err != nil {
+		t.Fatalf("%v\n%s", err, out.String())
+	}
+	if len(parsed.Responses) != 2 || parsed.Responses[0].Href != "/dav/u/todolist/a&b.ics" || len(parsed.Responses[0].Statuses) != 2 ||
+		parsed.Responses[1].Status != "HTTP/1.1 404 Not Found" || parsed.SyncToken != "urn:todolist:sync:2" {
+		t.Errorf("multistatus %+v\n%s", parsed, out.String())
+	}
+}
+
+func TestCalDAVNamesAndSyncTokens(t *testing.T) {
+	if id, ok := model.ParseCalDAVName(model.CalDAVName(42)); !ok || id != 42 {
+		t.Errorf("name of 42 reads as %v, %v", id, ok)
+	}
+	for _, name := range []string{"todolist-042.ics", "todolist-7", "3F2504E0.ics", "todolist--1.ics"} {
+		if _, ok := model.ParseCalDAVName(name); ok {
+			t.Errorf("%s reads as a todolist name", name)
+		}
+	}
+	takenAt := time.Date(2026, 10, 19, 11, 0, 0, 123456000, time.UTC)
+	for _, snapshot := range []string{"740:745:", "740:745:741,743"} {
+		at, read, err := model.ParseCalDAVSyncToken(model.CalDAVSyncToken(takenAt, snapshot))
+		if err != nil || !at.Equal(takenAt) || read != snapshot {
+			t.Errorf("sync token of %s reads as %v, %v, %v", snapshot, at, read, err)
+		}
+	}
+	for _, token := range []string{"http://example.com/sync/1", "urn:todolist:sync:1760871600000000", "urn:todolist:sync:1:740:745:'"} {
+		if _, _, err := model.ParseCalDAVSyncToken(token); err == nil {
+			t.Errorf("sync token %s is accepted", token)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package test
+
+import (
+	"go_gin/internal/domain/model/web"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	cursor := &web.Cursor{Values: []string{"3", "42"}, Prev: true, Sort: "-priority"}
+	token := web.EncodeCursor(cursor)
+	decoded, err := web.DecodeCursor(token, "-priority")
+	if err != nil || !reflect.DeepEqual(decoded, cursor) {
+		t.Fatalf("decoded = %v, %v", decoded, err)
+	}
+	if _, err := web.DecodeCursor(token, "priority"); err == nil {
+		t.Error("cursor of another sort should be refused")
+	}
+	payload, _, _ := strings.Cut(token, ".")
+	if _, err := web.DecodeCursor(payload+".forged", "-priority"); err == nil {
+		t.Error("cursor with a bad signature should be refused")
+	}
+}
+
+func TestPaginationLinks(t *testing.T) {
+	u, _ := url.Parse("/api/v1/user/1/todolists?page=2&limit=10&sort=priority")
+	pagination := web.NewPagination(2, 10, 35)
+	pagination.SetLinks(u)
+	if pagination.NextLink != "/api/v1/user/1/todolists?limit=10&page=3&sort=priority" {
+		t.Errorf("next = %s", pagination.NextLink)
+	}
+	if pagination.LinkHeader() != `<`+pagination.NextLink+`>; rel="next", <`+pagination.PrevLink+`>; rel="prev"` {
+		t.Errorf("link = %s", pagination.LinkHeader())
+	}
+	last := web.NewPagination(4, 10, 35)
+	last.SetLinks(u)
+	if last.NextLink != "" {
+		t.Errorf("last page should have no next link, got %s", last.NextLink)
+	}
+}
@@ -0,0 +1,76 @@
+package test
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"go_gin/internal/domain/model"
+	"go_gin/internal/domain/model/web"
+	"go_gin/pkg/taskio"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTodoListRequestDueDate(t *testing.T) {
+	cases := []struct {
+		body     string
+		expected string
+		timed    bool
+		timezone string
+	}{
+		{`{"due_date": {"year": 2026, "month": 11, "day": 2}}`, "2026-11-02T00:00:00Z", false, ""},
+		{`{"due_date": "2026-11-02", "due_timezone": "Europe/Paris"}`, "2026-11-02T00:00:00Z", false, ""},
+		{`{"due_date": {"year": 2026, "month": 11, "day": 2, "time": "17:30"}, "due_timezone": "Europe/Paris"}`, "2026-11-02T17:30:00+01:00", true, "Europe/Paris"},
+		{`{"due_date": "2026-10-20T09:00", "due_timezone": "America/New_York"}`, "2026-10-20T09:00:00-04:00", true, "America/New_York"},
+		{`{"due_date": "2026-11-02T17:30:00+09:00"}`, "2026-11-02T08:30:00Z", true, ""},
+	}
+	for _, c := range cases {
+		var request model.TodoListRequest
+		if err := json.Unmarshal([]byte(c.body), &request); err != nil {
+			t.Fatalf("%s: %v", c.body, err)
+		}
+		todolist := request.ToTodoList(uuid.Nil)
+		response := todolist.ToTodoListResponse()
+		if got := response.DueDate.Format(time.RFC3339); got != c.expected || todolist.DueTimed != c.timed || todolist.DueTimezone != c.timezone {
+			t.Errorf("%s: got %v timed %v in %q", c.body, got, todolist.DueTimed, todolist.DueTimezone)
+		}
+	}
+
+	var request model.TodoListRequest
+	if err := json.Unmarshal([]byte(`{"due_date": "next tuesday"}`), &request); err == nil {
+		t.Errorf("a due date that is no date was read")
+	}
+}
+
+func TestTimedDueDateInCalendar(t *testing.T) {
+	due := time.Date(2026, 11, 2, 16, 30, 0, 0, time.UTC)
+	todolist := model.TodoList{TaskID: 3, TaskName: "Call the bank", DueDate: &due, DueTimed: true, DueTimezone: "Europe/Paris"}
+	todo := todolist.ToVTodo("todo.example.com", due)
+	if property, _ := todo.Get("DUE"); property.Value != "20261102T163000Z" || len(property.Params) != 0 {
+		t.Errorf("vtodo due: got %v %v", property.Value, property.Params)
+	}
+	event := todolist.ToVEvent("todo.example.com", due)
+	if _, ok := event.Get("DTEND"); ok {
+		t.Errorf("a timed due date has no end")
+	}
+
+	ics := "BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\nSUMMARY:Call the bank\r\nDUE;TZID=Europe/Paris:20261102T173000\r\nEND:VTODO\r\nEND:VCALENDAR\r\n"
+	records, err := taskio.Read(taskio.FormatICS, strings.NewReader(ics), nil, web.TodoListImportFields)
+	if err != nil || len(records) != 1 {
+		t.Fatalf("ics import: %v %v", records, err)
+	}
+	request, err := model.NewTodoListImportRequest(records[0].Fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	imported := request.ToTodoList(uuid.Nil)
+	if !imported.DueDate.Equal(due) || !imported.DueTimed || imported.DueTimezone != "Europe/Paris" {
+		t.Errorf("ics import: got %v timed %v in %q", imported.DueDate, imported.DueTimed, imported.DueTimezone)
+	}
+
+	// a whole day exported as its midnight in UTC comes back as a day
+	request, err = model.NewTodoListImportRequest(map[string]string{"task_name": "Renew passport", "due_date": "2026-11-03T00:00:00Z", "due_timed": "false"})
+	if err != nil || request.DueDate.Time != "" || request.ToTodoList(uuid.Nil).DueTimed {
+		t.Errorf("exported day: got %+v %v", request.DueDate, err)
+	}
+}
@@ -0,0 +1,32 @@
+package test
+
+import (
+	"go_gin/internal/domain/model/web"
+	"testing"
+)
+
+func TestMatchETag(t *testing.T) {
+	if etag := web.ETag(3); etag != `"3"` {
+		t.Fatalf("etag %s, want \"3\"", etag)
+	}
+	cases := []struct {
+		header string
+		strong bool
+		weak   bool
+	}{
+		{`"3"`, true, true},
+		{`"2"`, false, false},
+		{`"1", "3"`, true, true},
+		{`W/"3"`, false, true},
+		{`*`, true, true},
+		{`3`, false, false},
+	}
+	for _, c := range cases {
+		if got := web.MatchETag(c.header, 3); got != c.strong {
+			t.Errorf("MatchETag(%s, 3) = %v, want %v", c.header, got, c.strong)
+		}
+		if got := web.MatchWeakETag(c.header, 3); got != c.weak {
+			t.Errorf("MatchWeakETag(%s, 3) = %v, want %v", c.header, got, c.weak)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package test
+
+import (
+	"go_gin/internal/domain/model/web"
+	"reflect"
+	"testing"
+)
+
+func TestParseSort(t *testing.T) {
+	keys, err := web.ParseSort("-priority, due_date", web.TodoListSortColumns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []web.SortKey{{Column: "priority", Desc: true}, {Column: "due_date"}}
+	if !reflect.DeepEqual(keys, expected) {
+		t.Errorf("keys = %v", keys)
+	}
+	for _, sort := range []string{"password", "priority,-priority", "-"} {
+		if _, err := web.ParseSort(sort, web.TodoListSortColumns); err == nil {
+			t.Errorf("sort %q should be refused", sort)
+		}
+	}
+}
+
+func TestParseFields(t *testing.T) {
+	fields, err := web.ParseFields("task_name,due_date", web.TodoListFields)
+	if err != nil || !reflect.DeepEqual(fields, []string{"task_name", "due_date"}) {
+		t.Errorf("fields = %v, %v", fields, err)
+	}
+	if _, err := web.ParseFields("user", web.TodoListFields); err == nil {
+		t.Error("unknown field should be refused")
+	}
+}
+
+func TestPrefixTsQuery(t *testing.T) {
+	cases := map[string]string{
+		"Meet notes":         "meet:* & notes:*",
+		"  rapat' & | !(x) ": "rapat:* & x:*",
+		"":                   "",
+		"***":                "",
+	}
+	for search, expected := range cases {
+		if got := web.PrefixTsQuery(search); got != expected {
+			t.Errorf("PrefixTsQuery(%q) = %q, want %q", search, got, expected)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package test
+
+import (
+	"go_gin/pkg/fractional"
+	"sort"
+	"testing"
+)
+
+func TestFractionalBetween(t *testing.T) {
+	first, _ := fractional.Between("", "")
+	second, _ := fractional.Between(first, "")
+	keys := []string{first, second}
+	// insert repeatedly at the front, the back and just after the first key
+	for i := 0; i < 200; i++ {
+		front, err := fractional.Between("", keys[0])
+		if err != nil {
+			t.Fatalf("front: %v", err)
+		}
+		back, err := fractional.Between(keys[len(keys)-1], "")
+		if err != nil {
+			t.Fatalf("back: %v", err)
+		}
+		middle, err := fractional.Between(keys[0], keys[1])
+		if err != nil {
+			t.Fatalf("middle: %v", err)
+		}
+		keys = append([]string{front, keys[0], middle}, append(keys[1:], back)...)
+		if !sort.StringsAreSorted(keys) {
+			t.Fatalf("keys out of order after %d inserts: %v", i, keys)
+		}
+		for _, key := range keys {
+			if !fractional.Valid(key) {
+				t.Fatalf("invalid key %q", key)
+			}
+		}
+	}
+	for i := 1; i < len(keys); i++ {
+		if keys[i-1] == keys[i] {
+			t.Fatalf("duplicate key %q", keys[i])
+		}
+	}
+	if _, err := fractional.Between("b", "a"); err != fractional.ErrOrder {
+		t.Errorf("expected ErrOrder, got %v", err)
+	}
+	if _, err := fractional.Between("a0", ""); err != fractional.ErrInvalidKey {
+		t.Errorf("expected ErrInvalidKey, got %v", err)
+	}
+}
+
+func TestFractionalSpread(t *testing.T) {
+	for _, n := range []int{1, 61, 62, 1000} {
+		keys := fractional.Spread(n)
+		if len(keys) != n || !sort.StringsAreSorted(keys) {
+			t.Fatalf("spread(%d) = %v", n, keys)
+		}
+		for i, key := range keys {
+			if !fractional.Valid(key) || (i > 0 && keys[i-1] == key) {
+				t.Fatalf("spread(%d): bad key %q", n, key)
+			}
+		}
+	}
+}
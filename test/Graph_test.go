@@ -0,0 +1,34 @@
+package test
+
+import (
+	"go_gin/pkg/graph"
+	"reflect"
+	"testing"
+)
+
+func TestTopologicalSort(t *testing.T) {
+	edges := []graph.Edge{{From: 3, To: 1}, {From: 1, To: 2}, {From: 3, To: 4}}
+	order, err := graph.TopologicalSort([]int{1, 2, 3, 4}, edges)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(order, []int{3, 1, 2, 4}) {
+		t.Errorf("order = %v", order)
+	}
+	_, err = graph.TopologicalSort([]int{1, 2}, []graph.Edge{{From: 1, To: 2}, {From: 2, To: 1}})
+	if err != graph.ErrCycle {
+		t.Errorf("cycle error = %v", err)
+	}
+}
+
+func TestCriticalPath(t *testing.T) {
+	edges := []graph.Edge{{From: 1, To: 2}, {From: 1, To: 3}, {From: 2, To: 4}, {From: 3, To: 4}}
+	order, err := graph.TopologicalSort([]int{1, 2, 3, 4}, edges)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path, total := graph.CriticalPath(order, edges, map[int]float64{1: 2, 2: 1, 3: 5, 4: 1})
+	if !reflect.DeepEqual(path, []int{1, 3, 4}) || total != 8 {
+		t.Errorf("critical path = %v (%v)", path, total)
+	}
+}
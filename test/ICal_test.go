@@ -0,0 +1,96 @@
+package test
+
+import (
+	"bytes"
+	"go_gin/internal/domain/model"
+	"go_gin/internal/domain/model/web"
+	"go_gin/pkg/ical"
+	"go_gin/pkg/taskio"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestICalFeedRoundTrip(t *testing.T) {
+	due := time.Date(2026, 11, 2, 0, 0, 0, 0, time.UTC)
+	todolist := model.TodoList{
+		TaskID:      7,
+		TaskName:    "Pay rent; flat, " + strings.Repeat("é", 60),
+		Description: "line one\nline two",
+		Priority:    8,
+		DueDate:     &due,
+		Recurrence:  "FREQ=MONTHLY;BYMONTHDAY=2",
+	}
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	calendar := ical.Component{Name: "VCALENDAR"}
+	calendar.Add("VERSION", "2.0")
+	calendar.Components = append(calendar.Components, todolist.ToVTodo("example.com", now), todolist.ToVEvent("example.com", now))
+	var feed bytes.Buffer
+	if err := ical.Encode(&feed, calendar); err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(feed.String(), "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line of %d octets is not folded: %q", len(line), line)
+		}
+	}
+
+	decoded, err := ical.Decode(&feed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	todos, events := decoded.Find("VTODO"), decoded.Find("VEVENT")
+	if len(todos) != 1 || len(events) != 1 {
+		t.Fatalf("%d todos, %d events", len(todos), len(events))
+	}
+	if todos[0].Text("SUMMARY") != todolist.TaskName || todos[0].Text("DESCRIPTION") != todolist.Description {
+		t.Errorf("text is not escaped back: %q %q", todos[0].Text("SUMMARY"), todos[0].Text("DESCRIPTION"))
+	}
+	if todos[0].Text("PRIORITY") != "2" || todos[0].Text("STATUS") != "NEEDS-ACTION" || todos[0].Text("RRULE") != todolist.Recurrence {
+		t.Errorf("vtodo %+v", todos[0].Properties)
+	}
+	if start, _ := events[0].Get("DTSTART"); start.Value != "20261102" || start.Params["VALUE"] != "DATE" {
+		t.Errorf("vevent start %+v", start)
+	}
+}
+
+func TestICalImport(t *testing.T) {
+	ics := "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nBEGIN:VTODO\r\nUID:1\r\nSUMMARY:Renew passport\\, urgently\r\nDUE;TZID=Europe/Paris:20261103T090000\r\n" +
+		"PRIORITY:1\r\nSTATUS:COMPLETED\r\nRRULE:FREQ=YEARLY\r\nEND:VTODO\r\nBEGIN:VEVENT\r\nSUMMARY:Not a task\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"
+	records, err := taskio.Read(taskio.FormatICS, strings.NewReader(ics), taskio.Mapping{}, web.TodoListImportFields)
+	if err != nil || len(records) != 1 || records[0].Line != 3 {
+		t.Fatalf("ics: %+v, %v", records, err)
+	}
+	request, err := model.NewTodoListImportRequest(records[0].Fields)
+	if err != nil || request.TaskName != "Renew passport, urgently" || request.Priority != 9 || !request.Completed || request.Recurrence != "FREQ=YEARLY" || request.DueDate == nil || request.DueDate.Day != 3 {
+		t.Errorf("ics request %+v, %v", request, err)
+	}
+
+	if _, err := ical.Decode(strings.NewReader("BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\nEND:VCALENDAR\r\n")); err == nil {
+		t.Error("unbalanced calendar is decoded")
+	}
+}
+
+func TestICalValidateRecurrence(t *testing.T) {
+	for rule, valid := range map[string]bool{
+		"FREQ=WEEKLY;BYDAY=MO,WE":                        true,
+		"FREQ=DAILY;COUNT=10":                            true,
+		"INTERVAL=2":                                     false,
+		"FREQ=HOURLY":                                    false,
+		"FREQ=WEEKLY;BYHOUR=9":                           false,
+		"FREQ=MONTHLY;UNTIL=":                            false,
+		"FREQ=YEARLY;UNTIL=20271231":                     true,
+		"FREQ=MONTHLY;BYDAY=-1FR;UNTIL=20271231T235959Z": true,
+		"FREQ=MONTHLY;BYMONTHDAY=-1,15;BYSETPOS=1":       true,
+		"FREQ=DAILY;INTERVAL=0":                          false,
+		"FREQ=WEEKLY;BYDAY=XX":                           false,
+		"FREQ=YEARLY;UNTIL=tomorrow":                     false,
+		"FREQ=DAILY;FREQ=WEEKLY":                         false,
+		"INTERVAL=1\r\nATTACH:https://evil.example/x\r\nX-A=b;FREQ=DAILY": false,
+		"FREQ=DAILY;WKST=MO\nATTACH:https://evil.example/x":               false,
+	} {
+		if err := ical.ValidateRecurrence(rule); (err == nil) != valid {
+			t.Errorf("%s: %v", rule, err)
+		}
+	}
+}
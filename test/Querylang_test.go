@@ -0,0 +1,94 @@
+package test
+
+import (
+	"errors"
+	"go_gin/internal/domain/model/web"
+	"go_gin/pkg/querylang"
+	"testing"
+	"time"
+)
+
+func TestParseQuery(t *testing.T) {
+	node, err := querylang.Parse(`priority>=3 due<2026-11-01 tag:work -completed "quarterly report"`, web.TodoListQueryFields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	and, ok := node.(*querylang.And)
+	if !ok || len(and.Nodes) != 5 {
+		t.Fatalf("node = %#v", node)
+	}
+	if c := and.Nodes[0].(*querylang.Compare); c.Field != "priority" || c.Op != querylang.OpGe || c.Value != 3 {
+		t.Errorf("priority = %#v", c)
+	}
+	if c := and.Nodes[1].(*querylang.Compare); c.Op != querylang.OpLt || !c.Value.(time.Time).Equal(time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("due = %#v", c)
+	}
+	if c := and.Nodes[2].(*querylang.Compare); c.Field != "tag" || c.Value != "work" {
+		t.Errorf("tag = %#v", c)
+	}
+	if not := and.Nodes[3].(*querylang.Not); not.Node.(*querylang.Compare).Field != "completed" {
+		t.Errorf("not = %#v", not)
+	}
+	if text := and.Nodes[4].(*querylang.Text); text.Value != "quarterly report" || text.Pos != 48 {
+		t.Errorf("text = %#v", text)
+	}
+
+	node, err = querylang.Parse("status:todo OR (tag:home priority>1)", web.TodoListQueryFields)
+	if or, ok := node.(*querylang.Or); err != nil || !ok || len(or.Nodes) != 2 {
+		t.Errorf("or = %#v, %v", node, err)
+	}
+
+	node, err = querylang.Parse("priority:-1 -2", web.TodoListQueryFields)
+	if and, ok := node.(*querylang.And); err != nil || !ok || and.Nodes[0].(*querylang.Compare).Value != -1 || and.Nodes[1].(*querylang.Not).Node.(*querylang.Text).Value != "2" {
+		t.Errorf("negative number = %#v, %v", node, err)
+	}
+}
+
+func TestParseQueryErrors(t *testing.T) {
+	cases := map[string]int{
+		"priority>=high":    11,
+		"owner:me":          1,
+		"tag:work (due<":    15,
+		`"quarterly report`: 1,
+		"status>todo":       7,
+		"due:2026-13-01":    5,
+		"a OR":              5,
+	}
+	for query, position := range cases {
+		_, err := querylang.Parse(query, web.TodoListQueryFields)
+		syntaxError := &querylang.SyntaxError{}
+		if !errors.As(err, &syntaxError) {
+			t.Errorf("%q: expected a syntax error, got %v", query, err)
+			continue
+		}
+		if syntaxError.Pos != position {
+			t.Errorf("%q: position = %d, want %d (%s)", query, syntaxError.Pos, position, syntaxError.Msg)
+		}
+	}
+}
+
+func TestParseRelativeDate(t *testing.T) {
+	node, err := querylang.Parse("due<today+7", web.TodoListQueryFields)
+	compare, ok := node.(*querylang.Compare)
+	if err != nil || !ok {
+		t.Fatalf("node = %#v, %v", node, err)
+	}
+	now := time.Now()
+	want := time.Date(now.Year(), now.Month(), now.Day()+7, 0, 0, 0, 0, time.UTC)
+	if !compare.Value.(time.Time).Equal(want) {
+		t.Errorf("due = %v, want %v", compare.Value, want)
+	}
+	if _, err := querylang.Parse("due<today*7", web.TodoListQueryFields); err == nil {
+		t.Error("today*7 should be refused")
+	}
+}
+
+func TestViewGroupSort(t *testing.T) {
+	query := &web.ViewTodoListsQuery{ViewID: "1"}
+	if sort := query.ToTodoListsQuery("", "due_date,-priority", "priority").Sort; sort != "-priority,due_date" {
+		t.Errorf("sort = %s", sort)
+	}
+	if sort := query.ToTodoListsQuery("", "due_date", "status").Sort; sort != "status,due_date" {
+		t.Errorf("sort = %s", sort)
+	}
+}
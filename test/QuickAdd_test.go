@@ -0,0 +1,62 @@
+package test
+
+import (
+	"go_gin/internal/domain/model"
+	"go_gin/pkg/quickadd"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestQuickAddParse(t *testing.T) {
+	zone := time.FixedZone("UTC+2", 2*60*60)
+	// a Monday
+	now := time.Date(2026, 10, 19, 10, 0, 0, 0, zone)
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2026, month, d, 0, 0, 0, 0, zone)
+	}
+	for _, tc := range []struct {
+		text string
+		want quickadd.Task
+	}{
+		{"Pay rent every 1st of month !high #home", quickadd.Task{Title: "Pay rent", Priority: 3, Tags: []string{"home"}, Recurrence: "FREQ=MONTHLY;BYMONTHDAY=1"}},
+		{"Call Anna tomorrow at 5pm", quickadd.Task{Title: "Call Anna", HasTime: true}},
+		{"Standup every weekday at 9:30 p2", quickadd.Task{Title: "Standup", HasTime: true, Priority: 3, Recurrence: "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR"}},
+		{"Water plants every other week on thursday", quickadd.Task{Title: "Water plants", Recurrence: "FREQ=WEEKLY;INTERVAL=2;BYDAY=TH"}},
+		{"Renew passport on 5th of march #admin #Admin", quickadd.Task{Title: "Renew passport", Tags: []string{"admin", "Admin"}}},
+		{"Report due friday !!", quickadd.Task{Title: "Report", Priority: 2}},
+		{"Check in on Bob in 3 days", quickadd.Task{Title: "Check in on Bob"}},
+		{"Buy 2 apples", quickadd.Task{Title: "Buy 2 apples"}},
+		{"Party every 31st of february", quickadd.Task{Title: "Party every 31st of february"}},
+		{"Close books every 29th of february", quickadd.Task{Title: "Close books", Recurrence: "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=29"}},
+	} {
+		got := quickadd.Parse(tc.text, now)
+		got.Due = nil
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%q: got %+v, want %+v", tc.text, got, tc.want)
+		}
+	}
+
+	for text, want := range map[string]time.Time{
+		"Pay rent every 1st of month":    day(time.November, 1),
+		"Call Anna tomorrow at 5pm":      time.Date(2026, 10, 20, 17, 0, 0, 0, zone),
+		"Standup every weekday at 9:30":  time.Date(2026, 10, 19, 9, 30, 0, 0, zone),
+		"Report due friday":              day(time.October, 23),
+		"Gym monday":                     day(time.October, 26),
+		"Renew passport on 5th of march": day(time.March, 5).AddDate(1, 0, 0),
+		"Ship 2026-12-24":                day(time.December, 24),
+		"Check in on Bob in 3 days":      day(time.October, 22),
+		"Lunch at noon":                  time.Date(2026, 10, 19, 12, 0, 0, 0, zone),
+		"Breakfast at 8":                 time.Date(2026, 10, 20, 8, 0, 0, 0, zone),
+	} {
+		task := quickadd.Parse(text, now)
+		if task.Due == nil || !task.Due.Equal(want) {
+			t.Errorf("%q is due %v, want %v", text, task.Due, want)
+		}
+	}
+
+	request := model.NewQuickAddTodoListRequest(quickadd.Parse("Buy 2 apples", now), now)
+	if request.DueDate == nil || request.DueDate.Day != 19 || request.Priority != 1 || request.Description != "Buy 2 apples" {
+		t.Errorf("undated request %+v", request)
+	}
+}
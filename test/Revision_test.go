@@ -0,0 +1,44 @@
+package test
+
+import (
+	"go_gin/internal/domain/model"
+	"testing"
+	"time"
+)
+
+func TestTodoListRevisionHistory(t *testing.T) {
+	due := time.Date(2026, time.October, 20, 0, 0, 0, 0, time.UTC)
+	later := due.AddDate(0, 0, 3)
+	first := model.TodoList{TaskID: 4, TaskName: "draft", Description: "write it", DueDate: &due, Priority: 1, Status: "todo"}
+	second := first
+	second.TaskName, second.DueDate = "final draft", &later
+	third := second
+	third.Status, third.Completed = "done", true
+
+	changes := model.DiffRevision(first, second)
+	if len(changes) != 2 || changes[0].Field != "task_name" || changes[1] != (model.RevisionChange{Field: "due_date", OldValue: "2026-10-20", NewValue: "2026-10-23"}) {
+		t.Fatalf("changes = %+v", changes)
+	}
+	if changes := model.DiffRevision(second, second); len(changes) != 0 {
+		t.Errorf("no change should give no diff, got %+v", changes)
+	}
+
+	revisions := model.TodoListRevisions{
+		*model.NewTodoListRevision(first, 1, first.UserID, nil),
+		*model.NewTodoListRevision(second, 2, first.UserID, changes),
+		*model.NewTodoListRevision(third, 3, first.UserID, model.DiffRevision(second, third)),
+	}
+	if revisions[1].Changed != "task_name,due_date" {
+		t.Errorf("changed = %q", revisions[1].Changed)
+	}
+	responses := revisions.ToTodoListRevisionResponses()
+	if len(responses) != 3 || responses[0].Number != 3 || responses[2].Number != 1 {
+		t.Fatalf("responses should come newest first: %+v", responses)
+	}
+	if len(responses[0].Changes) != 2 || responses[0].Changes[0].Field != "completed" || responses[0].Changes[1].Field != "status" {
+		t.Errorf("latest changes = %+v", responses[0].Changes)
+	}
+	if len(responses[2].Changes) != 0 {
+		t.Errorf("the first revision has nothing to compare to, got %+v", responses[2].Changes)
+	}
+}
@@ -0,0 +1,56 @@
+package test
+
+import (
+	"go_gin/internal/domain/model"
+	"go_gin/internal/domain/model/web"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestStreaks(t *testing.T) {
+	days := []string{"2026-10-01", "2026-10-02", "2026-10-03", "2026-10-10", "2026-10-17", "2026-10-18"}
+	cases := []struct {
+		last             string
+		current, longest int
+	}{
+		{"2026-10-18", 2, 3},
+		{"2026-10-19", 2, 3}, // nothing completed yet today, the streak still holds
+		{"2026-10-20", 0, 3},
+		{"2026-10-03", 3, 3},
+	}
+	for _, c := range cases {
+		last, _ := time.Parse(time.DateOnly, c.last)
+		if current, longest := model.Streaks(days, last); current != c.current || longest != c.longest {
+			t.Errorf("last %v: got %v %v, expected %v %v", c.last, current, longest, c.current, c.longest)
+		}
+	}
+}
+
+func TestStatisticsResponse(t *testing.T) {
+	query, err := (&web.StatisticsQuery{From: "2026-10-14", To: "2026-10-27", Period: "week"}).ToValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	lead := 5400.0
+	totals := model.StatisticsTotals{Created: 4, CreatedCompleted: 1, Completed: 3, LeadSeconds: &lead, Overdue: 2}
+	created := model.StatisticsCounts{{Period: "2026-10-12", Count: 1}, {Period: "2026-10-19", Count: 3}}
+	completed := model.StatisticsCounts{{Period: "2026-10-26", Count: 3}}
+	now := time.Date(2026, 10, 19, 15, 0, 0, 0, time.UTC)
+	response := model.NewStatisticsResponse(*query, totals, created, completed, nil, []string{"2026-10-18"}, now)
+
+	series := model.StatisticsPeriods{{Period: "2026-10-12", Created: 1}, {Period: "2026-10-19", Created: 3}, {Period: "2026-10-26", Completed: 3}}
+	if !reflect.DeepEqual(response.Series, series) {
+		t.Errorf("series: got %+v", response.Series)
+	}
+	if response.To != "2026-10-27" || response.CompletionRate != 0.25 || *response.AverageLeadHours != 1.5 || response.CurrentStreak != 1 || response.Users != nil {
+		t.Errorf("response: got %+v", response)
+	}
+	if response.Priorities == nil {
+		t.Errorf("priorities must show as an empty list")
+	}
+
+	if _, err := (&web.StatisticsQuery{From: "2026-10-27", To: "2026-10-14"}).ToValue(); err == nil {
+		t.Errorf("a range ending before it starts was accepted")
+	}
+}
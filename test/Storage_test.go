@@ -0,0 +1,89 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"go_gin/pkg/storage"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeS3 is a minimal in memory stand-in for an S3 compatible server.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=access/") {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		f.objects[r.URL.Path] = body
+	case http.MethodGet:
+		body, ok := f.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if rng := r.Header.Get("Range"); rng != "" {
+			start, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(rng, "bytes="), "-"))
+			body = body[start:]
+			w.WriteHeader(http.StatusPartialContent)
+		}
+		w.Write(body)
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func testStorage(t *testing.T, store storage.Storage) {
+	ctx := context.Background()
+	content := []byte("hello attachment storage")
+	if err := store.Put(ctx, "todolist/1/file", bytes.NewReader(content), int64(len(content)), "text/plain"); err != nil {
+		t.Fatal(err)
+	}
+	reader, err := store.Open(ctx, "todolist/1/file", int64(len(content)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reader.Seek(6, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	rest, err := io.ReadAll(reader)
+	reader.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(rest) != "attachment storage" {
+		t.Errorf("read after seek = %q", rest)
+	}
+	if err := store.Delete(ctx, "todolist/1/file"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLocalStorage(t *testing.T) {
+	testStorage(t, storage.NewLocalStorage(t.TempDir()))
+}
+
+func TestS3Storage(t *testing.T) {
+	fake := &fakeS3{objects: map[string][]byte{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	testStorage(t, storage.NewS3Storage(server.URL, "us-east-1", "bucket", "access", "secret", true))
+	if len(fake.objects) != 0 {
+		t.Errorf("objects left after delete: %v", len(fake.objects))
+	}
+}
@@ -0,0 +1,82 @@
+package test
+
+import (
+	"bytes"
+	"go_gin/internal/domain/model"
+	"go_gin/internal/domain/model/web"
+	"go_gin/pkg/taskio"
+	"strings"
+	"testing"
+)
+
+func TestTaskioRead(t *testing.T) {
+	mapping, err := taskio.ParseMapping("Title=task_name,Title=description,Due=due_date", web.TodoListImportFields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csv := "Title,Due,priority,Ignored\nWrite report,2026-11-02,3,x\n"
+	records, err := taskio.Read(taskio.FormatCSV, strings.NewReader(csv), mapping, web.TodoListImportFields)
+	if err != nil || len(records) != 1 {
+		t.Fatalf("csv: %v records, %v", len(records), err)
+	}
+	fields := records[0].Fields
+	if records[0].Line != 2 || fields["task_name"] != "Write report" || fields["description"] != "Write report" || fields["due_date"] != "2026-11-02" || fields["priority"] != "3" || len(fields) != 4 {
+		t.Errorf("csv record %+v", records[0])
+	}
+
+	todoist := "TYPE,CONTENT,DESCRIPTION,PRIORITY,DATE\nsection,Backlog,,,\ntask,Pay rent,monthly,1,2026-11-01\ntask,Water plants,,4,every day\ntask,Call back,,4,when it rains\n"
+	records, err = taskio.Read(taskio.FormatTodoist, strings.NewReader(todoist), taskio.Mapping{}, web.TodoListImportFields)
+	if err != nil || len(records) != 3 || records[0].Fields["priority"] != "4" || records[0].Line != 3 || records[0].Fields["due_date"] != "2026-11-01" {
+		t.Fatalf("todoist: %+v, %v", records, err)
+	}
+	if records[1].Fields["recurrence"] != "FREQ=DAILY" || records[1].Fields["due_date"] == "" || len(records[1].Warnings) != 0 {
+		t.Errorf("todoist date in words: %+v", records[1])
+	}
+	if records[2].Fields["due_date"] != "" || len(records[2].Warnings) != 1 {
+		t.Errorf("todoist date that is not one: %+v", records[2])
+	}
+
+	trello := `{"lists":[{"id":"l1","name":"done"}],"cards":[{"name":"Ship","desc":"v1","due":"2026-11-03T09:00:00.000Z","dueComplete":true,"idList":"l1"},{"name":"Old","closed":true}]}`
+	records, err = taskio.Read(taskio.FormatTrello, strings.NewReader(trello), taskio.Mapping{"list": {"status"}}, web.TodoListImportFields)
+	if err != nil || len(records) != 1 || records[0].Fields["status"] != "done" || records[0].Fields["completed"] != "true" {
+		t.Errorf("trello: %+v, %v", records, err)
+	}
+	request, err := model.NewTodoListImportRequest(records[0].Fields)
+	if err != nil || request.DueDate == nil || request.DueDate.Day != 3 || request.Priority != 1 {
+		t.Errorf("trello request %+v, %v", request, err)
+	}
+
+	if _, err = taskio.ParseMapping("Title=owner", web.TodoListImportFields); err == nil {
+		t.Error("mapping to an unknown field is accepted")
+	}
+}
+
+func TestTaskioWriter(t *testing.T) {
+	tasks := []map[string]interface{}{
+		{"task_id": float64(1), "task_name": "a, b", "completed": false},
+		{"task_id": float64(2), "task_name": "c", "completed": true},
+	}
+	cases := map[string]string{
+		taskio.FormatCSV:    "task_id,task_name,completed\n1,\"a, b\",false\n2,c,true\n",
+		taskio.FormatJSON:   "[{\"completed\":false,\"task_id\":1,\"task_name\":\"a, b\"}\n,{\"completed\":true,\"task_id\":2,\"task_name\":\"c\"}\n]\n",
+		taskio.FormatNDJSON: "{\"completed\":false,\"task_id\":1,\"task_name\":\"a, b\"}\n{\"completed\":true,\"task_id\":2,\"task_name\":\"c\"}\n",
+	}
+	for format, want := range cases {
+		var out bytes.Buffer
+		writer, err := taskio.NewWriter(format, &out, []string{"task_id", "task_name", "completed"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, task := range tasks {
+			if err = writer.Write(task); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err = writer.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if out.String() != want {
+			t.Errorf("%s: got %q, want %q", format, out.String(), want)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package test
+
+import (
+	"go_gin/internal/domain/model"
+	"testing"
+	"time"
+)
+
+func TestParseDueOffset(t *testing.T) {
+	cases := map[string]int{"+3d": 3, "3d": 3, "+2w": 14, "-1d": -1, "+0d": 0}
+	for due, want := range cases {
+		if days, err := model.ParseDueOffset(due); err != nil || days != want {
+			t.Errorf("%q = %d, %v, want %d", due, days, err, want)
+		}
+	}
+	for _, due := range []string{"", "3", "+3m", "d", "+ 3d"} {
+		if _, err := model.ParseDueOffset(due); err == nil {
+			t.Errorf("%q should be refused", due)
+		}
+	}
+}
+
+func TestTemplateToTodoListRequests(t *testing.T) {
+	template := model.Template{Items: model.TemplateItems{
+		{TaskName: "laptop", Description: "order a laptop", Due: "-2d", Priority: 2},
+		{TaskName: "review", Description: "first review", Due: "+1w", Priority: 1},
+	}}
+	start := time.Date(2026, time.December, 30, 0, 0, 0, 0, time.UTC)
+	requests, err := template.ToTodoListRequests(start, model.TemplateInstantiateRequest{})
+	if err != nil || len(requests) != 2 {
+		t.Fatalf("requests = %v, %v", requests, err)
+	}
+	if due := *requests[0].DueDate; due != (model.Date{Year: 2026, Month: 12, Day: 28}) {
+		t.Errorf("laptop due = %v", due)
+	}
+	if due := *requests[1].DueDate; due != (model.Date{Year: 2027, Month: 1, Day: 6}) {
+		t.Errorf("review due = %v", due)
+	}
+}
@@ -0,0 +1,48 @@
+package test
+
+import (
+	"go_gin/internal/domain/model"
+	"testing"
+	"time"
+)
+
+func TestBuildTimeReportByWeek(t *testing.T) {
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, time.October, day, hour, minute, 0, 0, time.UTC)
+	}
+	ended := func(day, hour, minute int) *time.Time {
+		end := at(day, hour, minute)
+		return &end
+	}
+	project := 7
+	entries := model.TimeEntries{
+		// Monday 12 and Sunday 18 October fall in the same week, Monday 19 starts the next one.
+		{StartedAt: at(12, 9, 0), EndedAt: ended(12, 10, 30), ProjectID: &project},
+		{StartedAt: at(18, 22, 0), EndedAt: ended(18, 22, 45), ProjectID: &project},
+		{StartedAt: at(14, 8, 0), EndedAt: ended(14, 8, 20)},
+		{StartedAt: at(19, 9, 0), EndedAt: ended(19, 9, 10), ProjectID: &project},
+		{StartedAt: at(19, 11, 0)},
+	}
+	rows := model.BuildTimeReport(entries, "week", false)
+	if len(rows) != 3 {
+		t.Fatalf("got %d rows, want 3: %+v", len(rows), rows)
+	}
+	if rows[0].Period != "2026-10-12" || rows[0].ProjectID != nil || rows[0].Minutes != 20 {
+		t.Errorf("first row = %+v, want the 20 minutes without project", rows[0])
+	}
+	if rows[1].Period != "2026-10-12" || rows[1].ProjectID == nil || *rows[1].ProjectID != project || rows[1].Minutes != 135 {
+		t.Errorf("second row = %+v, want 135 minutes on project %d", rows[1], project)
+	}
+	if rows[2].Period != "2026-10-19" || rows[2].Minutes != 10 {
+		t.Errorf("third row = %+v, want the running timer left out", rows[2])
+	}
+
+	data, err := rows.CSV()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "period,project_id,user_id,minutes\n2026-10-12,,,20\n2026-10-12,7,,135\n2026-10-19,7,,10\n"
+	if string(data) != want {
+		t.Errorf("csv = %q, want %q", data, want)
+	}
+}
@@ -0,0 +1,32 @@
+package test
+
+import (
+	"github.com/google/uuid"
+	"go_gin/internal/config"
+	"go_gin/pkg/helper"
+	"testing"
+	"time"
+)
+
+func TestUndoToken(t *testing.T) {
+	userID := uuid.New()
+	deletedAt := time.Now().Truncate(time.Microsecond)
+	token := helper.NewUndoToken(userID, deletedAt)
+
+	parsed, err := helper.ParseUndoToken(token, userID, deletedAt.Add(time.Minute))
+	if err != nil || !parsed.Equal(deletedAt) {
+		t.Fatalf("parsed %v, %v, want %v", parsed, err, deletedAt)
+	}
+	if _, err := helper.ParseUndoToken(token, uuid.New(), deletedAt); err != helper.ErrUndoTokenInvalid {
+		t.Errorf("token of another user: %v, want %v", err, helper.ErrUndoTokenInvalid)
+	}
+	tampered := []byte(token)
+	tampered[5] ^= 1
+	if _, err := helper.ParseUndoToken(string(tampered), userID, deletedAt); err != helper.ErrUndoTokenInvalid {
+		t.Errorf("tampered token: %v, want %v", err, helper.ErrUndoTokenInvalid)
+	}
+	late := deletedAt.Add(time.Duration(config.Trash.UndoMinutes)*time.Minute + time.Second)
+	if _, err := helper.ParseUndoToken(token, userID, late); err != helper.ErrUndoTokenExpired {
+		t.Errorf("late undo: %v, want %v", err, helper.ErrUndoTokenExpired)
+	}
+}